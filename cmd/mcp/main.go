@@ -68,6 +68,7 @@ func main() {
 
 	// Initialize YouTube service with fallback support
 	baseService := youtube.NewService(cfg.YouTube, cacheInstance, logger)
+	baseService.SetMetadataTTL(cfg.Cache.MetadataTTL)
 	youtubeService := youtube.NewEnhancedService(baseService)
 
 	// Initialize MCP server
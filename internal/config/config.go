@@ -37,23 +37,38 @@ type ServerConfig struct {
 
 // YouTubeConfig represents YouTube-specific configuration
 type YouTubeConfig struct {
-	UserAgent           string        `json:"user_agent"`
-	YoutubeDLPath       string        `json:"youtubedl_path"`
-	CookieFile          string        `json:"cookie_file"`
-	APIKey              string        `json:"api_key"`
-	ProxyURL            string        `json:"proxy_url"`
-	DefaultLanguages    []string      `json:"default_languages"`
-	ProxyList           []string      `json:"proxy_list"`
-	RetryDelay          time.Duration `json:"retry_delay"`
-	RateLimitPerHour    int           `json:"rate_limit_per_hour"`
-	RateLimitPerMinute  int           `json:"rate_limit_per_minute"`
-	RetryBackoffFactor  float64       `json:"retry_backoff_factor"`
-	MaxConcurrent       int           `json:"max_concurrent"`
-	RetryAttempts       int           `json:"retry_attempts"`
-	RequestTimeout      time.Duration `json:"request_timeout"`
-	EnableProxyRotation bool          `json:"enable_proxy_rotation"`
-	EnableCookies       bool          `json:"enable_cookies"`
-	EnableYoutubeDL     bool          `json:"enable_youtubedl"`
+	UserAgent     string `json:"user_agent"`
+	YoutubeDLPath string `json:"youtubedl_path"`
+	CookieFile    string `json:"cookie_file"`
+	APIKey        string `json:"api_key"`
+	ProxyURL      string `json:"proxy_url"`
+	// TranslationProvider selects the external translator used when YouTube cannot translate a
+	// track: "libretranslate", "deepl", or empty to disable
+	TranslationProvider  string `json:"translation_provider"`
+	TranslationURL       string `json:"translation_url"`
+	TranslationAPIKey    string `json:"translation_api_key"`
+	TranslationBatchSize int    `json:"translation_batch_size"`
+	// LibraryPath is the directory of the on-disk transcript index searched by search_library;
+	// empty disables the library
+	LibraryPath string `json:"library_path"`
+	// EmbeddingURL is the base URL of an OpenAI-compatible /v1/embeddings API used by
+	// semantic_search; with only an API key the OpenAI API is used
+	EmbeddingURL          string        `json:"embedding_url"`
+	EmbeddingAPIKey       string        `json:"embedding_api_key"`
+	EmbeddingModel        string        `json:"embedding_model"`
+	EmbeddingChunkSeconds int           `json:"embedding_chunk_seconds"`
+	DefaultLanguages      []string      `json:"default_languages"`
+	ProxyList             []string      `json:"proxy_list"`
+	RetryDelay            time.Duration `json:"retry_delay"`
+	RateLimitPerHour      int           `json:"rate_limit_per_hour"`
+	RateLimitPerMinute    int           `json:"rate_limit_per_minute"`
+	RetryBackoffFactor    float64       `json:"retry_backoff_factor"`
+	MaxConcurrent         int           `json:"max_concurrent"`
+	RetryAttempts         int           `json:"retry_attempts"`
+	RequestTimeout        time.Duration `json:"request_timeout"`
+	EnableProxyRotation   bool          `json:"enable_proxy_rotation"`
+	EnableCookies         bool          `json:"enable_cookies"`
+	EnableYoutubeDL       bool          `json:"enable_youtubedl"`
 }
 
 // MCPConfig represents MCP-specific configuration
@@ -144,17 +159,20 @@ func DefaultConfig() *Config {
 			EnableGzip:      true,
 		},
 		YouTube: YouTubeConfig{
-			DefaultLanguages:   []string{"en", "ja", "es", "fr", "de"},
-			RequestTimeout:     30 * time.Second,
-			RetryAttempts:      3,
-			RetryDelay:         time.Second,
-			RetryBackoffFactor: 2.0,
-			RateLimitPerMinute: 60,
-			RateLimitPerHour:   1000,
-			UserAgent:          "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
-			MaxConcurrent:      10,
-			EnableCookies:      false,
-			EnableYoutubeDL:    false,
+			DefaultLanguages:      []string{"en", "ja", "es", "fr", "de"},
+			RequestTimeout:        30 * time.Second,
+			RetryAttempts:         3,
+			RetryDelay:            time.Second,
+			RetryBackoffFactor:    2.0,
+			RateLimitPerMinute:    60,
+			RateLimitPerHour:      1000,
+			UserAgent:             "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			MaxConcurrent:         10,
+			TranslationBatchSize:  50,
+			EmbeddingModel:        "text-embedding-3-small",
+			EmbeddingChunkSeconds: 30,
+			EnableCookies:         false,
+			EnableYoutubeDL:       false,
 		},
 		MCP: MCPConfig{
 			Version:        "2024-11-05",
@@ -169,6 +187,21 @@ func DefaultConfig() *Config {
 				"translate_transcript":     true,
 				"format_transcript":        true,
 				"list_available_languages": true,
+				"search_transcript":        true,
+				"get_transcript_range":     true,
+				"get_chapters":             true,
+				"get_video_metadata":       true,
+				"get_playlist_transcripts": true,
+				"list_channel_videos":      true,
+				"search_videos":            true,
+				"get_live_chat_replay":     true,
+				"get_bilingual_transcript": true,
+				"compare_transcripts":      true,
+				"analyze_transcript":       true,
+				"extract_keywords":         true,
+				"search_library":           true,
+				"semantic_search":          true,
+				"locate_quote":             true,
 			},
 			EnableResources: false,
 			EnablePrompts:   false,
@@ -259,6 +292,15 @@ func Load() (*Config, error) {
 	cfg.YouTube.EnableCookies = getEnvBool("YOUTUBE_ENABLE_COOKIES", cfg.YouTube.EnableCookies)
 	cfg.YouTube.YoutubeDLPath = getEnvString("YOUTUBE_DL_PATH", cfg.YouTube.YoutubeDLPath)
 	cfg.YouTube.EnableYoutubeDL = getEnvBool("YOUTUBE_ENABLE_YOUTUBEDL", cfg.YouTube.EnableYoutubeDL)
+	cfg.YouTube.TranslationProvider = getEnvString("YOUTUBE_TRANSLATION_PROVIDER", cfg.YouTube.TranslationProvider)
+	cfg.YouTube.TranslationURL = getEnvString("YOUTUBE_TRANSLATION_URL", cfg.YouTube.TranslationURL)
+	cfg.YouTube.TranslationAPIKey = getEnvString("YOUTUBE_TRANSLATION_API_KEY", cfg.YouTube.TranslationAPIKey)
+	cfg.YouTube.TranslationBatchSize = getEnvInt("YOUTUBE_TRANSLATION_BATCH_SIZE", cfg.YouTube.TranslationBatchSize)
+	cfg.YouTube.LibraryPath = getEnvString("YOUTUBE_LIBRARY_PATH", cfg.YouTube.LibraryPath)
+	cfg.YouTube.EmbeddingURL = getEnvString("YOUTUBE_EMBEDDING_URL", cfg.YouTube.EmbeddingURL)
+	cfg.YouTube.EmbeddingAPIKey = getEnvString("YOUTUBE_EMBEDDING_API_KEY", cfg.YouTube.EmbeddingAPIKey)
+	cfg.YouTube.EmbeddingModel = getEnvString("YOUTUBE_EMBEDDING_MODEL", cfg.YouTube.EmbeddingModel)
+	cfg.YouTube.EmbeddingChunkSeconds = getEnvInt("YOUTUBE_EMBEDDING_CHUNK_SECONDS", cfg.YouTube.EmbeddingChunkSeconds)
 
 	// MCP configuration
 	cfg.MCP.Version = getEnvString("MCP_VERSION", cfg.MCP.Version)
@@ -368,6 +410,24 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid rate limit per hour: %d", c.YouTube.RateLimitPerHour)
 	}
 
+	switch c.YouTube.TranslationProvider {
+	case "", "libretranslate", "deepl":
+	default:
+		return fmt.Errorf("invalid translation provider: %s", c.YouTube.TranslationProvider)
+	}
+
+	if c.YouTube.TranslationProvider == "libretranslate" && c.YouTube.TranslationURL == "" {
+		return fmt.Errorf("translation URL is required for libretranslate")
+	}
+
+	if c.YouTube.TranslationProvider == "deepl" && c.YouTube.TranslationAPIKey == "" {
+		return fmt.Errorf("translation API key is required for deepl")
+	}
+
+	if c.YouTube.EmbeddingChunkSeconds < 0 {
+		return fmt.Errorf("invalid embedding chunk seconds: %d", c.YouTube.EmbeddingChunkSeconds)
+	}
+
 	if c.Cache.MaxSize < 0 {
 		return fmt.Errorf("invalid cache max size: %d", c.Cache.MaxSize)
 	}
@@ -66,6 +66,7 @@ func TestLoadWithEnvironmentVariables(t *testing.T) {
 		"REDIS_URL":                 "redis://test:6379",
 		"SECURITY_ENABLE_AUTH":      "true",
 		"SECURITY_API_KEYS":         "key1,key2,key3",
+		"CACHE_METADATA_TTL":        "30m",
 	}
 
 	// Set environment variables
@@ -107,6 +108,9 @@ func TestLoadWithEnvironmentVariables(t *testing.T) {
 	if cfg.Cache.RedisURL != "redis://test:6379" {
 		t.Errorf("Expected Redis URL 'redis://test:6379', got %s", cfg.Cache.RedisURL)
 	}
+	if cfg.Cache.MetadataTTL != 30*time.Minute {
+		t.Errorf("Expected metadata TTL 30m, got %v", cfg.Cache.MetadataTTL)
+	}
 	if !cfg.Security.EnableAuth {
 		t.Error("Expected auth to be enabled")
 	}
@@ -194,6 +198,30 @@ func TestConfigValidation(t *testing.T) {
 			wantErr: true,
 			errMsg:  "invalid log sampling rate",
 		},
+		{
+			name: "invalid translation provider",
+			setupFunc: func(cfg *Config) {
+				cfg.YouTube.TranslationProvider = "babelfish"
+			},
+			wantErr: true,
+			errMsg:  "invalid translation provider",
+		},
+		{
+			name: "libretranslate without URL",
+			setupFunc: func(cfg *Config) {
+				cfg.YouTube.TranslationProvider = "libretranslate"
+			},
+			wantErr: true,
+			errMsg:  "translation URL is required",
+		},
+		{
+			name: "negative embedding chunk seconds",
+			setupFunc: func(cfg *Config) {
+				cfg.YouTube.EmbeddingChunkSeconds = -1
+			},
+			wantErr: true,
+			errMsg:  "invalid embedding chunk seconds",
+		},
 		{
 			name: "valid config",
 			setupFunc: func(cfg *Config) {
@@ -0,0 +1,310 @@
+// Package library provides an embedded full-text index of fetched transcripts that persists on disk.
+package library
+
+import (
+	"encoding/gob"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// storageVersion is bumped whenever the file format or the tokenizer changes; files of other
+// versions are ignored and rebuilt as their videos are fetched again
+const storageVersion = 2
+
+// fileExtension is the extension of the per-transcript index files
+const fileExtension = ".gob"
+
+// Document is a transcript stored in the library
+type Document struct {
+	VideoID     string
+	Title       string
+	ChannelID   string
+	ChannelName string
+	Language    string
+	PublishedAt string
+	IndexedAt   time.Time
+	Segments    []Segment
+}
+
+// Segment is a caption segment of a stored transcript
+type Segment struct {
+	Text  string
+	Start float64
+	End   float64
+}
+
+// storedDocument is the on-disk form of one transcript: its stored fields and its slice of the
+// inverted index, so nothing needs to be tokenized again when the library is opened
+type storedDocument struct {
+	Version  int
+	Document Document
+	Postings map[string][]storedPosting
+	Lengths  []int32 // tokens per segment
+}
+
+// storedPosting lists where a term occurs in one segment
+type storedPosting struct {
+	Segment   int32
+	Positions []int32
+}
+
+// posting lists where a term occurs in one segment of an indexed document
+type posting struct {
+	document  int
+	segment   int32
+	positions []int32
+}
+
+// indexedDocument is a document loaded into the in-memory index
+type indexedDocument struct {
+	Document
+	lengths []int32
+	terms   []string
+}
+
+// Index is an inverted index over transcript segments. Each transcript is stored in its own file
+// under the index directory, and all of them are loaded into memory when the index is opened.
+type Index struct {
+	logger    *slog.Logger
+	documents map[int]*indexedDocument
+	keys      map[string]int
+	postings  map[string][]posting
+	dir       string
+	nextID    int
+	segments  int
+	tokens    int
+	mu        sync.RWMutex
+}
+
+// Open opens the index in dir, creating the directory if needed. Unreadable files are skipped.
+func Open(dir string, logger *slog.Logger) (*Index, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create library directory: %w", err)
+	}
+
+	index := &Index{
+		logger:    logger,
+		documents: make(map[int]*indexedDocument),
+		keys:      make(map[string]int),
+		postings:  make(map[string][]posting),
+		dir:       dir,
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read library directory: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != fileExtension {
+			continue
+		}
+		var stored storedDocument
+		if err := readFile(filepath.Join(dir, entry.Name()), &stored); err != nil {
+			logger.Warn("Skipping unreadable library file", "file", entry.Name(), "error", err)
+			continue
+		}
+		if stored.Version != storageVersion {
+			continue
+		}
+		index.insert(strings.TrimSuffix(entry.Name(), fileExtension), &stored)
+	}
+
+	logger.Info("Transcript library opened", "path", dir, "videos", len(index.documents))
+	return index, nil
+}
+
+// Add indexes a transcript, replacing any earlier version of the same video and language
+func (ix *Index) Add(transcript *models.TranscriptResponse) error {
+	stored := buildStoredDocument(transcript)
+	key := documentKey(transcript.VideoID, transcript.Language)
+
+	if err := writeFile(filepath.Join(ix.dir, key+fileExtension), stored); err != nil {
+		return err
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.insert(key, stored)
+	return nil
+}
+
+// Size returns the number of transcripts in the index
+func (ix *Index) Size() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.documents)
+}
+
+// Documents returns the transcripts in the index, ordered by video and language
+func (ix *Index) Documents() []Document {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	documents := make([]Document, 0, len(ix.documents))
+	for _, document := range ix.documents {
+		documents = append(documents, document.Document)
+	}
+	sort.Slice(documents, func(i, j int) bool {
+		if documents[i].VideoID != documents[j].VideoID {
+			return documents[i].VideoID < documents[j].VideoID
+		}
+		return documents[i].Language < documents[j].Language
+	})
+	return documents
+}
+
+// insert adds a stored document to the in-memory index. The caller must hold the write lock.
+func (ix *Index) insert(key string, stored *storedDocument) {
+	if id, ok := ix.keys[key]; ok {
+		ix.remove(id)
+	}
+
+	id := ix.nextID
+	ix.nextID++
+
+	document := &indexedDocument{
+		Document: stored.Document,
+		lengths:  stored.Lengths,
+		terms:    make([]string, 0, len(stored.Postings)),
+	}
+	for term, storedPostings := range stored.Postings {
+		document.terms = append(document.terms, term)
+		for _, storedPosting := range storedPostings {
+			ix.postings[term] = append(ix.postings[term], posting{
+				document:  id,
+				segment:   storedPosting.Segment,
+				positions: storedPosting.Positions,
+			})
+		}
+	}
+	for _, length := range stored.Lengths {
+		ix.tokens += int(length)
+	}
+	ix.segments += len(stored.Lengths)
+
+	ix.documents[id] = document
+	ix.keys[key] = id
+}
+
+// remove drops a document and its postings from the in-memory index
+func (ix *Index) remove(id int) {
+	document := ix.documents[id]
+	for _, term := range document.terms {
+		kept := ix.postings[term][:0]
+		for _, p := range ix.postings[term] {
+			if p.document != id {
+				kept = append(kept, p)
+			}
+		}
+		if len(kept) == 0 {
+			delete(ix.postings, term)
+		} else {
+			ix.postings[term] = kept
+		}
+	}
+	for _, length := range document.lengths {
+		ix.tokens -= int(length)
+	}
+	ix.segments -= len(document.lengths)
+
+	delete(ix.keys, documentKey(document.VideoID, document.Language))
+	delete(ix.documents, id)
+}
+
+// buildStoredDocument tokenizes a transcript into its stored form
+func buildStoredDocument(transcript *models.TranscriptResponse) *storedDocument {
+	stored := &storedDocument{
+		Version: storageVersion,
+		Document: Document{
+			VideoID:     transcript.VideoID,
+			Title:       transcript.Title,
+			ChannelID:   transcript.Metadata.ChannelID,
+			ChannelName: transcript.Metadata.ChannelName,
+			Language:    transcript.Language,
+			PublishedAt: transcript.Metadata.PublishedAt,
+			IndexedAt:   time.Now().UTC(),
+			Segments:    make([]Segment, 0, len(transcript.Transcript)),
+		},
+		Postings: make(map[string][]storedPosting),
+		Lengths:  make([]int32, 0, len(transcript.Transcript)),
+	}
+
+	for i, segment := range transcript.Transcript {
+		end := segment.End
+		if end == 0 {
+			end = segment.Start + segment.Duration
+		}
+		stored.Document.Segments = append(stored.Document.Segments, Segment{Text: segment.Text, Start: segment.Start, End: end})
+
+		tokens := tokenize(segment.Text)
+		stored.Lengths = append(stored.Lengths, int32(len(tokens)))
+
+		positions := make(map[string][]int32)
+		for position, token := range tokens {
+			positions[token] = append(positions[token], int32(position))
+		}
+		for term, termPositions := range positions {
+			stored.Postings[term] = append(stored.Postings[term], storedPosting{Segment: int32(i), Positions: termPositions})
+		}
+	}
+
+	return stored
+}
+
+// documentKey names the file of a video's transcript in one language, keeping only characters
+// that are safe in file names
+func documentKey(videoID, language string) string {
+	safe := func(r rune) rune {
+		if r == '-' || r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return '_'
+	}
+	return strings.Map(safe, videoID) + "." + strings.Map(safe, language)
+}
+
+// readFile decodes a library file into value
+func readFile(path string, value any) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return gob.NewDecoder(file).Decode(value)
+}
+
+// writeFile encodes value into a library file through a temporary file so that readers never see
+// a partially written file
+func writeFile(path string, value any) error {
+	file, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create library file: %w", err)
+	}
+	tempPath := file.Name()
+
+	if err := gob.NewEncoder(file).Encode(value); err != nil {
+		_ = file.Close()
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to encode library file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to write library file: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to replace library file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package library
+
+import (
+	"log/slog"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func testTranscript(videoID, language, channel, published string, texts ...string) *models.TranscriptResponse {
+	segments := make([]models.TranscriptSegment, len(texts))
+	for i, text := range texts {
+		segments[i] = models.TranscriptSegment{Text: text, Start: float64(i * 5), Duration: 4}
+	}
+	return &models.TranscriptResponse{
+		VideoID:    videoID,
+		Title:      "Video " + videoID,
+		Language:   language,
+		Transcript: segments,
+		Metadata: models.TranscriptMetadata{
+			ChannelID:   "UC" + channel,
+			ChannelName: channel,
+			PublishedAt: published,
+		},
+	}
+}
+
+func TestIndex_PersistsAcrossOpen(t *testing.T) {
+	dir := t.TempDir()
+	index, err := Open(dir, slog.Default())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if err := index.Add(testTranscript("video1", "en", "Science", "2024-01-10", "neural networks learn", "gradient descent")); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	reopened, err := Open(dir, slog.Default())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if reopened.Size() != 1 {
+		t.Fatalf("Size() = %d, want 1", reopened.Size())
+	}
+	hits, total, err := reopened.Search(models.LibrarySearchOptions{Query: "gradient"})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if total != 1 || hits[0].VideoID != "video1" || hits[0].Start != 5 || hits[0].End != 9 {
+		t.Errorf("Search() = %+v (total %d)", hits, total)
+	}
+}
+
+func TestIndex_AddReplacesTranscript(t *testing.T) {
+	index, err := Open(t.TempDir(), slog.Default())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if err := index.Add(testTranscript("video1", "en", "Science", "", "old words")); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := index.Add(testTranscript("video1", "en", "Science", "", "new words")); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if index.Size() != 1 {
+		t.Errorf("Size() = %d, want 1", index.Size())
+	}
+	if _, total, _ := index.Search(models.LibrarySearchOptions{Query: "old"}); total != 0 {
+		t.Errorf("replaced text still matches %d segments", total)
+	}
+	if _, total, _ := index.Search(models.LibrarySearchOptions{Query: "words"}); total != 1 {
+		t.Errorf("Search(words) total = %d, want 1", total)
+	}
+}
+
+func TestIndex_Search(t *testing.T) {
+	index, err := Open(t.TempDir(), slog.Default())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	transcripts := []*models.TranscriptResponse{
+		testTranscript("video1", "en", "Science Channel", "2024-01-10", "neural networks are everywhere", "networks of neurons"),
+		testTranscript("video2", "en-GB", "Cooking", "2024-06-01", "a neural recipe for bread", "bread and butter"),
+		testTranscript("video3", "zh", "Science Channel", "2023-05-05", "我们学习机器学习的方法"),
+	}
+	for _, transcript := range transcripts {
+		if err := index.Add(transcript); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	date := func(value string) *time.Time {
+		parsed, _ := models.ParseDate(value)
+		return &parsed
+	}
+
+	tests := []struct {
+		name string
+		opts models.LibrarySearchOptions
+		want []string // video ID and segment start of each hit, in any order
+	}{
+		{name: "single word", opts: models.LibrarySearchOptions{Query: "neural"}, want: []string{"video1@0", "video2@0"}},
+		{name: "implicit and", opts: models.LibrarySearchOptions{Query: "neural bread"}, want: []string{"video2@0"}},
+		{name: "phrase", opts: models.LibrarySearchOptions{Query: `"neural networks"`}, want: []string{"video1@0"}},
+		{name: "phrase out of order", opts: models.LibrarySearchOptions{Query: `"networks neural"`}, want: nil},
+		{name: "or", opts: models.LibrarySearchOptions{Query: "neurons OR butter"}, want: []string{"video1@5", "video2@5"}},
+		{name: "exclusion", opts: models.LibrarySearchOptions{Query: "neural -bread"}, want: []string{"video1@0"}},
+		{name: "not with parentheses", opts: models.LibrarySearchOptions{Query: "networks NOT (neural OR everywhere)"}, want: []string{"video1@5"}},
+		{name: "channel name", opts: models.LibrarySearchOptions{Query: "neural", Channel: "science"}, want: []string{"video1@0"}},
+		{name: "channel id", opts: models.LibrarySearchOptions{Query: "neural", Channel: "UCCooking"}, want: []string{"video2@0"}},
+		{name: "language prefix", opts: models.LibrarySearchOptions{Query: "neural", Language: "en-gb"}, want: []string{"video2@0"}},
+		{name: "published after", opts: models.LibrarySearchOptions{Query: "neural", PublishedAfter: date("2024-03-01")}, want: []string{"video2@0"}},
+		{name: "published before", opts: models.LibrarySearchOptions{Query: "neural", PublishedBefore: date("2024-03-01")}, want: []string{"video1@0"}},
+		{name: "chinese word inside run", opts: models.LibrarySearchOptions{Query: "机器学习"}, want: []string{"video3@0"}},
+		{name: "chinese missing word", opts: models.LibrarySearchOptions{Query: "学机"}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, total, err := index.Search(tt.opts)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			got := make(map[string]bool, len(hits))
+			for _, hit := range hits {
+				got[hit.VideoID+"@"+formatStart(hit.Start)] = true
+			}
+			if total != len(tt.want) || len(got) != len(tt.want) {
+				t.Fatalf("Search() = %v (total %d), want %v", got, total, tt.want)
+			}
+			for _, want := range tt.want {
+				if !got[want] {
+					t.Errorf("Search() = %v, missing %s", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestIndex_SearchRanksAndTruncates(t *testing.T) {
+	index, err := Open(t.TempDir(), slog.Default())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	transcript := testTranscript("video1", "en", "Science", "", "gradient gradient gradient", "one gradient among many other words here", "gradient again")
+	if err := index.Add(transcript); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	hits, total, err := index.Search(models.LibrarySearchOptions{Query: "gradient", MaxResults: 2})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if total != 3 || len(hits) != 2 {
+		t.Fatalf("Search() returned %d hits of %d, want 2 of 3", len(hits), total)
+	}
+	if hits[0].Start != 0 || hits[0].Score <= hits[1].Score {
+		t.Errorf("Search() ranking = %+v", hits)
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	tests := []string{
+		"-bread",
+		"NOT bread",
+		"(neural",
+		"neural)",
+		"!!!",
+		"neural OR",
+	}
+
+	for _, query := range tests {
+		t.Run(query, func(t *testing.T) {
+			if _, err := parseQuery(query); err == nil {
+				t.Errorf("parseQuery(%q) expected an error", query)
+			}
+		})
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{text: "Don’t STOP, e-mail!", want: []string{"don't", "stop", "e", "mail"}},
+		{text: "机器学习", want: []string{"机器", "器学", "学习"}},
+		{text: "中", want: []string{"中"}},
+		{text: "コーヒーを飲む", want: []string{"コーヒー", "を", "飲", "む"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got := tokenize(tt.text)
+			if len(got) != len(tt.want) {
+				t.Fatalf("tokenize(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("tokenize(%q) = %q, want %q", tt.text, got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func formatStart(start float64) string {
+	return strconv.FormatFloat(start, 'f', -1, 64)
+}
@@ -0,0 +1,399 @@
+package library
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// BM25 parameters for ranking segments
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// hitKey identifies a segment of an indexed document
+type hitKey struct {
+	document int
+	segment  int32
+}
+
+// queryNode is a parsed query expression that scores the segments it matches
+type queryNode interface {
+	evaluate(ix *Index) map[hitKey]float64
+}
+
+// phraseNode matches segments containing its terms next to each other; a single term is a phrase
+// of one
+type phraseNode struct {
+	terms []string
+}
+
+// andNode matches segments matched by all of its included nodes and none of its excluded ones
+type andNode struct {
+	included []queryNode
+	excluded []queryNode
+}
+
+// orNode matches segments matched by any of its nodes
+type orNode struct {
+	nodes []queryNode
+}
+
+// Search returns the segments matching a query, best first, and the total number of matches.
+// Queries combine words, "quoted phrases", AND, OR, NOT or a leading minus, and parentheses;
+// words next to each other must all match.
+func (ix *Index) Search(opts models.LibrarySearchOptions) ([]models.LibraryHit, int, error) {
+	node, err := parseQuery(opts.Query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	scores := node.evaluate(ix)
+	keys := make([]hitKey, 0, len(scores))
+	for key := range scores {
+		if ix.matchesFilters(ix.documents[key.document], opts) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if scores[keys[i]] != scores[keys[j]] {
+			return scores[keys[i]] > scores[keys[j]]
+		}
+		first, second := ix.documents[keys[i].document], ix.documents[keys[j].document]
+		if first.VideoID != second.VideoID {
+			return first.VideoID < second.VideoID
+		}
+		return keys[i].segment < keys[j].segment
+	})
+
+	total := len(keys)
+	if opts.MaxResults > 0 && len(keys) > opts.MaxResults {
+		keys = keys[:opts.MaxResults]
+	}
+
+	hits := make([]models.LibraryHit, 0, len(keys))
+	for _, key := range keys {
+		document := ix.documents[key.document]
+		segment := document.Segments[key.segment]
+		hits = append(hits, models.LibraryHit{
+			VideoID:     document.VideoID,
+			Title:       document.Title,
+			ChannelName: document.ChannelName,
+			Language:    document.Language,
+			PublishedAt: document.PublishedAt,
+			Text:        segment.Text,
+			Start:       segment.Start,
+			End:         segment.End,
+			Score:       math.Round(scores[key]*10000) / 10000,
+		})
+	}
+	return hits, total, nil
+}
+
+// matchesFilters reports whether a document passes the channel, language and date filters
+func (ix *Index) matchesFilters(document *indexedDocument, opts models.LibrarySearchOptions) bool {
+	if !document.Matches(opts.Channel, opts.Language) {
+		return false
+	}
+	if opts.PublishedAfter != nil || opts.PublishedBefore != nil {
+		published, err := models.ParseDate(document.PublishedAt)
+		if err != nil {
+			return false
+		}
+		if opts.PublishedAfter != nil && published.Before(*opts.PublishedAfter) {
+			return false
+		}
+		if opts.PublishedBefore != nil && published.After(*opts.PublishedBefore) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches reports whether a document belongs to a channel, given by ID or part of its name, and is
+// in a language, given exactly or without its region. Empty filters match every document.
+func (d *Document) Matches(channel, language string) bool {
+	if channel != "" && d.ChannelID != channel &&
+		!strings.Contains(strings.ToLower(d.ChannelName), strings.ToLower(channel)) {
+		return false
+	}
+	if language != "" {
+		documentLanguage, requested := strings.ToLower(d.Language), strings.ToLower(language)
+		if documentLanguage != requested && !strings.HasPrefix(documentLanguage, requested+"-") {
+			return false
+		}
+	}
+	return true
+}
+
+// termScore is the BM25 score of a term occurring tf times in a segment of the given length
+func (ix *Index) termScore(term string, tf int, length int32) float64 {
+	segments := float64(ix.segments)
+	documentFrequency := float64(len(ix.postings[term]))
+	idf := math.Log(1 + (segments-documentFrequency+0.5)/(documentFrequency+0.5))
+
+	averageLength := float64(ix.tokens) / max(segments, 1)
+	norm := bm25K1 * (1 - bm25B + bm25B*float64(length)/max(averageLength, 1))
+	return idf * float64(tf) * (bm25K1 + 1) / (float64(tf) + norm)
+}
+
+// evaluate implements queryNode
+func (n *phraseNode) evaluate(ix *Index) map[hitKey]float64 {
+	// Positions of each term per segment, starting from the first term's segments
+	positions := make([]map[hitKey][]int32, len(n.terms))
+	for i, term := range n.terms {
+		positions[i] = make(map[hitKey][]int32, len(ix.postings[term]))
+		for _, p := range ix.postings[term] {
+			positions[i][hitKey{p.document, p.segment}] = p.positions
+		}
+	}
+
+	scores := make(map[hitKey]float64)
+	for key, starts := range positions[0] {
+		occurrences := 0
+		for _, start := range starts {
+			matched := true
+			for i := 1; i < len(n.terms) && matched; i++ {
+				matched = containsPosition(positions[i][key], start+int32(i))
+			}
+			if matched {
+				occurrences++
+			}
+		}
+		if occurrences == 0 {
+			continue
+		}
+		length := ix.documents[key.document].lengths[key.segment]
+		for _, term := range n.terms {
+			scores[key] += ix.termScore(term, occurrences, length)
+		}
+	}
+	return scores
+}
+
+// evaluate implements queryNode
+func (n *andNode) evaluate(ix *Index) map[hitKey]float64 {
+	scores := n.included[0].evaluate(ix)
+	for _, node := range n.included[1:] {
+		other := node.evaluate(ix)
+		for key, score := range scores {
+			if otherScore, ok := other[key]; ok {
+				scores[key] = score + otherScore
+			} else {
+				delete(scores, key)
+			}
+		}
+	}
+	for _, node := range n.excluded {
+		for key := range node.evaluate(ix) {
+			delete(scores, key)
+		}
+	}
+	return scores
+}
+
+// evaluate implements queryNode
+func (n *orNode) evaluate(ix *Index) map[hitKey]float64 {
+	scores := make(map[hitKey]float64)
+	for _, node := range n.nodes {
+		for key, score := range node.evaluate(ix) {
+			scores[key] += score
+		}
+	}
+	return scores
+}
+
+// containsPosition reports whether sorted positions contain position
+func containsPosition(positions []int32, position int32) bool {
+	i := sort.Search(len(positions), func(i int) bool { return positions[i] >= position })
+	return i < len(positions) && positions[i] == position
+}
+
+// queryToken is a lexical token of a query
+type queryToken struct {
+	text   string
+	quoted bool
+}
+
+// queryParser is a recursive descent parser over query tokens
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+// parseQuery parses a query string into an expression
+func parseQuery(query string) (queryNode, error) {
+	parser := &queryParser{tokens: lexQuery(query)}
+	node, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos < len(parser.tokens) {
+		return nil, fmt.Errorf("unexpected %q in query", parser.tokens[parser.pos].text)
+	}
+	if node == nil {
+		return nil, fmt.Errorf("query has no searchable words")
+	}
+	return node, nil
+}
+
+// lexQuery splits a query into words, quoted phrases, parentheses and minus signs
+func lexQuery(query string) []queryToken {
+	var tokens []queryToken
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, queryToken{text: string(r)})
+			i++
+		case r == '"' || r == '“' || r == '”':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' && runes[end] != '”' && runes[end] != '“' {
+				end++
+			}
+			tokens = append(tokens, queryToken{text: string(runes[i+1 : end]), quoted: true})
+			i = end + 1
+		case r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) && (i == 0 || unicode.IsSpace(runes[i-1]) || runes[i-1] == '('):
+			tokens = append(tokens, queryToken{text: "-"})
+			i++
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune(`()"“”`, runes[end]) {
+				end++
+			}
+			tokens = append(tokens, queryToken{text: string(runes[i:end])})
+			i = end
+		}
+	}
+	return tokens
+}
+
+// peek returns the next unquoted operator or word, or "" at the end
+func (p *queryParser) peek() string {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].quoted {
+		return ""
+	}
+	return p.tokens[p.pos].text
+}
+
+// parseOr parses: and ("OR" and)*
+func (p *queryParser) parseOr() (queryNode, error) {
+	var nodes []queryNode
+	for {
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if node != nil {
+			nodes = append(nodes, node)
+		}
+		if p.peek() != "OR" {
+			break
+		}
+		p.pos++
+		if p.pos >= len(p.tokens) || p.peek() == ")" {
+			return nil, fmt.Errorf("query ends after an operator")
+		}
+	}
+
+	switch len(nodes) {
+	case 0:
+		return nil, nil
+	case 1:
+		return nodes[0], nil
+	default:
+		return &orNode{nodes: nodes}, nil
+	}
+}
+
+// parseAnd parses a run of terms joined by AND or by nothing, some of them negated
+func (p *queryParser) parseAnd() (queryNode, error) {
+	and := &andNode{}
+	for p.pos < len(p.tokens) {
+		switch p.peek() {
+		case "OR", ")":
+			return and.simplify()
+		case "AND":
+			p.pos++
+			continue
+		}
+
+		negated := false
+		for p.peek() == "-" || p.peek() == "NOT" {
+			negated = !negated
+			p.pos++
+		}
+
+		node, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			continue
+		}
+		if negated {
+			and.excluded = append(and.excluded, node)
+		} else {
+			and.included = append(and.included, node)
+		}
+	}
+	return and.simplify()
+}
+
+// simplify returns the node an AND run stands for, or an error for a run of exclusions only
+func (n *andNode) simplify() (queryNode, error) {
+	switch {
+	case len(n.included) == 0 && len(n.excluded) > 0:
+		return nil, fmt.Errorf("a query cannot only exclude words")
+	case len(n.included) == 0:
+		return nil, nil
+	case len(n.included) == 1 && len(n.excluded) == 0:
+		return n.included[0], nil
+	default:
+		return n, nil
+	}
+}
+
+// parsePrimary parses a parenthesized expression, a quoted phrase or a word. Words that the
+// tokenizer splits, such as "e-mail" or Japanese text, become phrases. It returns nil for
+// punctuation that has no terms.
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("query ends after an operator")
+	}
+
+	token := p.tokens[p.pos]
+	p.pos++
+
+	if !token.quoted && token.text == "(" {
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis in query")
+		}
+		p.pos++
+		return node, nil
+	}
+	if !token.quoted && token.text == ")" {
+		return nil, fmt.Errorf("unexpected ) in query")
+	}
+
+	terms := tokenize(token.text)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	return &phraseNode{terms: terms}, nil
+}
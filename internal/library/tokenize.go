@@ -0,0 +1,23 @@
+package library
+
+import (
+	"github.com/youtube-transcript-mcp/internal/textseg"
+)
+
+// tokenize splits text into lower-cased index terms with the shared tokenizer. Ideograph runs are
+// split further into overlapping character bigrams, which lets any word inside a run be found as a
+// phrase.
+func tokenize(text string) []string {
+	var terms []string
+	for _, token := range textseg.Tokens(text) {
+		runes := []rune(token.Text)
+		if token.Class != textseg.ClassHan || len(runes) <= 2 {
+			terms = append(terms, token.Text)
+			continue
+		}
+		for i := 0; i+2 <= len(runes); i++ {
+			terms = append(terms, string(runes[i:i+2]))
+		}
+	}
+	return terms
+}
@@ -0,0 +1,199 @@
+package library
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// vectorStorageVersion is bumped whenever the vector file format changes
+const vectorStorageVersion = 1
+
+// Chunk is a time window of a transcript and the embedding of its text
+type Chunk struct {
+	Text   string
+	Start  float64
+	End    float64
+	Vector []float32
+}
+
+// DocumentVectors holds the embedded chunks of one transcript. Model and ChunkSeconds record how
+// the vectors were made, and the document's IndexedAt the version of the transcript they were
+// made from.
+type DocumentVectors struct {
+	Version      int
+	Model        string
+	ChunkSeconds int
+	Document     Document
+	Chunks       []Chunk
+}
+
+// VectorIndex is a vector index over transcript chunks. The chunks of each transcript are stored
+// in their own file under the index directory and searched exhaustively in memory.
+type VectorIndex struct {
+	logger  *slog.Logger
+	entries map[string]*DocumentVectors
+	dir     string
+	mu      sync.RWMutex
+}
+
+// OpenVectors opens the vector index in dir, creating the directory if needed. Unreadable files
+// are skipped.
+func OpenVectors(dir string, logger *slog.Logger) (*VectorIndex, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create vector directory: %w", err)
+	}
+
+	index := &VectorIndex{
+		logger:  logger,
+		entries: make(map[string]*DocumentVectors),
+		dir:     dir,
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vector directory: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != fileExtension {
+			continue
+		}
+		var vectors DocumentVectors
+		if err := readFile(filepath.Join(dir, entry.Name()), &vectors); err != nil {
+			logger.Warn("Skipping unreadable vector file", "file", entry.Name(), "error", err)
+			continue
+		}
+		if vectors.Version != vectorStorageVersion {
+			continue
+		}
+		index.entries[strings.TrimSuffix(entry.Name(), fileExtension)] = &vectors
+	}
+
+	return index, nil
+}
+
+// Add stores the embedded chunks of a transcript, replacing any earlier ones of the same video
+// and language. Vectors are normalized so that similarity is a dot product.
+func (vx *VectorIndex) Add(vectors DocumentVectors) error {
+	vectors.Version = vectorStorageVersion
+	vectors.Document.Segments = nil
+	chunks := make([]Chunk, len(vectors.Chunks))
+	for i, chunk := range vectors.Chunks {
+		chunk.Vector = normalize(chunk.Vector)
+		chunks[i] = chunk
+	}
+	vectors.Chunks = chunks
+
+	key := documentKey(vectors.Document.VideoID, vectors.Document.Language)
+	if err := writeFile(filepath.Join(vx.dir, key+fileExtension), &vectors); err != nil {
+		return err
+	}
+
+	vx.mu.Lock()
+	defer vx.mu.Unlock()
+	vx.entries[key] = &vectors
+	return nil
+}
+
+// Current reports whether the stored vectors of a transcript were made from its current version
+// with the given model and chunk length
+func (vx *VectorIndex) Current(document Document, model string, chunkSeconds int) bool {
+	vx.mu.RLock()
+	defer vx.mu.RUnlock()
+
+	vectors, ok := vx.entries[documentKey(document.VideoID, document.Language)]
+	return ok && vectors.Model == model && vectors.ChunkSeconds == chunkSeconds &&
+		vectors.Document.IndexedAt.Equal(document.IndexedAt)
+}
+
+// Search returns the chunks closest to a query vector by cosine similarity, best first, and the
+// number of chunks compared. Only chunks embedded with the given model are compared.
+func (vx *VectorIndex) Search(query []float32, model string, opts models.SemanticSearchOptions) ([]models.LibraryHit, int) {
+	query = normalize(query)
+
+	vx.mu.RLock()
+	defer vx.mu.RUnlock()
+
+	type scoredChunk struct {
+		vectors *DocumentVectors
+		chunk   int
+		score   float64
+	}
+	var scored []scoredChunk
+	compared := 0
+	for _, vectors := range vx.entries {
+		if vectors.Model != model || !vectors.Document.Matches(opts.Channel, opts.Language) {
+			continue
+		}
+		for i, chunk := range vectors.Chunks {
+			if len(chunk.Vector) != len(query) {
+				continue
+			}
+			compared++
+			scored = append(scored, scoredChunk{vectors: vectors, chunk: i, score: dot(query, chunk.Vector)})
+		}
+	}
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
+		}
+		if scored[i].vectors.Document.VideoID != scored[j].vectors.Document.VideoID {
+			return scored[i].vectors.Document.VideoID < scored[j].vectors.Document.VideoID
+		}
+		return scored[i].chunk < scored[j].chunk
+	})
+	if opts.MaxResults > 0 && len(scored) > opts.MaxResults {
+		scored = scored[:opts.MaxResults]
+	}
+
+	hits := make([]models.LibraryHit, 0, len(scored))
+	for _, result := range scored {
+		document := result.vectors.Document
+		chunk := result.vectors.Chunks[result.chunk]
+		hits = append(hits, models.LibraryHit{
+			VideoID:     document.VideoID,
+			Title:       document.Title,
+			ChannelName: document.ChannelName,
+			Language:    document.Language,
+			PublishedAt: document.PublishedAt,
+			Text:        chunk.Text,
+			Start:       chunk.Start,
+			End:         chunk.End,
+			Score:       math.Round(result.score*10000) / 10000,
+		})
+	}
+	return hits, compared
+}
+
+// normalize returns a vector scaled to unit length
+func normalize(vector []float32) []float32 {
+	var sum float64
+	for _, value := range vector {
+		sum += float64(value) * float64(value)
+	}
+	normalized := make([]float32, len(vector))
+	if sum == 0 {
+		return normalized
+	}
+	length := math.Sqrt(sum)
+	for i, value := range vector {
+		normalized[i] = float32(float64(value) / length)
+	}
+	return normalized
+}
+
+// dot returns the dot product of two vectors of the same length
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
@@ -0,0 +1,112 @@
+package library
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func testVectors(videoID, channel, model string, indexedAt time.Time, chunks ...Chunk) DocumentVectors {
+	return DocumentVectors{
+		Model:        model,
+		ChunkSeconds: 30,
+		Document: Document{
+			VideoID:     videoID,
+			Title:       "Video " + videoID,
+			ChannelID:   "UC" + channel,
+			ChannelName: channel,
+			Language:    "en",
+			IndexedAt:   indexedAt,
+			Segments:    []Segment{{Text: "dropped", Start: 0, End: 1}},
+		},
+		Chunks: chunks,
+	}
+}
+
+func TestVectorIndex_PersistsAcrossOpen(t *testing.T) {
+	dir := t.TempDir()
+	indexedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	index, err := OpenVectors(dir, slog.Default())
+	if err != nil {
+		t.Fatalf("OpenVectors() error = %v", err)
+	}
+	vectors := testVectors("video1", "Science", "model-a", indexedAt, Chunk{Text: "cars", Start: 0, End: 30, Vector: []float32{3, 4}})
+	if err := index.Add(vectors); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	reopened, err := OpenVectors(dir, slog.Default())
+	if err != nil {
+		t.Fatalf("OpenVectors() error = %v", err)
+	}
+	document := vectors.Document
+	if !reopened.Current(document, "model-a", 30) {
+		t.Error("Current() = false after reopening")
+	}
+	if reopened.Current(document, "model-b", 30) || reopened.Current(document, "model-a", 60) {
+		t.Error("Current() = true for another model or chunk length")
+	}
+	document.IndexedAt = indexedAt.Add(time.Hour)
+	if reopened.Current(document, "model-a", 30) {
+		t.Error("Current() = true for a transcript indexed again")
+	}
+
+	hits, compared := reopened.Search([]float32{3, 4}, "model-a", models.SemanticSearchOptions{})
+	if compared != 1 || len(hits) != 1 || hits[0].Score != 1 || hits[0].End != 30 {
+		t.Errorf("Search() = %+v (compared %d)", hits, compared)
+	}
+}
+
+func TestVectorIndex_Search(t *testing.T) {
+	index, err := OpenVectors(t.TempDir(), slog.Default())
+	if err != nil {
+		t.Fatalf("OpenVectors() error = %v", err)
+	}
+	now := time.Now()
+	additions := []DocumentVectors{
+		testVectors("video1", "Science", "model-a", now,
+			Chunk{Text: "close", Start: 0, End: 30, Vector: []float32{1, 0.1}},
+			Chunk{Text: "far", Start: 30, End: 60, Vector: []float32{0, 1}},
+		),
+		testVectors("video2", "Cooking", "model-a", now,
+			Chunk{Text: "closest", Start: 0, End: 30, Vector: []float32{2, 0}},
+		),
+		testVectors("video3", "Science", "model-b", now,
+			Chunk{Text: "other model", Start: 0, End: 30, Vector: []float32{1, 0}},
+		),
+	}
+	for _, vectors := range additions {
+		if err := index.Add(vectors); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		opts     models.SemanticSearchOptions
+		expected []string
+		compared int
+	}{
+		{name: "ranked by similarity", opts: models.SemanticSearchOptions{}, expected: []string{"closest", "close", "far"}, compared: 3},
+		{name: "limited", opts: models.SemanticSearchOptions{MaxResults: 1}, expected: []string{"closest"}, compared: 3},
+		{name: "channel filter", opts: models.SemanticSearchOptions{Channel: "science"}, expected: []string{"close", "far"}, compared: 2},
+		{name: "language filter", opts: models.SemanticSearchOptions{Language: "de"}, expected: nil, compared: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, compared := index.Search([]float32{1, 0}, "model-a", tt.opts)
+			if compared != tt.compared || len(hits) != len(tt.expected) {
+				t.Fatalf("Search() = %+v (compared %d)", hits, compared)
+			}
+			for i, hit := range hits {
+				if hit.Text != tt.expected[i] {
+					t.Errorf("hit %d = %q, want %q", i, hit.Text, tt.expected[i])
+				}
+			}
+		})
+	}
+}
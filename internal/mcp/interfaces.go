@@ -9,8 +9,26 @@ import (
 // YouTubeService defines the interface for YouTube transcript operations
 type YouTubeService interface {
 	GetTranscript(ctx context.Context, videoID string, languages []string, preserveFormatting bool) (*models.TranscriptResponse, error)
+	GetTranscriptWithOptions(ctx context.Context, videoID string, languages []string, preserveFormatting bool, opts models.TranscriptOptions) (*models.TranscriptResponse, error)
 	GetMultipleTranscripts(ctx context.Context, videoIDs []string, languages []string, continueOnError bool) (*models.MultipleTranscriptResponse, error)
+	GetMultipleTranscriptsWithOptions(ctx context.Context, videoIDs []string, languages []string, continueOnError bool, opts models.TranscriptOptions) (*models.MultipleTranscriptResponse, error)
 	ListAvailableLanguages(ctx context.Context, videoID string) (*models.AvailableLanguagesResponse, error)
 	TranslateTranscript(ctx context.Context, videoID, targetLang, sourceLang string) (*models.TranscriptResponse, error)
 	FormatTranscript(ctx context.Context, videoID, formatType string, includeTimestamps bool) (*models.TranscriptResponse, error)
+	FormatTranscriptWithOptions(ctx context.Context, videoID string, opts models.FormatOptions) (*models.TranscriptResponse, error)
+	SearchTranscript(ctx context.Context, videoID string, opts models.TranscriptSearchOptions) (*models.SearchTranscriptResponse, error)
+	GetTranscriptRange(ctx context.Context, videoID string, opts models.TranscriptRangeOptions) (*models.TranscriptRangeResponse, error)
+	GetChapters(ctx context.Context, videoID string) (*models.ChaptersResponse, error)
+	GetVideoMetadata(ctx context.Context, videoID string) (*models.VideoInfo, error)
+	GetPlaylistTranscripts(ctx context.Context, playlistID string, opts models.PlaylistOptions) (*models.PlaylistTranscriptsResponse, error)
+	ListChannelVideos(ctx context.Context, channelID string, opts models.ChannelVideosOptions) (*models.ChannelVideosResponse, error)
+	SearchVideos(ctx context.Context, opts models.VideoSearchOptions) (*models.SearchVideosResponse, error)
+	GetLiveChatReplay(ctx context.Context, videoID string, opts models.LiveChatOptions) (*models.LiveChatReplayResponse, error)
+	GetBilingualTranscript(ctx context.Context, videoID string, opts models.BilingualOptions) (*models.BilingualTranscriptResponse, error)
+	CompareTranscripts(ctx context.Context, reference, candidate models.TranscriptReference, maxDifferences int) (*models.TranscriptComparisonResponse, error)
+	AnalyzeTranscript(ctx context.Context, videoID string, opts models.AnalysisOptions) (*models.TranscriptAnalysis, error)
+	ExtractKeywords(ctx context.Context, videoIDs []string, opts models.KeywordOptions) (*models.KeywordsResponse, error)
+	SearchLibrary(ctx context.Context, opts models.LibrarySearchOptions) (*models.LibrarySearchResponse, error)
+	SemanticSearch(ctx context.Context, opts models.SemanticSearchOptions) (*models.SemanticSearchResponse, error)
+	LocateQuote(ctx context.Context, videoID string, opts models.QuoteOptions) (*models.LocateQuoteResponse, error)
 }
@@ -16,6 +16,9 @@ import (
 	"github.com/youtube-transcript-mcp/internal/models"
 )
 
+// annotationsDescription describes the annotations parameter of the tools that return transcript text
+const annotationsDescription = "How to handle sound events such as [Music] and >> speaker changes in the text: keep them as captioned, strip them, or tag them as [music] and >> consistently. Segments always list them in sound_events and speaker_change"
+
 // Server implements the MCP server
 type Server struct {
 	youtube      YouTubeService
@@ -225,6 +228,36 @@ func (s *Server) executeTool(ctx context.Context, toolName string, arguments map
 		return s.executeFormatTranscript(ctx, arguments)
 	case models.ToolListLanguages:
 		return s.executeListLanguages(ctx, arguments)
+	case models.ToolSearchTranscript:
+		return s.executeSearchTranscript(ctx, arguments)
+	case models.ToolGetTranscriptRange:
+		return s.executeGetTranscriptRange(ctx, arguments)
+	case models.ToolGetChapters:
+		return s.executeGetChapters(ctx, arguments)
+	case models.ToolGetVideoMetadata:
+		return s.executeGetVideoMetadata(ctx, arguments)
+	case models.ToolGetPlaylistTranscripts:
+		return s.executeGetPlaylistTranscripts(ctx, arguments)
+	case models.ToolListChannelVideos:
+		return s.executeListChannelVideos(ctx, arguments)
+	case models.ToolSearchVideos:
+		return s.executeSearchVideos(ctx, arguments)
+	case models.ToolGetLiveChatReplay:
+		return s.executeGetLiveChatReplay(ctx, arguments)
+	case models.ToolGetBilingualTranscript:
+		return s.executeGetBilingualTranscript(ctx, arguments)
+	case models.ToolCompareTranscripts:
+		return s.executeCompareTranscripts(ctx, arguments)
+	case models.ToolAnalyzeTranscript:
+		return s.executeAnalyzeTranscript(ctx, arguments)
+	case models.ToolExtractKeywords:
+		return s.executeExtractKeywords(ctx, arguments)
+	case models.ToolSearchLibrary:
+		return s.executeSearchLibrary(ctx, arguments)
+	case models.ToolSemanticSearch:
+		return s.executeSemanticSearch(ctx, arguments)
+	case models.ToolLocateQuote:
+		return s.executeLocateQuote(ctx, arguments)
 	default:
 		return "", &models.MCPError{
 			Code:    models.MCPErrorCodeMethodNotFound,
@@ -252,11 +285,18 @@ func (s *Server) executeGetTranscript(ctx context.Context, arguments map[string]
 	}
 
 	// Execute the tool
-	result, err := s.youtube.GetTranscript(
+	result, err := s.youtube.GetTranscriptWithOptions(
 		ctx,
 		params.VideoIdentifier,
 		params.Languages,
 		params.PreserveFormatting,
+		models.TranscriptOptions{
+			MaxTokens:          params.MaxTokens,
+			TokenStrategy:      params.TokenStrategy,
+			SplitByChapter:     params.SplitByChapter,
+			Annotations:        params.Annotations,
+			IncludeWordTimings: params.IncludeWordTimings,
+		},
 	)
 	if err != nil {
 		// If it's already an MCP error, return it
@@ -286,11 +326,12 @@ func (s *Server) executeGetTranscript(ctx context.Context, arguments map[string]
 	}
 
 	if !params.IncludeTimestamps {
-		// Remove timestamps from segments
+		// Remove timestamps from segments and their words
 		for i := range result.Transcript {
 			result.Transcript[i].Start = 0
 			result.Transcript[i].Duration = 0
 			result.Transcript[i].End = 0
+			result.Transcript[i].Words = nil
 		}
 	}
 
@@ -325,11 +366,16 @@ func (s *Server) executeGetMultipleTranscripts(ctx context.Context, arguments ma
 	}
 
 	// Execute the tool
-	result, err := s.youtube.GetMultipleTranscripts(
+	result, err := s.youtube.GetMultipleTranscriptsWithOptions(
 		ctx,
 		params.VideoIdentifiers,
 		params.Languages,
 		params.ContinueOnError,
+		models.TranscriptOptions{
+			MaxTokens:     params.MaxTokens,
+			TokenStrategy: params.TokenStrategy,
+			Annotations:   params.Annotations,
+		},
 	)
 	if err != nil && !params.ContinueOnError {
 		return "", &models.MCPError{
@@ -362,9 +408,9 @@ func (s *Server) executeGetMultipleTranscripts(ctx context.Context, arguments ma
 	return string(jsonBytes), nil
 }
 
-// executeTranslateTranscript executes the translate_transcript tool
-func (s *Server) executeTranslateTranscript(ctx context.Context, arguments map[string]any) (string, error) {
-	var params models.TranslateTranscriptParams
+// executeGetPlaylistTranscripts executes the get_playlist_transcripts tool
+func (s *Server) executeGetPlaylistTranscripts(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.GetPlaylistTranscriptsParams
 
 	if err := s.mapToStruct(arguments, &params); err != nil {
 		return "", &models.MCPError{
@@ -380,13 +426,23 @@ func (s *Server) executeTranslateTranscript(ctx context.Context, arguments map[s
 		}
 	}
 
-	// Execute the tool
-	result, err := s.youtube.TranslateTranscript(
-		ctx,
-		params.VideoIdentifier,
-		params.TargetLanguage,
-		params.SourceLanguage,
-	)
+	if params.MaxVideos == 0 {
+		params.MaxVideos = models.DefaultPlaylistVideos
+	}
+	if params.Order == "" {
+		params.Order = models.PlaylistOrderPlaylist
+	}
+
+	result, err := s.youtube.GetPlaylistTranscripts(ctx, params.PlaylistIdentifier, models.PlaylistOptions{
+		Order:     params.Order,
+		Languages: params.Languages,
+		Transcript: models.TranscriptOptions{
+			MaxTokens:     params.MaxTokens,
+			TokenStrategy: params.TokenStrategy,
+		},
+		MaxVideos:       params.MaxVideos,
+		SkipUnavailable: params.SkipUnavailable,
+	})
 	if err != nil {
 		if transcriptErr, ok := err.(*models.TranscriptError); ok {
 			return "", &models.MCPError{
@@ -404,16 +460,6 @@ func (s *Server) executeTranslateTranscript(ctx context.Context, arguments map[s
 		}
 	}
 
-	// Optionally remove timestamps
-	if !params.PreserveTimestamps {
-		for i := range result.Transcript {
-			result.Transcript[i].Start = 0
-			result.Transcript[i].Duration = 0
-			result.Transcript[i].End = 0
-		}
-	}
-
-	// Convert to JSON string
 	jsonBytes, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
 		return "", &models.MCPError{
@@ -425,9 +471,9 @@ func (s *Server) executeTranslateTranscript(ctx context.Context, arguments map[s
 	return string(jsonBytes), nil
 }
 
-// executeFormatTranscript executes the format_transcript tool
-func (s *Server) executeFormatTranscript(ctx context.Context, arguments map[string]any) (string, error) {
-	var params models.FormatTranscriptParams
+// executeListChannelVideos executes the list_channel_videos tool
+func (s *Server) executeListChannelVideos(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.ListChannelVideosParams
 
 	if err := s.mapToStruct(arguments, &params); err != nil {
 		return "", &models.MCPError{
@@ -443,18 +489,46 @@ func (s *Server) executeFormatTranscript(ctx context.Context, arguments map[stri
 		}
 	}
 
-	// Set default format type if not specified
-	if params.FormatType == "" {
-		params.FormatType = models.FormatTypePlainText
+	opts := models.ChannelVideosOptions{
+		PageToken: params.PageToken,
+		Languages: params.Languages,
+		Transcript: models.TranscriptOptions{
+			MaxTokens:     params.MaxTokens,
+			TokenStrategy: params.TokenStrategy,
+		},
+		MaxResults:         params.MaxResults,
+		IncludeTranscripts: params.IncludeTranscripts,
+	}
+	if opts.MaxResults == 0 {
+		opts.MaxResults = models.DefaultChannelVideos
 	}
 
-	// Execute the tool
-	result, err := s.youtube.FormatTranscript(
-		ctx,
-		params.VideoIdentifier,
-		params.FormatType,
-		params.IncludeTimestamps,
-	)
+	if params.PublishedAfter != "" {
+		after, err := models.ParseDate(params.PublishedAfter)
+		if err != nil {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeInvalidParams,
+				Message: fmt.Sprintf("Invalid published_after: %v", err),
+			}
+		}
+		opts.PublishedAfter = &after
+	}
+	if params.PublishedBefore != "" {
+		before, err := models.ParseDate(params.PublishedBefore)
+		if err != nil {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeInvalidParams,
+				Message: fmt.Sprintf("Invalid published_before: %v", err),
+			}
+		}
+		// A plain date includes the whole day
+		if len(strings.TrimSpace(params.PublishedBefore)) == len(time.DateOnly) {
+			before = before.Add(24*time.Hour - time.Nanosecond)
+		}
+		opts.PublishedBefore = &before
+	}
+
+	result, err := s.youtube.ListChannelVideos(ctx, params.ChannelIdentifier, opts)
 	if err != nil {
 		if transcriptErr, ok := err.(*models.TranscriptError); ok {
 			return "", &models.MCPError{
@@ -472,26 +546,63 @@ func (s *Server) executeFormatTranscript(ctx context.Context, arguments map[stri
 		}
 	}
 
-	// For certain format types, return the formatted text directly
-	if params.FormatType == models.FormatTypeSRT ||
-		params.FormatType == models.FormatTypeVTT ||
-		params.FormatType == models.FormatTypePlainText {
-		return result.FormattedText, nil
+	jsonBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: fmt.Sprintf("Failed to serialize result: %v", err),
+		}
 	}
 
-	// For other formats, return structured response
-	response := map[string]any{
-		"video_id":       result.VideoID,
-		"title":          result.Title,
-		"language":       result.Language,
-		"format_type":    params.FormatType,
-		"formatted_text": result.FormattedText,
-		"word_count":     result.WordCount,
-		"char_count":     result.CharCount,
-		"duration":       result.DurationSeconds,
+	return string(jsonBytes), nil
+}
+
+// executeSearchVideos executes the search_videos tool
+func (s *Server) executeSearchVideos(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.SearchVideosParams
+
+	if err := s.mapToStruct(arguments, &params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}
 	}
 
-	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+	if err := s.validator.Struct(params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Validation error: %v", err),
+		}
+	}
+
+	if params.MaxResults == 0 {
+		params.MaxResults = models.DefaultSearchVideos
+	}
+
+	result, err := s.youtube.SearchVideos(ctx, models.VideoSearchOptions{
+		Query:       params.Query,
+		Duration:    params.Duration,
+		UploadDate:  params.UploadDate,
+		MaxResults:  params.MaxResults,
+		HasCaptions: params.HasCaptions,
+	})
+	if err != nil {
+		if transcriptErr, ok := err.(*models.TranscriptError); ok {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeServerError,
+				Message: transcriptErr.Message,
+				Data: map[string]any{
+					"type": transcriptErr.Type,
+				},
+			}
+		}
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: err.Error(),
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
 		return "", &models.MCPError{
 			Code:    models.MCPErrorCodeInternalError,
@@ -502,9 +613,9 @@ func (s *Server) executeFormatTranscript(ctx context.Context, arguments map[stri
 	return string(jsonBytes), nil
 }
 
-// executeListLanguages executes the list_available_languages tool
-func (s *Server) executeListLanguages(ctx context.Context, arguments map[string]any) (string, error) {
-	var params models.ListLanguagesParams
+// executeGetLiveChatReplay executes the get_live_chat_replay tool
+func (s *Server) executeGetLiveChatReplay(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.GetLiveChatReplayParams
 
 	if err := s.mapToStruct(arguments, &params); err != nil {
 		return "", &models.MCPError{
@@ -520,8 +631,27 @@ func (s *Server) executeListLanguages(ctx context.Context, arguments map[string]
 		}
 	}
 
-	// Execute the tool
-	result, err := s.youtube.ListAvailableLanguages(ctx, params.VideoIdentifier)
+	if params.MaxMessages == 0 {
+		params.MaxMessages = models.DefaultChatMessages
+	}
+
+	opts := models.LiveChatOptions{
+		MessageTypes:      params.MessageTypes,
+		Languages:         params.Languages,
+		MaxMessages:       params.MaxMessages,
+		IncludeTranscript: params.IncludeTranscript,
+		PageToken:         params.PageToken,
+	}
+	if params.Start != nil {
+		start := float64(*params.Start)
+		opts.Start = &start
+	}
+	if params.End != nil {
+		end := float64(*params.End)
+		opts.End = &end
+	}
+
+	result, err := s.youtube.GetLiveChatReplay(ctx, params.VideoIdentifier, opts)
 	if err != nil {
 		if transcriptErr, ok := err.(*models.TranscriptError); ok {
 			return "", &models.MCPError{
@@ -539,18 +669,67 @@ func (s *Server) executeListLanguages(ctx context.Context, arguments map[string]
 		}
 	}
 
-	// Filter out auto-generated if requested
-	if !params.IncludeAuto {
-		filtered := make([]models.LanguageInfo, 0)
-		for _, lang := range result.Languages {
-			if lang.Type != models.TranscriptTypeAuto {
-				filtered = append(filtered, lang)
+	jsonBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: fmt.Sprintf("Failed to serialize result: %v", err),
+		}
+	}
+
+	return string(jsonBytes), nil
+}
+
+// executeGetBilingualTranscript executes the get_bilingual_transcript tool
+func (s *Server) executeGetBilingualTranscript(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.GetBilingualTranscriptParams
+
+	if err := s.mapToStruct(arguments, &params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Validation error: %v", err),
+		}
+	}
+
+	if params.FormatType == "" {
+		params.FormatType = models.FormatTypeJSON
+	}
+
+	result, err := s.youtube.GetBilingualTranscript(ctx, params.VideoIdentifier, models.BilingualOptions{
+		PrimaryLanguage:   params.PrimaryLanguage,
+		SecondaryLanguage: params.SecondaryLanguage,
+		FormatType:        params.FormatType,
+	})
+	if err != nil {
+		if transcriptErr, ok := err.(*models.TranscriptError); ok {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeServerError,
+				Message: transcriptErr.Message,
+				Data: map[string]any{
+					"type":        transcriptErr.Type,
+					"video_id":    transcriptErr.VideoID,
+					"suggestions": transcriptErr.Suggestions,
+				},
 			}
 		}
-		result.Languages = filtered
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: err.Error(),
+		}
+	}
+
+	// Tables and subtitles are returned as-is so they can be saved or pasted directly
+	if params.FormatType != models.FormatTypeJSON {
+		return result.FormattedText, nil
 	}
 
-	// Convert to JSON string
 	jsonBytes, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
 		return "", &models.MCPError{
@@ -562,97 +741,1445 @@ func (s *Server) executeListLanguages(ctx context.Context, arguments map[string]
 	return string(jsonBytes), nil
 }
 
-// getAvailableTools returns the list of available MCP tools
-func (s *Server) getAvailableTools() []models.MCPTool {
-	tools := []models.MCPTool{}
+// executeCompareTranscripts executes the compare_transcripts tool
+func (s *Server) executeCompareTranscripts(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.CompareTranscriptsParams
 
-	if s.config.Tools[models.ToolGetTranscript] {
-		tools = append(tools, models.MCPTool{
-			Name:        models.ToolGetTranscript,
-			Description: "Get transcript for a YouTube video in specified languages",
-			InputSchema: map[string]any{
-				"type": "object",
-				"properties": map[string]any{
-					"video_identifier": map[string]any{
-						"type":        "string",
-						"description": "YouTube video URL, video ID, or watch URL",
-					},
-					"languages": map[string]any{
-						"type": "array",
-						"items": map[string]any{
-							"type": "string",
-						},
-						"description": "Preferred language codes (e.g., ['en', 'ja']). If not specified, uses default languages.",
-					},
-					"preserve_formatting": map[string]any{
-						"type":        "boolean",
-						"description": "Whether to preserve original formatting with timestamps",
-						"default":     false,
-					},
-					"include_metadata": map[string]any{
-						"type":        "boolean",
-						"description": "Whether to include video metadata (channel, views, etc.)",
-						"default":     true,
-					},
-					"include_timestamps": map[string]any{
-						"type":        "boolean",
-						"description": "Whether to include timestamp information in segments",
-						"default":     true,
-					},
+	if err := s.mapToStruct(arguments, &params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Validation error: %v", err),
+		}
+	}
+
+	if params.MaxDifferences == 0 {
+		params.MaxDifferences = models.DefaultMaxDifferences
+	}
+
+	result, err := s.youtube.CompareTranscripts(ctx, params.Reference, params.Candidate, params.MaxDifferences)
+	if err != nil {
+		if transcriptErr, ok := err.(*models.TranscriptError); ok {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeServerError,
+				Message: transcriptErr.Message,
+				Data: map[string]any{
+					"type":        transcriptErr.Type,
+					"video_id":    transcriptErr.VideoID,
+					"suggestions": transcriptErr.Suggestions,
 				},
-				"required": []string{"video_identifier"},
-			},
-		})
+			}
+		}
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: err.Error(),
+		}
 	}
 
-	if s.config.Tools[models.ToolGetMultipleTranscripts] {
-		tools = append(tools, models.MCPTool{
-			Name:        models.ToolGetMultipleTranscripts,
-			Description: "Get transcripts for multiple YouTube videos",
-			InputSchema: map[string]any{
-				"type": "object",
-				"properties": map[string]any{
-					"video_identifiers": map[string]any{
-						"type": "array",
-						"items": map[string]any{
-							"type": "string",
-						},
-						"description": "List of YouTube video URLs or IDs (max 50)",
-						"minItems":    1,
-						"maxItems":    50,
-					},
-					"languages": map[string]any{
-						"type": "array",
-						"items": map[string]any{
+	jsonBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: fmt.Sprintf("Failed to serialize result: %v", err),
+		}
+	}
+
+	return string(jsonBytes), nil
+}
+
+// executeAnalyzeTranscript executes the analyze_transcript tool
+func (s *Server) executeAnalyzeTranscript(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.AnalyzeTranscriptParams
+
+	if err := s.mapToStruct(arguments, &params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Validation error: %v", err),
+		}
+	}
+
+	if params.WindowSeconds == 0 {
+		params.WindowSeconds = models.DefaultAnalysisWindow
+	}
+	if params.PauseSeconds == 0 {
+		params.PauseSeconds = models.DefaultPauseSeconds
+	}
+	if params.TopTerms == 0 {
+		params.TopTerms = models.DefaultTopTerms
+	}
+
+	result, err := s.youtube.AnalyzeTranscript(ctx, params.VideoIdentifier, models.AnalysisOptions{
+		Languages:     params.Languages,
+		WindowSeconds: params.WindowSeconds,
+		PauseSeconds:  params.PauseSeconds,
+		TopTerms:      params.TopTerms,
+	})
+	if err != nil {
+		if transcriptErr, ok := err.(*models.TranscriptError); ok {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeServerError,
+				Message: transcriptErr.Message,
+				Data: map[string]any{
+					"type":     transcriptErr.Type,
+					"video_id": transcriptErr.VideoID,
+				},
+			}
+		}
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: err.Error(),
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: fmt.Sprintf("Failed to serialize result: %v", err),
+		}
+	}
+
+	return string(jsonBytes), nil
+}
+
+// executeExtractKeywords executes the extract_keywords tool
+func (s *Server) executeExtractKeywords(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.ExtractKeywordsParams
+
+	if err := s.mapToStruct(arguments, &params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Validation error: %v", err),
+		}
+	}
+
+	if params.MaxKeywords == 0 {
+		params.MaxKeywords = models.DefaultMaxKeywords
+	}
+	if params.MaxThemes == 0 {
+		params.MaxThemes = models.DefaultMaxThemes
+	}
+
+	result, err := s.youtube.ExtractKeywords(ctx, params.VideoIdentifiers, models.KeywordOptions{
+		Languages:   params.Languages,
+		MaxKeywords: params.MaxKeywords,
+		MaxThemes:   params.MaxThemes,
+	})
+	if err != nil {
+		if transcriptErr, ok := err.(*models.TranscriptError); ok {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeServerError,
+				Message: transcriptErr.Message,
+				Data: map[string]any{
+					"type":     transcriptErr.Type,
+					"video_id": transcriptErr.VideoID,
+				},
+			}
+		}
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: err.Error(),
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: fmt.Sprintf("Failed to serialize result: %v", err),
+		}
+	}
+
+	return string(jsonBytes), nil
+}
+
+// executeSearchLibrary executes the search_library tool
+func (s *Server) executeSearchLibrary(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.SearchLibraryParams
+
+	if err := s.mapToStruct(arguments, &params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Validation error: %v", err),
+		}
+	}
+
+	opts := models.LibrarySearchOptions{
+		Query:      params.Query,
+		Channel:    params.Channel,
+		Language:   params.Language,
+		MaxResults: params.MaxResults,
+	}
+	if opts.MaxResults == 0 {
+		opts.MaxResults = models.DefaultLibraryResults
+	}
+
+	if params.PublishedAfter != "" {
+		after, err := models.ParseDate(params.PublishedAfter)
+		if err != nil {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeInvalidParams,
+				Message: fmt.Sprintf("Invalid published_after: %v", err),
+			}
+		}
+		opts.PublishedAfter = &after
+	}
+	if params.PublishedBefore != "" {
+		before, err := models.ParseDate(params.PublishedBefore)
+		if err != nil {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeInvalidParams,
+				Message: fmt.Sprintf("Invalid published_before: %v", err),
+			}
+		}
+		// A plain date includes the whole day
+		if len(strings.TrimSpace(params.PublishedBefore)) == len(time.DateOnly) {
+			before = before.Add(24*time.Hour - time.Nanosecond)
+		}
+		opts.PublishedBefore = &before
+	}
+
+	result, err := s.youtube.SearchLibrary(ctx, opts)
+	if err != nil {
+		if transcriptErr, ok := err.(*models.TranscriptError); ok {
+			code := models.MCPErrorCodeServerError
+			if transcriptErr.Type == models.ErrorTypeValidationError {
+				code = models.MCPErrorCodeInvalidParams
+			}
+			return "", &models.MCPError{
+				Code:    code,
+				Message: transcriptErr.Message,
+				Data: map[string]any{
+					"type": transcriptErr.Type,
+				},
+			}
+		}
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: err.Error(),
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: fmt.Sprintf("Failed to serialize result: %v", err),
+		}
+	}
+
+	return string(jsonBytes), nil
+}
+
+// executeSemanticSearch executes the semantic_search tool
+func (s *Server) executeSemanticSearch(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.SemanticSearchParams
+
+	if err := s.mapToStruct(arguments, &params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Validation error: %v", err),
+		}
+	}
+
+	opts := models.SemanticSearchOptions{
+		Query:      params.Query,
+		Channel:    params.Channel,
+		Language:   params.Language,
+		MaxResults: params.MaxResults,
+	}
+	if opts.MaxResults == 0 {
+		opts.MaxResults = models.DefaultSemanticResults
+	}
+
+	result, err := s.youtube.SemanticSearch(ctx, opts)
+	if err != nil {
+		if transcriptErr, ok := err.(*models.TranscriptError); ok {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeServerError,
+				Message: transcriptErr.Message,
+				Data: map[string]any{
+					"type":     transcriptErr.Type,
+					"video_id": transcriptErr.VideoID,
+				},
+			}
+		}
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: err.Error(),
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: fmt.Sprintf("Failed to serialize result: %v", err),
+		}
+	}
+
+	return string(jsonBytes), nil
+}
+
+// executeLocateQuote executes the locate_quote tool
+func (s *Server) executeLocateQuote(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.LocateQuoteParams
+
+	if err := s.mapToStruct(arguments, &params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Validation error: %v", err),
+		}
+	}
+
+	// Set defaults for options that were not specified
+	if params.MaxResults == 0 {
+		params.MaxResults = models.DefaultQuoteMatches
+	}
+	if _, ok := arguments["min_confidence"]; !ok {
+		params.MinConfidence = models.DefaultQuoteConfidence
+	}
+
+	result, err := s.youtube.LocateQuote(ctx, params.VideoIdentifier, models.QuoteOptions{
+		Quote:         params.Quote,
+		Languages:     params.Languages,
+		MaxResults:    params.MaxResults,
+		MinConfidence: params.MinConfidence,
+	})
+	if err != nil {
+		if transcriptErr, ok := err.(*models.TranscriptError); ok {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeServerError,
+				Message: transcriptErr.Message,
+				Data: map[string]any{
+					"type":     transcriptErr.Type,
+					"video_id": transcriptErr.VideoID,
+				},
+			}
+		}
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: err.Error(),
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: fmt.Sprintf("Failed to serialize result: %v", err),
+		}
+	}
+
+	return string(jsonBytes), nil
+}
+
+// executeTranslateTranscript executes the translate_transcript tool
+func (s *Server) executeTranslateTranscript(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.TranslateTranscriptParams
+
+	if err := s.mapToStruct(arguments, &params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Validation error: %v", err),
+		}
+	}
+
+	// Execute the tool
+	result, err := s.youtube.TranslateTranscript(
+		ctx,
+		params.VideoIdentifier,
+		params.TargetLanguage,
+		params.SourceLanguage,
+	)
+	if err != nil {
+		if transcriptErr, ok := err.(*models.TranscriptError); ok {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeServerError,
+				Message: transcriptErr.Message,
+				Data: map[string]any{
+					"type":        transcriptErr.Type,
+					"video_id":    transcriptErr.VideoID,
+					"suggestions": transcriptErr.Suggestions,
+				},
+			}
+		}
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: err.Error(),
+		}
+	}
+
+	// Optionally remove timestamps
+	if !params.PreserveTimestamps {
+		for i := range result.Transcript {
+			result.Transcript[i].Start = 0
+			result.Transcript[i].Duration = 0
+			result.Transcript[i].End = 0
+			result.Transcript[i].Words = nil
+		}
+	}
+
+	// Convert to JSON string
+	jsonBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: fmt.Sprintf("Failed to serialize result: %v", err),
+		}
+	}
+
+	return string(jsonBytes), nil
+}
+
+// executeFormatTranscript executes the format_transcript tool
+func (s *Server) executeFormatTranscript(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.FormatTranscriptParams
+
+	if err := s.mapToStruct(arguments, &params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Validation error: %v", err),
+		}
+	}
+
+	// Set default format type if not specified
+	if params.FormatType == "" {
+		params.FormatType = models.FormatTypePlainText
+	}
+
+	// Execute the tool
+	result, err := s.youtube.FormatTranscriptWithOptions(
+		ctx,
+		params.VideoIdentifier,
+		models.FormatOptions{
+			FormatType:        params.FormatType,
+			IncludeTimestamps: params.IncludeTimestamps,
+			Transcript: models.TranscriptOptions{
+				MaxTokens:     params.MaxTokens,
+				TokenStrategy: params.TokenStrategy,
+				Annotations:   params.Annotations,
+			},
+			Paragraphs: models.ParagraphOptions{
+				PauseSeconds:  params.ParagraphPause,
+				TargetSeconds: params.ParagraphSeconds,
+				TargetWords:   params.ParagraphWords,
+			},
+		},
+	)
+	if err != nil {
+		if transcriptErr, ok := err.(*models.TranscriptError); ok {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeServerError,
+				Message: transcriptErr.Message,
+				Data: map[string]any{
+					"type":     transcriptErr.Type,
+					"video_id": transcriptErr.VideoID,
+				},
+			}
+		}
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: err.Error(),
+		}
+	}
+
+	// For certain format types, return the formatted text directly
+	if params.FormatType == models.FormatTypeSRT ||
+		params.FormatType == models.FormatTypeVTT ||
+		params.FormatType == models.FormatTypePlainText {
+		return result.FormattedText, nil
+	}
+
+	// For other formats, return structured response
+	response := map[string]any{
+		"video_id":       result.VideoID,
+		"title":          result.Title,
+		"language":       result.Language,
+		"format_type":    params.FormatType,
+		"formatted_text": result.FormattedText,
+		"word_count":     result.WordCount,
+		"char_count":     result.CharCount,
+		"token_count":    result.TokenCount,
+		"truncated":      result.Truncated,
+		"duration":       result.DurationSeconds,
+	}
+
+	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: fmt.Sprintf("Failed to serialize result: %v", err),
+		}
+	}
+
+	return string(jsonBytes), nil
+}
+
+// executeListLanguages executes the list_available_languages tool
+func (s *Server) executeListLanguages(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.ListLanguagesParams
+
+	if err := s.mapToStruct(arguments, &params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Validation error: %v", err),
+		}
+	}
+
+	// Execute the tool
+	result, err := s.youtube.ListAvailableLanguages(ctx, params.VideoIdentifier)
+	if err != nil {
+		if transcriptErr, ok := err.(*models.TranscriptError); ok {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeServerError,
+				Message: transcriptErr.Message,
+				Data: map[string]any{
+					"type":     transcriptErr.Type,
+					"video_id": transcriptErr.VideoID,
+				},
+			}
+		}
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: err.Error(),
+		}
+	}
+
+	// Filter out auto-generated if requested
+	if !params.IncludeAuto {
+		filtered := make([]models.LanguageInfo, 0)
+		for _, lang := range result.Languages {
+			if lang.Type != models.TranscriptTypeAuto {
+				filtered = append(filtered, lang)
+			}
+		}
+		result.Languages = filtered
+	}
+
+	// Convert to JSON string
+	jsonBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: fmt.Sprintf("Failed to serialize result: %v", err),
+		}
+	}
+
+	return string(jsonBytes), nil
+}
+
+// executeGetChapters executes the get_chapters tool
+func (s *Server) executeGetChapters(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.GetChaptersParams
+
+	if err := s.mapToStruct(arguments, &params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Validation error: %v", err),
+		}
+	}
+
+	result, err := s.youtube.GetChapters(ctx, params.VideoIdentifier)
+	if err != nil {
+		if transcriptErr, ok := err.(*models.TranscriptError); ok {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeServerError,
+				Message: transcriptErr.Message,
+				Data: map[string]any{
+					"type":     transcriptErr.Type,
+					"video_id": transcriptErr.VideoID,
+				},
+			}
+		}
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: err.Error(),
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: fmt.Sprintf("Failed to serialize result: %v", err),
+		}
+	}
+
+	return string(jsonBytes), nil
+}
+
+// executeGetVideoMetadata executes the get_video_metadata tool
+func (s *Server) executeGetVideoMetadata(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.GetVideoMetadataParams
+
+	if err := s.mapToStruct(arguments, &params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Validation error: %v", err),
+		}
+	}
+
+	result, err := s.youtube.GetVideoMetadata(ctx, params.VideoIdentifier)
+	if err != nil {
+		if transcriptErr, ok := err.(*models.TranscriptError); ok {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeServerError,
+				Message: transcriptErr.Message,
+				Data: map[string]any{
+					"type":     transcriptErr.Type,
+					"video_id": transcriptErr.VideoID,
+				},
+			}
+		}
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: err.Error(),
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: fmt.Sprintf("Failed to serialize result: %v", err),
+		}
+	}
+
+	return string(jsonBytes), nil
+}
+
+// executeSearchTranscript executes the search_transcript tool
+func (s *Server) executeSearchTranscript(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.SearchTranscriptParams
+
+	if err := s.mapToStruct(arguments, &params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Validation error: %v", err),
+		}
+	}
+
+	// Set defaults for options that were not specified
+	if params.Mode == "" {
+		params.Mode = models.SearchModeKeyword
+	}
+	if _, ok := arguments["context_segments"]; !ok {
+		params.ContextSegments = models.DefaultSearchContext
+	}
+	if params.MaxResults == 0 {
+		params.MaxResults = models.DefaultSearchResults
+	}
+
+	// Execute the tool
+	result, err := s.youtube.SearchTranscript(ctx, params.VideoIdentifier, models.TranscriptSearchOptions{
+		Query:           params.Query,
+		Mode:            params.Mode,
+		Languages:       params.Languages,
+		ContextSegments: params.ContextSegments,
+		MaxResults:      params.MaxResults,
+		CaseSensitive:   params.CaseSensitive,
+		FoldDiacritics:  !params.KeepDiacritics,
+	})
+	if err != nil {
+		if transcriptErr, ok := err.(*models.TranscriptError); ok {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeServerError,
+				Message: transcriptErr.Message,
+				Data: map[string]any{
+					"type":     transcriptErr.Type,
+					"video_id": transcriptErr.VideoID,
+				},
+			}
+		}
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: err.Error(),
+		}
+	}
+
+	// Convert to JSON string
+	jsonBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: fmt.Sprintf("Failed to serialize result: %v", err),
+		}
+	}
+
+	return string(jsonBytes), nil
+}
+
+// executeGetTranscriptRange executes the get_transcript_range tool
+func (s *Server) executeGetTranscriptRange(ctx context.Context, arguments map[string]any) (string, error) {
+	var params models.GetTranscriptRangeParams
+
+	if err := s.mapToStruct(arguments, &params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Validation error: %v", err),
+		}
+	}
+
+	// Set default format type if not specified
+	if params.FormatType == "" {
+		params.FormatType = models.FormatTypePlainText
+	}
+
+	opts := models.TranscriptRangeOptions{
+		Languages: params.Languages,
+		Format: models.FormatOptions{
+			FormatType:        params.FormatType,
+			IncludeTimestamps: params.IncludeTimestamps,
+		},
+		Rebase: params.RebaseTimestamps,
+	}
+	if params.Start != nil {
+		start := float64(*params.Start)
+		opts.Start = &start
+	}
+	if params.End != nil {
+		end := float64(*params.End)
+		opts.End = &end
+	}
+
+	// Execute the tool
+	result, err := s.youtube.GetTranscriptRange(ctx, params.VideoIdentifier, opts)
+	if err != nil {
+		if transcriptErr, ok := err.(*models.TranscriptError); ok {
+			return "", &models.MCPError{
+				Code:    models.MCPErrorCodeServerError,
+				Message: transcriptErr.Message,
+				Data: map[string]any{
+					"type":     transcriptErr.Type,
+					"video_id": transcriptErr.VideoID,
+				},
+			}
+		}
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: err.Error(),
+		}
+	}
+
+	// For certain format types, return the formatted text directly
+	if params.FormatType == models.FormatTypeSRT ||
+		params.FormatType == models.FormatTypeVTT ||
+		params.FormatType == models.FormatTypePlainText {
+		return result.FormattedText, nil
+	}
+
+	// For other formats, return structured response without duplicating the segments
+	result.Transcript = nil
+	jsonBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", &models.MCPError{
+			Code:    models.MCPErrorCodeInternalError,
+			Message: fmt.Sprintf("Failed to serialize result: %v", err),
+		}
+	}
+
+	return string(jsonBytes), nil
+}
+
+// getAvailableTools returns the list of available MCP tools
+func (s *Server) getAvailableTools() []models.MCPTool {
+	tools := []models.MCPTool{}
+
+	if s.config.Tools[models.ToolGetTranscript] {
+		tools = append(tools, models.MCPTool{
+			Name:        models.ToolGetTranscript,
+			Description: "Get transcript for a YouTube video in specified languages",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"video_identifier": map[string]any{
+						"type":        "string",
+						"description": "YouTube video URL, video ID, or watch URL",
+					},
+					"languages": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type": "string",
+						},
+						"description": "Preferred language codes (e.g., ['en', 'ja']). If not specified, uses default languages.",
+					},
+					"preserve_formatting": map[string]any{
+						"type":        "boolean",
+						"description": "Whether to preserve original formatting with timestamps",
+						"default":     false,
+					},
+					"include_metadata": map[string]any{
+						"type":        "boolean",
+						"description": "Whether to include video metadata (channel, views, etc.)",
+						"default":     true,
+					},
+					"include_timestamps": map[string]any{
+						"type":        "boolean",
+						"description": "Whether to include timestamp information in segments",
+						"default":     true,
+					},
+					"max_tokens": map[string]any{
+						"type":        "integer",
+						"description": "Maximum estimated tokens for the transcript text; longer transcripts are reduced with token_strategy",
+						"minimum":     1,
+					},
+					"token_strategy": map[string]any{
+						"type":        "string",
+						"enum":        []string{"truncate", "sample", "compress"},
+						"description": "How to fit an over-budget transcript: keep the beginning, sample evenly across the timeline, or drop filler words before sampling",
+						"default":     "truncate",
+					},
+					"include_word_timings": map[string]any{
+						"type":        "boolean",
+						"description": "Include the start and duration of every word in each segment's words, where the captions time them (mostly automatic captions), for karaoke-style highlighting and precise citations",
+						"default":     false,
+					},
+					"annotations": map[string]any{
+						"type":        "string",
+						"enum":        []string{"keep", "strip", "tag"},
+						"description": annotationsDescription,
+						"default":     "keep",
+					},
+					"split_by_chapter": map[string]any{
+						"type":        "boolean",
+						"description": "Group the transcript under the video's chapter headings",
+						"default":     false,
+					},
+				},
+				"required": []string{"video_identifier"},
+			},
+		})
+	}
+
+	if s.config.Tools[models.ToolGetMultipleTranscripts] {
+		tools = append(tools, models.MCPTool{
+			Name:        models.ToolGetMultipleTranscripts,
+			Description: "Get transcripts for multiple YouTube videos",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"video_identifiers": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type": "string",
+						},
+						"description": "List of YouTube video URLs or IDs (max 50)",
+						"minItems":    1,
+						"maxItems":    50,
+					},
+					"languages": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type": "string",
+						},
+						"description": "Preferred language codes",
+					},
+					"continue_on_error": map[string]any{
+						"type":        "boolean",
+						"description": "Continue processing other videos if one fails",
+						"default":     true,
+					},
+					"include_metadata": map[string]any{
+						"type":        "boolean",
+						"description": "Whether to include video metadata",
+						"default":     false,
+					},
+					"parallel": map[string]any{
+						"type":        "boolean",
+						"description": "Process videos in parallel for faster results",
+						"default":     true,
+					},
+					"max_tokens": map[string]any{
+						"type":        "integer",
+						"description": "Maximum estimated tokens shared across all transcripts in the batch",
+						"minimum":     1,
+					},
+					"token_strategy": map[string]any{
+						"type":        "string",
+						"enum":        []string{"truncate", "sample", "compress"},
+						"description": "How to fit an over-budget transcript: keep the beginning, sample evenly across the timeline, or drop filler words before sampling",
+						"default":     "truncate",
+					},
+					"annotations": map[string]any{
+						"type":        "string",
+						"enum":        []string{"keep", "strip", "tag"},
+						"description": annotationsDescription,
+						"default":     "keep",
+					},
+				},
+				"required": []string{"video_identifiers"},
+			},
+		})
+	}
+
+	if s.config.Tools[models.ToolTranslateTranscript] {
+		tools = append(tools, models.MCPTool{
+			Name:        models.ToolTranslateTranscript,
+			Description: "Translate a video transcript to a target language using YouTube's machine translation of its caption tracks",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"video_identifier": map[string]any{
+						"type":        "string",
+						"description": "YouTube video URL or ID",
+					},
+					"target_language": map[string]any{
+						"type":        "string",
+						"description": "Target language code (e.g., 'ja', 'es', 'zh-Hans')",
+						"minLength":   2,
+						"maxLength":   10,
+					},
+					"source_language": map[string]any{
+						"type":        "string",
+						"description": "Language of the caption track to translate from (defaults to the video's default track)",
+					},
+					"preserve_timestamps": map[string]any{
+						"type":        "boolean",
+						"description": "Whether to preserve timestamp information",
+						"default":     true,
+					},
+				},
+				"required": []string{"video_identifier", "target_language"},
+			},
+		})
+	}
+
+	if s.config.Tools[models.ToolFormatTranscript] {
+		tools = append(tools, models.MCPTool{
+			Name:        models.ToolFormatTranscript,
+			Description: "Format a transcript in various styles",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"video_identifier": map[string]any{
+						"type":        "string",
+						"description": "YouTube video URL or ID",
+					},
+					"format_type": map[string]any{
+						"type":        "string",
+						"enum":        []string{"plain_text", "paragraphs", "sentences", "srt", "vtt", "json", "speaker_turns", "vtt_karaoke"},
+						"description": "Output format type; speaker_turns groups the text into turns labelled with the speaker names the captions give, or Speaker 1 and Speaker 2; vtt_karaoke is WebVTT with an inline timestamp before every word",
+						"default":     "plain_text",
+					},
+					"include_timestamps": map[string]any{
+						"type":        "boolean",
+						"description": "Include timestamps in the formatted output",
+						"default":     false,
+					},
+					"paragraph_pause": map[string]any{
+						"type":        "number",
+						"description": "For the paragraphs format: start a new paragraph after a silence of this many seconds",
+						"default":     2,
+					},
+					"paragraph_seconds": map[string]any{
+						"type":        "number",
+						"description": "For the paragraphs format: target paragraph length in seconds; paragraphs end at the first sentence end after it (defaults to 45 unless paragraph_words is set)",
+					},
+					"paragraph_words": map[string]any{
+						"type":        "integer",
+						"description": "For the paragraphs format: target paragraph length in words; paragraphs end at the first sentence end after it",
+						"minimum":     1,
+					},
+					"timestamp_format": map[string]any{
+						"type":        "string",
+						"enum":        []string{"seconds", "hms", "ms"},
+						"description": "Timestamp format (seconds, HH:MM:SS, or HH:MM:SS,mmm)",
+						"default":     "seconds",
+					},
+					"max_line_length": map[string]any{
+						"type":        "integer",
+						"description": "Maximum characters per line (for subtitle formats)",
+						"default":     80,
+						"minimum":     20,
+						"maximum":     200,
+					},
+					"max_tokens": map[string]any{
+						"type":        "integer",
+						"description": "Maximum estimated tokens for the formatted output, including format markup",
+						"minimum":     1,
+					},
+					"token_strategy": map[string]any{
+						"type":        "string",
+						"enum":        []string{"truncate", "sample", "compress"},
+						"description": "How to fit an over-budget transcript: keep the beginning, sample evenly across the timeline, or drop filler words before sampling",
+						"default":     "truncate",
+					},
+					"annotations": map[string]any{
+						"type":        "string",
+						"enum":        []string{"keep", "strip", "tag"},
+						"description": annotationsDescription,
+						"default":     "keep",
+					},
+				},
+				"required": []string{"video_identifier"},
+			},
+		})
+	}
+
+	if s.config.Tools[models.ToolListLanguages] {
+		tools = append(tools, models.MCPTool{
+			Name:        models.ToolListLanguages,
+			Description: "List available transcript languages for a video",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"video_identifier": map[string]any{
+						"type":        "string",
+						"description": "YouTube video URL or ID",
+					},
+					"include_auto": map[string]any{
+						"type":        "boolean",
+						"description": "Include auto-generated transcripts in the list",
+						"default":     true,
+					},
+				},
+				"required": []string{"video_identifier"},
+			},
+		})
+	}
+
+	if s.config.Tools[models.ToolSearchTranscript] {
+		tools = append(tools, models.MCPTool{
+			Name:        models.ToolSearchTranscript,
+			Description: "Search a video transcript and return timestamped matches with deep links",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"video_identifier": map[string]any{
+						"type":        "string",
+						"description": "YouTube video URL or ID",
+					},
+					"query": map[string]any{
+						"type":        "string",
+						"description": "Keywords, phrase or regular expression to search for",
+					},
+					"mode": map[string]any{
+						"type":        "string",
+						"enum":        []string{"keyword", "phrase", "regex"},
+						"description": "Match any keyword, the exact phrase (may span segments), or a regular expression",
+						"default":     "keyword",
+					},
+					"languages": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type": "string",
+						},
+						"description": "Preferred language codes",
+					},
+					"context_segments": map[string]any{
+						"type":        "integer",
+						"description": "Number of surrounding segments to include before and after each match",
+						"default":     1,
+						"minimum":     0,
+						"maximum":     20,
+					},
+					"max_results": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of matches to return",
+						"default":     50,
+						"minimum":     1,
+						"maximum":     500,
+					},
+					"case_sensitive": map[string]any{
+						"type":        "boolean",
+						"description": "Match letter case exactly",
+						"default":     false,
+					},
+					"keep_diacritics": map[string]any{
+						"type":        "boolean",
+						"description": "Treat accented letters as distinct (by default 'cafe' matches 'café')",
+						"default":     false,
+					},
+				},
+				"required": []string{"video_identifier", "query"},
+			},
+		})
+	}
+
+	if s.config.Tools[models.ToolGetTranscriptRange] {
+		tools = append(tools, models.MCPTool{
+			Name:        models.ToolGetTranscriptRange,
+			Description: "Get the part of a transcript between a start and end time",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"video_identifier": map[string]any{
+						"type":        "string",
+						"description": "YouTube video URL or ID. A t= offset in a pasted URL is used as the start when start is omitted",
+					},
+					"start": map[string]any{
+						"type":        []string{"number", "string"},
+						"description": "Range start in seconds or HH:MM:SS (e.g. 750 or '12:30')",
+					},
+					"end": map[string]any{
+						"type":        []string{"number", "string"},
+						"description": "Range end in seconds or HH:MM:SS (defaults to the end of the video)",
+					},
+					"format_type": map[string]any{
+						"type":        "string",
+						"enum":        []string{"plain_text", "paragraphs", "sentences", "srt", "vtt", "json"},
+						"description": "Output format type",
+						"default":     "plain_text",
+					},
+					"languages": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type": "string",
+						},
+						"description": "Preferred language codes",
+					},
+					"include_timestamps": map[string]any{
+						"type":        "boolean",
+						"description": "Include timestamps in the formatted output",
+						"default":     false,
+					},
+					"rebase_timestamps": map[string]any{
+						"type":        "boolean",
+						"description": "Shift times so the range starts at 00:00:00 (useful for SRT/VTT clips)",
+						"default":     false,
+					},
+				},
+				"required": []string{"video_identifier"},
+			},
+		})
+	}
+
+	if s.config.Tools[models.ToolGetChapters] {
+		tools = append(tools, models.MCPTool{
+			Name:        models.ToolGetChapters,
+			Description: "Get the chapters of a video with titles and start/end times, from chapter markers or description timestamps",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"video_identifier": map[string]any{
+						"type":        "string",
+						"description": "YouTube video URL or ID",
+					},
+				},
+				"required": []string{"video_identifier"},
+			},
+		})
+	}
+
+	if s.config.Tools[models.ToolGetVideoMetadata] {
+		tools = append(tools, models.MCPTool{
+			Name:        models.ToolGetVideoMetadata,
+			Description: "Get video details such as publish date, duration, keywords, category, view and like counts, thumbnails and privacy flags",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"video_identifier": map[string]any{
+						"type":        "string",
+						"description": "YouTube video URL or ID",
+					},
+				},
+				"required": []string{"video_identifier"},
+			},
+		})
+	}
+
+	if s.config.Tools[models.ToolGetPlaylistTranscripts] {
+		tools = append(tools, models.MCPTool{
+			Name:        models.ToolGetPlaylistTranscripts,
+			Description: "Get transcripts for the videos of a YouTube playlist",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"playlist_identifier": map[string]any{
+						"type":        "string",
+						"description": "Playlist URL, watch URL with a list= parameter, or playlist ID",
+					},
+					"languages": map[string]any{
+						"type": "array",
+						"items": map[string]any{
 							"type": "string",
 						},
 						"description": "Preferred language codes",
 					},
-					"continue_on_error": map[string]any{
+					"max_videos": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of videos to fetch",
+						"minimum":     1,
+						"maximum":     200,
+						"default":     models.DefaultPlaylistVideos,
+					},
+					"order": map[string]any{
+						"type":        "string",
+						"enum":        []string{"playlist", "reverse", "shortest", "longest"},
+						"description": "Which videos come first when max_videos cuts the playlist short",
+						"default":     "playlist",
+					},
+					"skip_unavailable": map[string]any{
 						"type":        "boolean",
-						"description": "Continue processing other videos if one fails",
-						"default":     true,
+						"description": "Leave out private and deleted videos instead of reporting them as errors",
+						"default":     false,
 					},
-					"include_metadata": map[string]any{
+					"max_tokens": map[string]any{
+						"type":        "integer",
+						"description": "Maximum estimated tokens shared across all transcripts",
+						"minimum":     1,
+					},
+					"token_strategy": map[string]any{
+						"type":        "string",
+						"enum":        []string{"truncate", "sample", "compress"},
+						"description": "How to fit an over-budget transcript: keep the beginning, sample evenly across the timeline, or drop filler words before sampling",
+						"default":     "truncate",
+					},
+				},
+				"required": []string{"playlist_identifier"},
+			},
+		})
+	}
+
+	if s.config.Tools[models.ToolListChannelVideos] {
+		tools = append(tools, models.MCPTool{
+			Name:        models.ToolListChannelVideos,
+			Description: "List a channel's uploads, newest first, with optional date filters, paging and transcript fetching",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"channel_identifier": map[string]any{
+						"type":        "string",
+						"description": "Channel @handle, channel ID, or a /@handle, /channel/UC…, /c/ or /user/ URL",
+					},
+					"published_after": map[string]any{
+						"type":        "string",
+						"description": "Only videos published on or after this date (YYYY-MM-DD or RFC 3339). Dates come from YouTube's relative times, so boundaries are approximate",
+					},
+					"published_before": map[string]any{
+						"type":        "string",
+						"description": "Only videos published on or before this date (YYYY-MM-DD or RFC 3339)",
+					},
+					"max_results": map[string]any{
+						"type":        "integer",
+						"description": "Maximum videos per page",
+						"minimum":     1,
+						"maximum":     500,
+						"default":     models.DefaultChannelVideos,
+					},
+					"page_token": map[string]any{
+						"type":        "string",
+						"description": "next_page_token from a previous call to continue the listing",
+					},
+					"include_transcripts": map[string]any{
 						"type":        "boolean",
-						"description": "Whether to include video metadata",
+						"description": "Also fetch transcripts for the listed videos, subject to the rate limits",
 						"default":     false,
 					},
-					"parallel": map[string]any{
+					"languages": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type": "string",
+						},
+						"description": "Preferred language codes for transcripts",
+					},
+					"max_tokens": map[string]any{
+						"type":        "integer",
+						"description": "Maximum estimated tokens shared across all transcripts",
+						"minimum":     1,
+					},
+					"token_strategy": map[string]any{
+						"type":        "string",
+						"enum":        []string{"truncate", "sample", "compress"},
+						"description": "How to fit an over-budget transcript: keep the beginning, sample evenly across the timeline, or drop filler words before sampling",
+						"default":     "truncate",
+					},
+				},
+				"required": []string{"channel_identifier"},
+			},
+		})
+	}
+
+	if s.config.Tools[models.ToolSearchVideos] {
+		tools = append(tools, models.MCPTool{
+			Name:        models.ToolSearchVideos,
+			Description: "Search YouTube for videos on a topic and return candidate video IDs with titles, channels, durations and view counts",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"query": map[string]any{
+						"type":        "string",
+						"description": "Search terms",
+					},
+					"duration": map[string]any{
+						"type":        "string",
+						"enum":        []string{"short", "medium", "long"},
+						"description": "Video length: short (under 4 minutes), medium (4-20 minutes) or long (over 20 minutes)",
+					},
+					"upload_date": map[string]any{
+						"type":        "string",
+						"enum":        []string{"hour", "today", "week", "month", "year"},
+						"description": "Only videos uploaded within this period",
+					},
+					"has_captions": map[string]any{
 						"type":        "boolean",
-						"description": "Process videos in parallel for faster results",
-						"default":     true,
+						"description": "Only videos with subtitles or closed captions",
+						"default":     false,
+					},
+					"max_results": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of videos to return",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     models.DefaultSearchVideos,
 					},
 				},
-				"required": []string{"video_identifiers"},
+				"required": []string{"query"},
 			},
 		})
 	}
 
-	if s.config.Tools[models.ToolTranslateTranscript] {
+	if s.config.Tools[models.ToolGetLiveChatReplay] {
 		tools = append(tools, models.MCPTool{
-			Name:        models.ToolTranslateTranscript,
-			Description: "Translate a video transcript to a target language",
+			Name:        models.ToolGetLiveChatReplay,
+			Description: "Get the chat replay of a finished live stream, optionally merged with the captions into one timeline",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"video_identifier": map[string]any{
+						"type":        "string",
+						"description": "YouTube video URL or ID of a past live stream or premiere",
+					},
+					"start": map[string]any{
+						"type":        []string{"number", "string"},
+						"description": "Only messages from this offset, in seconds or HH:MM:SS",
+					},
+					"end": map[string]any{
+						"type":        []string{"number", "string"},
+						"description": "Only messages before this offset, in seconds or HH:MM:SS",
+					},
+					"message_types": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type": "string",
+							"enum": []string{"text", "super_chat", "super_sticker", "membership"},
+						},
+						"description": "Message types to keep (defaults to all)",
+					},
+					"max_messages": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of messages to return",
+						"minimum":     1,
+						"maximum":     10000,
+						"default":     models.DefaultChatMessages,
+					},
+					"include_transcript": map[string]any{
+						"type":        "boolean",
+						"description": "Merge the caption transcript and chat into one timeline ordered by offset",
+						"default":     false,
+					},
+					"languages": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type": "string",
+						},
+						"description": "Preferred caption language codes for the merged timeline",
+					},
+					"page_token": map[string]any{
+						"type":        "string",
+						"description": "next_page_token from a previous call to continue a long replay where it stopped",
+					},
+				},
+				"required": []string{"video_identifier"},
+			},
+		})
+	}
+
+	if s.config.Tools[models.ToolGetBilingualTranscript] {
+		tools = append(tools, models.MCPTool{
+			Name:        models.ToolGetBilingualTranscript,
+			Description: "Get a transcript in two languages aligned by time, as JSON pairs, a two-column Markdown table or dual-line subtitles",
 			InputSchema: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
@@ -660,31 +2187,81 @@ func (s *Server) getAvailableTools() []models.MCPTool {
 						"type":        "string",
 						"description": "YouTube video URL or ID",
 					},
-					"target_language": map[string]any{
+					"primary_language": map[string]any{
 						"type":        "string",
-						"description": "Target language code (e.g., 'ja', 'es', 'fr')",
+						"description": "Language code of the first column or upper subtitle line",
 						"minLength":   2,
-						"maxLength":   5,
+						"maxLength":   10,
 					},
-					"source_language": map[string]any{
+					"secondary_language": map[string]any{
 						"type":        "string",
-						"description": "Source language code (optional, auto-detected if not specified)",
+						"description": "Language code of the second column or lower subtitle line",
+						"minLength":   2,
+						"maxLength":   10,
 					},
-					"preserve_timestamps": map[string]any{
-						"type":        "boolean",
-						"description": "Whether to preserve timestamp information",
-						"default":     true,
+					"format_type": map[string]any{
+						"type":        "string",
+						"enum":        []string{"json", "markdown", "srt", "vtt"},
+						"description": "Output format",
+						"default":     "json",
 					},
 				},
-				"required": []string{"video_identifier", "target_language"},
+				"required": []string{"video_identifier", "primary_language", "secondary_language"},
 			},
 		})
 	}
 
-	if s.config.Tools[models.ToolFormatTranscript] {
+	if s.config.Tools[models.ToolCompareTranscripts] {
+		transcriptReference := func(description string) map[string]any {
+			return map[string]any{
+				"type":        "object",
+				"description": description,
+				"properties": map[string]any{
+					"video_identifier": map[string]any{
+						"type":        "string",
+						"description": "YouTube video URL or ID",
+					},
+					"language": map[string]any{
+						"type":        "string",
+						"description": "Caption language code (defaults to the configured languages)",
+						"minLength":   2,
+						"maxLength":   10,
+					},
+					"track_type": map[string]any{
+						"type":        "string",
+						"enum":        []string{"manual", "auto"},
+						"description": "Manual or auto-generated captions (defaults to either)",
+					},
+				},
+				"required": []string{"video_identifier"},
+			}
+		}
+
 		tools = append(tools, models.MCPTool{
-			Name:        models.ToolFormatTranscript,
-			Description: "Format a transcript in various styles",
+			Name:        models.ToolCompareTranscripts,
+			Description: "Compare two caption tracks word by word, with a timestamped diff, a similarity score and the word error rate of the candidate against the reference",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"reference": transcriptReference("Caption track treated as ground truth, usually the manual track or the original upload"),
+					"candidate": transcriptReference("Caption track measured against the reference, usually the auto-generated track or a re-upload"),
+					"max_differences": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of differences to list",
+						"minimum":     1,
+						"maximum":     5000,
+						"default":     models.DefaultMaxDifferences,
+					},
+				},
+				"required": []string{"reference", "candidate"},
+			},
+		})
+	}
+
+	if s.config.Tools[models.ToolAnalyzeTranscript] {
+		tools = append(tools, models.MCPTool{
+			Name:        models.ToolAnalyzeTranscript,
+			Description: "Analyze a transcript: words per minute overall and per window, pauses and music-only stretches, caption tag counts, vocabulary richness and top terms",
 			InputSchema: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
@@ -692,54 +2269,199 @@ func (s *Server) getAvailableTools() []models.MCPTool {
 						"type":        "string",
 						"description": "YouTube video URL or ID",
 					},
-					"format_type": map[string]any{
+					"languages": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type": "string",
+						},
+						"description": "Preferred language codes in order of preference",
+					},
+					"window_seconds": map[string]any{
+						"type":        "integer",
+						"description": "Length of the windows the speaking rate is measured over",
+						"minimum":     10,
+						"maximum":     3600,
+						"default":     models.DefaultAnalysisWindow,
+					},
+					"pause_seconds": map[string]any{
+						"type":             "number",
+						"description":      "Shortest gap without speech reported as a pause",
+						"exclusiveMinimum": 0,
+						"maximum":          60,
+						"default":          models.DefaultPauseSeconds,
+					},
+					"top_terms": map[string]any{
+						"type":        "integer",
+						"description": "Number of top words, two-word and three-word phrases to return, without stopwords",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     models.DefaultTopTerms,
+					},
+				},
+				"required": []string{"video_identifier"},
+			},
+		})
+	}
+
+	if s.config.Tools[models.ToolExtractKeywords] {
+		tools = append(tools, models.MCPTool{
+			Name:        models.ToolExtractKeywords,
+			Description: "Extract distinctive keyphrases per video with TF-IDF, with the timestamps where they appear, and the themes shared across the batch",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"video_identifiers": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type": "string",
+						},
+						"description": "YouTube video URLs or IDs",
+						"minItems":    1,
+						"maxItems":    50,
+					},
+					"languages": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type": "string",
+						},
+						"description": "Preferred language codes in order of preference",
+					},
+					"max_keywords": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of keyphrases per video",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     models.DefaultMaxKeywords,
+					},
+					"max_themes": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of themes shared across videos",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     models.DefaultMaxThemes,
+					},
+				},
+				"required": []string{"video_identifiers"},
+			},
+		})
+	}
+
+	if s.config.Tools[models.ToolSearchLibrary] {
+		tools = append(tools, models.MCPTool{
+			Name:        models.ToolSearchLibrary,
+			Description: "Search every transcript fetched so far, across videos, with ranked segment hits and links to the moment they are said",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"query": map[string]any{
 						"type":        "string",
-						"enum":        []string{"plain_text", "paragraphs", "sentences", "srt", "vtt", "json"},
-						"description": "Output format type",
-						"default":     "plain_text",
+						"description": "Words must all match; use \"quoted phrases\", OR, NOT or -word, and parentheses",
+						"minLength":   1,
+						"maxLength":   500,
 					},
-					"include_timestamps": map[string]any{
-						"type":        "boolean",
-						"description": "Include timestamps in the formatted output",
-						"default":     false,
+					"channel": map[string]any{
+						"type":        "string",
+						"description": "Only videos of this channel, by channel ID or part of its name",
 					},
-					"timestamp_format": map[string]any{
+					"language": map[string]any{
 						"type":        "string",
-						"enum":        []string{"seconds", "hms", "ms"},
-						"description": "Timestamp format (seconds, HH:MM:SS, or HH:MM:SS,mmm)",
-						"default":     "seconds",
+						"description": "Only transcripts in this language code",
 					},
-					"max_line_length": map[string]any{
+					"published_after": map[string]any{
+						"type":        "string",
+						"description": "Only videos published on or after this date (YYYY-MM-DD or RFC 3339)",
+					},
+					"published_before": map[string]any{
+						"type":        "string",
+						"description": "Only videos published on or before this date (YYYY-MM-DD or RFC 3339)",
+					},
+					"max_results": map[string]any{
 						"type":        "integer",
-						"description": "Maximum characters per line (for subtitle formats)",
-						"default":     80,
-						"minimum":     20,
+						"description": "Maximum number of hits to return",
+						"minimum":     1,
 						"maximum":     200,
+						"default":     models.DefaultLibraryResults,
 					},
 				},
-				"required": []string{"video_identifier"},
+				"required": []string{"query"},
 			},
 		})
 	}
 
-	if s.config.Tools[models.ToolListLanguages] {
+	if s.config.Tools[models.ToolSemanticSearch] {
 		tools = append(tools, models.MCPTool{
-			Name:        models.ToolListLanguages,
-			Description: "List available transcript languages for a video",
+			Name:        models.ToolSemanticSearch,
+			Description: "Search every transcript fetched so far by meaning rather than exact words, returning the closest passages with their video, time range and link",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"query": map[string]any{
+						"type":        "string",
+						"description": "What to look for, in natural language",
+						"minLength":   1,
+						"maxLength":   1000,
+					},
+					"channel": map[string]any{
+						"type":        "string",
+						"description": "Only videos of this channel, by channel ID or part of its name",
+					},
+					"language": map[string]any{
+						"type":        "string",
+						"description": "Only transcripts in this language code",
+					},
+					"max_results": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of passages to return",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     models.DefaultSemanticResults,
+					},
+				},
+				"required": []string{"query"},
+			},
+		})
+	}
+
+	if s.config.Tools[models.ToolLocateQuote] {
+		tools = append(tools, models.MCPTool{
+			Name:        models.ToolLocateQuote,
+			Description: "Find where a quote, even a half-remembered one, was said in a video, with the exact wording, times, a confidence score and a ready-to-use citation with a timestamped link",
 			InputSchema: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
 					"video_identifier": map[string]any{
 						"type":        "string",
-						"description": "YouTube video URL or ID",
+						"description": "YouTube video URL or video ID",
 					},
-					"include_auto": map[string]any{
-						"type":        "boolean",
-						"description": "Include auto-generated transcripts in the list",
-						"default":     true,
+					"quote": map[string]any{
+						"type":        "string",
+						"description": "The quote to look for; punctuation, case and small wording differences do not matter",
+						"minLength":   1,
+						"maxLength":   1000,
+					},
+					"languages": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type": "string",
+						},
+						"description": "Preferred language codes (e.g., ['en', 'ja']). If not specified, uses default languages.",
+					},
+					"max_results": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of matches to return",
+						"minimum":     1,
+						"maximum":     20,
+						"default":     models.DefaultQuoteMatches,
+					},
+					"min_confidence": map[string]any{
+						"type":        "number",
+						"description": "Minimum confidence of a match, from 0 to 1",
+						"minimum":     0,
+						"maximum":     1,
+						"default":     models.DefaultQuoteConfidence,
 					},
 				},
-				"required": []string{"video_identifier"},
+				"required": []string{"video_identifier", "quote"},
 			},
 		})
 	}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,6 +23,25 @@ type mockYouTubeService struct {
 	listAvailableLanguagesFunc func(ctx context.Context, videoID string) (*models.AvailableLanguagesResponse, error)
 	translateTranscriptFunc    func(ctx context.Context, videoID, targetLang, sourceLang string) (*models.TranscriptResponse, error)
 	formatTranscriptFunc       func(ctx context.Context, videoID, formatType string, includeTimestamps bool) (*models.TranscriptResponse, error)
+	searchTranscriptFunc       func(ctx context.Context, videoID string, opts models.TranscriptSearchOptions) (*models.SearchTranscriptResponse, error)
+	getTranscriptRangeFunc     func(ctx context.Context, videoID string, opts models.TranscriptRangeOptions) (*models.TranscriptRangeResponse, error)
+	getChaptersFunc            func(ctx context.Context, videoID string) (*models.ChaptersResponse, error)
+	getVideoMetadataFunc       func(ctx context.Context, videoID string) (*models.VideoInfo, error)
+	getPlaylistTranscriptsFunc func(ctx context.Context, playlistID string, opts models.PlaylistOptions) (*models.PlaylistTranscriptsResponse, error)
+	listChannelVideosFunc      func(ctx context.Context, channelID string, opts models.ChannelVideosOptions) (*models.ChannelVideosResponse, error)
+	searchVideosFunc           func(ctx context.Context, opts models.VideoSearchOptions) (*models.SearchVideosResponse, error)
+	getLiveChatReplayFunc      func(ctx context.Context, videoID string, opts models.LiveChatOptions) (*models.LiveChatReplayResponse, error)
+	getBilingualTranscriptFunc func(ctx context.Context, videoID string, opts models.BilingualOptions) (*models.BilingualTranscriptResponse, error)
+	compareTranscriptsFunc     func(ctx context.Context, reference, candidate models.TranscriptReference, maxDifferences int) (*models.TranscriptComparisonResponse, error)
+	analyzeTranscriptFunc      func(ctx context.Context, videoID string, opts models.AnalysisOptions) (*models.TranscriptAnalysis, error)
+	extractKeywordsFunc        func(ctx context.Context, videoIDs []string, opts models.KeywordOptions) (*models.KeywordsResponse, error)
+	searchLibraryFunc          func(ctx context.Context, opts models.LibrarySearchOptions) (*models.LibrarySearchResponse, error)
+	semanticSearchFunc         func(ctx context.Context, opts models.SemanticSearchOptions) (*models.SemanticSearchResponse, error)
+	locateQuoteFunc            func(ctx context.Context, videoID string, opts models.QuoteOptions) (*models.LocateQuoteResponse, error)
+
+	// The options last passed to the *WithOptions methods
+	transcriptOptions models.TranscriptOptions
+	formatOptions     models.FormatOptions
 }
 
 func (m *mockYouTubeService) GetTranscript(ctx context.Context, videoID string, languages []string, preserveFormatting bool) (*models.TranscriptResponse, error) {
@@ -81,6 +102,176 @@ func (m *mockYouTubeService) FormatTranscript(ctx context.Context, videoID, form
 	}, nil
 }
 
+func (m *mockYouTubeService) GetTranscriptWithOptions(ctx context.Context, videoID string, languages []string, preserveFormatting bool, opts models.TranscriptOptions) (*models.TranscriptResponse, error) {
+	m.transcriptOptions = opts
+	return m.GetTranscript(ctx, videoID, languages, preserveFormatting)
+}
+
+func (m *mockYouTubeService) GetMultipleTranscriptsWithOptions(ctx context.Context, videoIDs []string, languages []string, continueOnError bool, opts models.TranscriptOptions) (*models.MultipleTranscriptResponse, error) {
+	m.transcriptOptions = opts
+	return m.GetMultipleTranscripts(ctx, videoIDs, languages, continueOnError)
+}
+
+func (m *mockYouTubeService) FormatTranscriptWithOptions(ctx context.Context, videoID string, opts models.FormatOptions) (*models.TranscriptResponse, error) {
+	m.formatOptions = opts
+	return m.FormatTranscript(ctx, videoID, opts.FormatType, opts.IncludeTimestamps)
+}
+
+func (m *mockYouTubeService) SearchTranscript(ctx context.Context, videoID string, opts models.TranscriptSearchOptions) (*models.SearchTranscriptResponse, error) {
+	if m.searchTranscriptFunc != nil {
+		return m.searchTranscriptFunc(ctx, videoID, opts)
+	}
+	return &models.SearchTranscriptResponse{
+		VideoID: videoID,
+		Query:   opts.Query,
+		Mode:    opts.Mode,
+		Matches: []models.TranscriptSearchMatch{},
+	}, nil
+}
+
+func (m *mockYouTubeService) GetTranscriptRange(ctx context.Context, videoID string, opts models.TranscriptRangeOptions) (*models.TranscriptRangeResponse, error) {
+	if m.getTranscriptRangeFunc != nil {
+		return m.getTranscriptRangeFunc(ctx, videoID, opts)
+	}
+	return &models.TranscriptRangeResponse{
+		VideoID:       videoID,
+		FormatType:    opts.Format.FormatType,
+		FormattedText: "Range text",
+	}, nil
+}
+
+func (m *mockYouTubeService) GetChapters(ctx context.Context, videoID string) (*models.ChaptersResponse, error) {
+	if m.getChaptersFunc != nil {
+		return m.getChaptersFunc(ctx, videoID)
+	}
+	return &models.ChaptersResponse{
+		VideoID:  videoID,
+		Chapters: []models.Chapter{},
+	}, nil
+}
+
+func (m *mockYouTubeService) GetVideoMetadata(ctx context.Context, videoID string) (*models.VideoInfo, error) {
+	if m.getVideoMetadataFunc != nil {
+		return m.getVideoMetadataFunc(ctx, videoID)
+	}
+	return &models.VideoInfo{
+		ID:    videoID,
+		Title: "Test Video",
+	}, nil
+}
+
+func (m *mockYouTubeService) GetPlaylistTranscripts(ctx context.Context, playlistID string, opts models.PlaylistOptions) (*models.PlaylistTranscriptsResponse, error) {
+	if m.getPlaylistTranscriptsFunc != nil {
+		return m.getPlaylistTranscriptsFunc(ctx, playlistID, opts)
+	}
+	return &models.PlaylistTranscriptsResponse{
+		MultipleTranscriptResponse: &models.MultipleTranscriptResponse{
+			Results: []models.TranscriptResult{},
+		},
+		PlaylistID: playlistID,
+		Videos:     []models.PlaylistVideo{},
+	}, nil
+}
+
+func (m *mockYouTubeService) ListChannelVideos(ctx context.Context, channelID string, opts models.ChannelVideosOptions) (*models.ChannelVideosResponse, error) {
+	if m.listChannelVideosFunc != nil {
+		return m.listChannelVideosFunc(ctx, channelID, opts)
+	}
+	return &models.ChannelVideosResponse{
+		Videos: []models.ChannelVideo{},
+	}, nil
+}
+
+func (m *mockYouTubeService) SearchVideos(ctx context.Context, opts models.VideoSearchOptions) (*models.SearchVideosResponse, error) {
+	if m.searchVideosFunc != nil {
+		return m.searchVideosFunc(ctx, opts)
+	}
+	return &models.SearchVideosResponse{
+		Query:   opts.Query,
+		Results: []models.VideoSearchResult{},
+	}, nil
+}
+
+func (m *mockYouTubeService) GetLiveChatReplay(ctx context.Context, videoID string, opts models.LiveChatOptions) (*models.LiveChatReplayResponse, error) {
+	if m.getLiveChatReplayFunc != nil {
+		return m.getLiveChatReplayFunc(ctx, videoID, opts)
+	}
+	return &models.LiveChatReplayResponse{
+		VideoID:  videoID,
+		Messages: []models.LiveChatMessage{},
+	}, nil
+}
+
+func (m *mockYouTubeService) GetBilingualTranscript(ctx context.Context, videoID string, opts models.BilingualOptions) (*models.BilingualTranscriptResponse, error) {
+	if m.getBilingualTranscriptFunc != nil {
+		return m.getBilingualTranscriptFunc(ctx, videoID, opts)
+	}
+	return &models.BilingualTranscriptResponse{
+		VideoID:  videoID,
+		Segments: []models.BilingualSegment{},
+	}, nil
+}
+
+func (m *mockYouTubeService) CompareTranscripts(ctx context.Context, reference, candidate models.TranscriptReference, maxDifferences int) (*models.TranscriptComparisonResponse, error) {
+	if m.compareTranscriptsFunc != nil {
+		return m.compareTranscriptsFunc(ctx, reference, candidate, maxDifferences)
+	}
+	return &models.TranscriptComparisonResponse{
+		Differences: []models.TranscriptDifference{},
+	}, nil
+}
+
+func (m *mockYouTubeService) AnalyzeTranscript(ctx context.Context, videoID string, opts models.AnalysisOptions) (*models.TranscriptAnalysis, error) {
+	if m.analyzeTranscriptFunc != nil {
+		return m.analyzeTranscriptFunc(ctx, videoID, opts)
+	}
+	return &models.TranscriptAnalysis{
+		VideoID:   videoID,
+		TagCounts: map[string]int{},
+	}, nil
+}
+
+func (m *mockYouTubeService) ExtractKeywords(ctx context.Context, videoIDs []string, opts models.KeywordOptions) (*models.KeywordsResponse, error) {
+	if m.extractKeywordsFunc != nil {
+		return m.extractKeywordsFunc(ctx, videoIDs, opts)
+	}
+	return &models.KeywordsResponse{
+		Videos: []models.VideoKeywords{},
+		Themes: []models.KeywordTheme{},
+	}, nil
+}
+
+func (m *mockYouTubeService) SearchLibrary(ctx context.Context, opts models.LibrarySearchOptions) (*models.LibrarySearchResponse, error) {
+	if m.searchLibraryFunc != nil {
+		return m.searchLibraryFunc(ctx, opts)
+	}
+	return &models.LibrarySearchResponse{
+		Query: opts.Query,
+		Hits:  []models.LibraryHit{},
+	}, nil
+}
+
+func (m *mockYouTubeService) SemanticSearch(ctx context.Context, opts models.SemanticSearchOptions) (*models.SemanticSearchResponse, error) {
+	if m.semanticSearchFunc != nil {
+		return m.semanticSearchFunc(ctx, opts)
+	}
+	return &models.SemanticSearchResponse{
+		Query: opts.Query,
+		Hits:  []models.LibraryHit{},
+	}, nil
+}
+
+func (m *mockYouTubeService) LocateQuote(ctx context.Context, videoID string, opts models.QuoteOptions) (*models.LocateQuoteResponse, error) {
+	if m.locateQuoteFunc != nil {
+		return m.locateQuoteFunc(ctx, videoID, opts)
+	}
+	return &models.LocateQuoteResponse{
+		VideoID: videoID,
+		Quote:   opts.Quote,
+		Matches: []models.QuoteMatch{},
+	}, nil
+}
+
 func TestHandleMCP_Initialize(t *testing.T) {
 	mockYT := &mockYouTubeService{}
 	cfg := config.MCPConfig{
@@ -223,8 +414,12 @@ func TestHandleMCP_CallTool_GetTranscript(t *testing.T) {
 		Params: map[string]any{
 			"name": "get_transcript",
 			"arguments": map[string]any{
-				"video_identifier": "test123",
-				"languages":        []string{"en"},
+				"video_identifier":     "test123",
+				"languages":            []string{"en"},
+				"max_tokens":           500,
+				"token_strategy":       "sample",
+				"annotations":          "strip",
+				"include_word_timings": true,
 			},
 		},
 	}
@@ -260,6 +455,125 @@ func TestHandleMCP_CallTool_GetTranscript(t *testing.T) {
 	if !ok || len(content) == 0 {
 		t.Fatal("Expected content array")
 	}
+
+	expected := models.TranscriptOptions{MaxTokens: 500, TokenStrategy: "sample", Annotations: "strip", IncludeWordTimings: true}
+	if mockYT.transcriptOptions != expected {
+		t.Errorf("Expected options %+v, got %+v", expected, mockYT.transcriptOptions)
+	}
+}
+
+func TestHandleMCP_CallTool_GetMultipleTranscripts(t *testing.T) {
+	mockYT := &mockYouTubeService{}
+
+	cfg := config.MCPConfig{
+		MaxRequestSize: 5 * 1024 * 1024, // 5MB
+		RequestTimeout: 60 * time.Second,
+		Tools: map[string]bool{
+			"get_multiple_transcripts": true,
+		},
+	}
+
+	server := NewServer(mockYT, cfg, slog.Default())
+
+	request := models.MCPRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  models.MCPMethodCallTool,
+		Params: map[string]any{
+			"name": "get_multiple_transcripts",
+			"arguments": map[string]any{
+				"video_identifiers": []string{"test123", "test456"},
+				"max_tokens":        800,
+				"token_strategy":    "compress",
+				"annotations":       "tag",
+			},
+		},
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest("POST", "/mcp", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	server.HandleMCP(rec, req)
+
+	var response models.MCPResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if response.Error != nil {
+		t.Fatalf("Unexpected error: %v", response.Error)
+	}
+
+	expected := models.TranscriptOptions{MaxTokens: 800, TokenStrategy: "compress", Annotations: "tag"}
+	if mockYT.transcriptOptions != expected {
+		t.Errorf("Expected options %+v, got %+v", expected, mockYT.transcriptOptions)
+	}
+}
+
+func TestHandleMCP_CallTool_FormatTranscript(t *testing.T) {
+	mockYT := &mockYouTubeService{}
+
+	cfg := config.MCPConfig{
+		MaxRequestSize: 5 * 1024 * 1024, // 5MB
+		RequestTimeout: 60 * time.Second,
+		Tools: map[string]bool{
+			"format_transcript": true,
+		},
+	}
+
+	server := NewServer(mockYT, cfg, slog.Default())
+
+	request := models.MCPRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  models.MCPMethodCallTool,
+		Params: map[string]any{
+			"name": "format_transcript",
+			"arguments": map[string]any{
+				"video_identifier":   "test123",
+				"format_type":        "paragraphs",
+				"include_timestamps": true,
+				"max_tokens":         300,
+				"token_strategy":     "truncate",
+				"annotations":        "strip",
+				"paragraph_pause":    1.5,
+				"paragraph_seconds":  45,
+				"paragraph_words":    120,
+			},
+		},
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest("POST", "/mcp", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	server.HandleMCP(rec, req)
+
+	var response models.MCPResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if response.Error != nil {
+		t.Fatalf("Unexpected error: %v", response.Error)
+	}
+
+	expected := models.FormatOptions{
+		FormatType:        "paragraphs",
+		IncludeTimestamps: true,
+		Transcript:        models.TranscriptOptions{MaxTokens: 300, TokenStrategy: "truncate", Annotations: "strip"},
+		Paragraphs:        models.ParagraphOptions{PauseSeconds: 1.5, TargetSeconds: 45, TargetWords: 120},
+	}
+	if mockYT.formatOptions != expected {
+		t.Errorf("Expected options %+v, got %+v", expected, mockYT.formatOptions)
+	}
 }
 
 func TestHandleMCP_InvalidMethod(t *testing.T) {
@@ -516,3 +830,630 @@ func TestValidation(t *testing.T) {
 		t.Errorf("Expected invalid params error, got %d", response.Error.Code)
 	}
 }
+
+func TestHandleMCP_CallTool_GetTranscriptRange(t *testing.T) {
+	var received models.TranscriptRangeOptions
+	mockYT := &mockYouTubeService{
+		getTranscriptRangeFunc: func(ctx context.Context, videoID string, opts models.TranscriptRangeOptions) (*models.TranscriptRangeResponse, error) {
+			received = opts
+			return &models.TranscriptRangeResponse{
+				VideoID:       videoID,
+				FormatType:    opts.Format.FormatType,
+				FormattedText: "WEBVTT",
+			}, nil
+		},
+	}
+
+	cfg := config.MCPConfig{
+		MaxRequestSize: 5 * 1024 * 1024, // 5MB
+		RequestTimeout: 60 * time.Second,
+		Tools: map[string]bool{
+			"get_transcript_range": true,
+		},
+	}
+
+	server := NewServer(mockYT, cfg, slog.Default())
+
+	request := models.MCPRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  models.MCPMethodCallTool,
+		Params: map[string]any{
+			"name": "get_transcript_range",
+			"arguments": map[string]any{
+				"video_identifier":  "test123",
+				"start":             "12:30",
+				"end":               1080,
+				"format_type":       "vtt",
+				"rebase_timestamps": true,
+			},
+		},
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest("POST", "/mcp", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	server.HandleMCP(rec, req)
+
+	var response models.MCPResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if response.Error != nil {
+		t.Fatalf("Unexpected error: %v", response.Error)
+	}
+
+	if received.Start == nil || *received.Start != 750 {
+		t.Errorf("Expected start 750, got %v", received.Start)
+	}
+	if received.End == nil || *received.End != 1080 {
+		t.Errorf("Expected end 1080, got %v", received.End)
+	}
+	if !received.Rebase || received.Format.FormatType != models.FormatTypeVTT {
+		t.Errorf("Unexpected options: %+v", received)
+	}
+}
+
+func TestHandleMCP_CallTool_ListChannelVideos(t *testing.T) {
+	var received models.ChannelVideosOptions
+	mockYT := &mockYouTubeService{
+		listChannelVideosFunc: func(ctx context.Context, channelID string, opts models.ChannelVideosOptions) (*models.ChannelVideosResponse, error) {
+			received = opts
+			return &models.ChannelVideosResponse{Videos: []models.ChannelVideo{}}, nil
+		},
+	}
+
+	cfg := config.MCPConfig{
+		MaxRequestSize: 5 * 1024 * 1024, // 5MB
+		RequestTimeout: 60 * time.Second,
+		Tools: map[string]bool{
+			"list_channel_videos": true,
+		},
+	}
+
+	server := NewServer(mockYT, cfg, slog.Default())
+
+	request := models.MCPRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  models.MCPMethodCallTool,
+		Params: map[string]any{
+			"name": "list_channel_videos",
+			"arguments": map[string]any{
+				"channel_identifier": "@example",
+				"published_after":    "2024-01-01",
+				"published_before":   "2024-01-31",
+			},
+		},
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest("POST", "/mcp", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	server.HandleMCP(rec, req)
+
+	var response models.MCPResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if response.Error != nil {
+		t.Fatalf("Unexpected error: %v", response.Error)
+	}
+
+	if received.PublishedAfter == nil || !received.PublishedAfter.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected published_after: %v", received.PublishedAfter)
+	}
+	if received.PublishedBefore == nil || received.PublishedBefore.Day() != 31 || received.PublishedBefore.Hour() != 23 {
+		t.Errorf("Expected published_before to cover the whole day, got %v", received.PublishedBefore)
+	}
+	if received.MaxResults != models.DefaultChannelVideos {
+		t.Errorf("Expected default max results, got %d", received.MaxResults)
+	}
+}
+
+func TestHandleMCP_CallTool_GetLiveChatReplay(t *testing.T) {
+	var received models.LiveChatOptions
+	mockYT := &mockYouTubeService{
+		getLiveChatReplayFunc: func(ctx context.Context, videoID string, opts models.LiveChatOptions) (*models.LiveChatReplayResponse, error) {
+			received = opts
+			return &models.LiveChatReplayResponse{VideoID: videoID, Messages: []models.LiveChatMessage{}}, nil
+		},
+	}
+
+	cfg := config.MCPConfig{
+		MaxRequestSize: 5 * 1024 * 1024, // 5MB
+		RequestTimeout: 60 * time.Second,
+		Tools: map[string]bool{
+			"get_live_chat_replay": true,
+		},
+	}
+
+	server := NewServer(mockYT, cfg, slog.Default())
+
+	call := func(arguments map[string]any) models.MCPResponse {
+		request := models.MCPRequest{
+			JSONRPC: "2.0",
+			ID:      1,
+			Method:  models.MCPMethodCallTool,
+			Params: map[string]any{
+				"name":      "get_live_chat_replay",
+				"arguments": arguments,
+			},
+		}
+		body, err := json.Marshal(request)
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+		req := httptest.NewRequest("POST", "/mcp", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		server.HandleMCP(rec, req)
+
+		var response models.MCPResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response
+	}
+
+	response := call(map[string]any{
+		"video_identifier":   "dQw4w9WgXcQ",
+		"start":              "1:00:00",
+		"end":                3900,
+		"message_types":      []string{"super_chat", "super_sticker"},
+		"include_transcript": true,
+	})
+	if response.Error != nil {
+		t.Fatalf("Unexpected error: %v", response.Error)
+	}
+	if received.Start == nil || *received.Start != 3600 || received.End == nil || *received.End != 3900 {
+		t.Errorf("Unexpected range: %v-%v", received.Start, received.End)
+	}
+	if len(received.MessageTypes) != 2 || !received.IncludeTranscript || received.MaxMessages != models.DefaultChatMessages {
+		t.Errorf("Unexpected options: %+v", received)
+	}
+
+	response = call(map[string]any{
+		"video_identifier": "dQw4w9WgXcQ",
+		"message_types":    []string{"poll"},
+	})
+	if response.Error == nil || response.Error.Code != models.MCPErrorCodeInvalidParams {
+		t.Errorf("Expected invalid params error for unknown message type, got %+v", response.Error)
+	}
+}
+
+func TestHandleMCP_CallTool_GetBilingualTranscript(t *testing.T) {
+	var received models.BilingualOptions
+	mockYT := &mockYouTubeService{
+		getBilingualTranscriptFunc: func(ctx context.Context, videoID string, opts models.BilingualOptions) (*models.BilingualTranscriptResponse, error) {
+			received = opts
+			return &models.BilingualTranscriptResponse{VideoID: videoID, FormattedText: "| Time | en | de |"}, nil
+		},
+	}
+
+	cfg := config.MCPConfig{
+		MaxRequestSize: 5 * 1024 * 1024, // 5MB
+		RequestTimeout: 60 * time.Second,
+		Tools: map[string]bool{
+			"get_bilingual_transcript": true,
+		},
+	}
+
+	server := NewServer(mockYT, cfg, slog.Default())
+
+	call := func(arguments map[string]any) models.MCPResponse {
+		request := models.MCPRequest{
+			JSONRPC: "2.0",
+			ID:      1,
+			Method:  models.MCPMethodCallTool,
+			Params: map[string]any{
+				"name":      "get_bilingual_transcript",
+				"arguments": arguments,
+			},
+		}
+		body, err := json.Marshal(request)
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+		req := httptest.NewRequest("POST", "/mcp", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		server.HandleMCP(rec, req)
+
+		var response models.MCPResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response
+	}
+
+	response := call(map[string]any{
+		"video_identifier":   "dQw4w9WgXcQ",
+		"primary_language":   "en",
+		"secondary_language": "de",
+		"format_type":        "markdown",
+	})
+	if response.Error != nil {
+		t.Fatalf("Unexpected error: %v", response.Error)
+	}
+	if received.PrimaryLanguage != "en" || received.SecondaryLanguage != "de" || received.FormatType != models.FormatTypeMarkdown {
+		t.Errorf("Unexpected options: %+v", received)
+	}
+	if !strings.Contains(fmt.Sprint(response.Result), "| Time | en | de |") {
+		t.Errorf("Expected the Markdown table to be returned as-is, got %v", response.Result)
+	}
+
+	response = call(map[string]any{
+		"video_identifier":   "dQw4w9WgXcQ",
+		"primary_language":   "en",
+		"secondary_language": "en",
+	})
+	if response.Error == nil || response.Error.Code != models.MCPErrorCodeInvalidParams {
+		t.Errorf("Expected invalid params error for identical languages, got %+v", response.Error)
+	}
+}
+
+func TestHandleMCP_CallTool_CompareTranscripts(t *testing.T) {
+	var receivedReference, receivedCandidate models.TranscriptReference
+	var receivedMax int
+	mockYT := &mockYouTubeService{
+		compareTranscriptsFunc: func(ctx context.Context, reference, candidate models.TranscriptReference, maxDifferences int) (*models.TranscriptComparisonResponse, error) {
+			receivedReference, receivedCandidate, receivedMax = reference, candidate, maxDifferences
+			return &models.TranscriptComparisonResponse{Differences: []models.TranscriptDifference{}}, nil
+		},
+	}
+
+	cfg := config.MCPConfig{
+		MaxRequestSize: 5 * 1024 * 1024, // 5MB
+		RequestTimeout: 60 * time.Second,
+		Tools: map[string]bool{
+			"compare_transcripts": true,
+		},
+	}
+
+	server := NewServer(mockYT, cfg, slog.Default())
+
+	call := func(arguments map[string]any) models.MCPResponse {
+		request := models.MCPRequest{
+			JSONRPC: "2.0",
+			ID:      1,
+			Method:  models.MCPMethodCallTool,
+			Params: map[string]any{
+				"name":      "compare_transcripts",
+				"arguments": arguments,
+			},
+		}
+		body, err := json.Marshal(request)
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+		req := httptest.NewRequest("POST", "/mcp", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		server.HandleMCP(rec, req)
+
+		var response models.MCPResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response
+	}
+
+	response := call(map[string]any{
+		"reference": map[string]any{"video_identifier": "dQw4w9WgXcQ", "track_type": "manual"},
+		"candidate": map[string]any{"video_identifier": "dQw4w9WgXcQ", "track_type": "auto", "language": "en"},
+	})
+	if response.Error != nil {
+		t.Fatalf("Unexpected error: %v", response.Error)
+	}
+	if receivedReference.TrackType != "manual" || receivedCandidate.TrackType != "auto" || receivedCandidate.Language != "en" {
+		t.Errorf("Unexpected references: %+v, %+v", receivedReference, receivedCandidate)
+	}
+	if receivedMax != models.DefaultMaxDifferences {
+		t.Errorf("Expected default max differences, got %d", receivedMax)
+	}
+
+	response = call(map[string]any{
+		"reference": map[string]any{"video_identifier": "dQw4w9WgXcQ"},
+		"candidate": map[string]any{"track_type": "auto"},
+	})
+	if response.Error == nil || response.Error.Code != models.MCPErrorCodeInvalidParams {
+		t.Errorf("Expected invalid params error for a candidate without a video, got %+v", response.Error)
+	}
+}
+
+func TestHandleMCP_CallTool_AnalyzeTranscript(t *testing.T) {
+	var received models.AnalysisOptions
+	mockYT := &mockYouTubeService{
+		analyzeTranscriptFunc: func(ctx context.Context, videoID string, opts models.AnalysisOptions) (*models.TranscriptAnalysis, error) {
+			received = opts
+			return &models.TranscriptAnalysis{VideoID: videoID, TagCounts: map[string]int{}}, nil
+		},
+	}
+
+	cfg := config.MCPConfig{
+		MaxRequestSize: 5 * 1024 * 1024, // 5MB
+		RequestTimeout: 60 * time.Second,
+		Tools: map[string]bool{
+			"analyze_transcript": true,
+		},
+	}
+
+	server := NewServer(mockYT, cfg, slog.Default())
+
+	request := models.MCPRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  models.MCPMethodCallTool,
+		Params: map[string]any{
+			"name": "analyze_transcript",
+			"arguments": map[string]any{
+				"video_identifier": "dQw4w9WgXcQ",
+				"pause_seconds":    1.5,
+			},
+		},
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest("POST", "/mcp", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	server.HandleMCP(rec, req)
+
+	var response models.MCPResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Error != nil {
+		t.Fatalf("Unexpected error: %v", response.Error)
+	}
+	if received.PauseSeconds != 1.5 || received.WindowSeconds != models.DefaultAnalysisWindow || received.TopTerms != models.DefaultTopTerms {
+		t.Errorf("Unexpected options: %+v", received)
+	}
+}
+
+func TestHandleMCP_CallTool_ExtractKeywords(t *testing.T) {
+	var receivedIDs []string
+	var received models.KeywordOptions
+	mockYT := &mockYouTubeService{
+		extractKeywordsFunc: func(ctx context.Context, videoIDs []string, opts models.KeywordOptions) (*models.KeywordsResponse, error) {
+			receivedIDs, received = videoIDs, opts
+			return &models.KeywordsResponse{Videos: []models.VideoKeywords{}, Themes: []models.KeywordTheme{}}, nil
+		},
+	}
+
+	cfg := config.MCPConfig{
+		MaxRequestSize: 5 * 1024 * 1024, // 5MB
+		RequestTimeout: 60 * time.Second,
+		Tools: map[string]bool{
+			"extract_keywords": true,
+		},
+	}
+
+	server := NewServer(mockYT, cfg, slog.Default())
+
+	request := models.MCPRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  models.MCPMethodCallTool,
+		Params: map[string]any{
+			"name": "extract_keywords",
+			"arguments": map[string]any{
+				"video_identifiers": []string{"dQw4w9WgXcQ", "jNQXAC9IVRw"},
+				"max_keywords":      5,
+			},
+		},
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest("POST", "/mcp", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	server.HandleMCP(rec, req)
+
+	var response models.MCPResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Error != nil {
+		t.Fatalf("Unexpected error: %v", response.Error)
+	}
+	if len(receivedIDs) != 2 || received.MaxKeywords != 5 || received.MaxThemes != models.DefaultMaxThemes {
+		t.Errorf("Unexpected arguments: %v %+v", receivedIDs, received)
+	}
+}
+
+func TestHandleMCP_CallTool_SearchLibrary(t *testing.T) {
+	var received models.LibrarySearchOptions
+	mockYT := &mockYouTubeService{
+		searchLibraryFunc: func(ctx context.Context, opts models.LibrarySearchOptions) (*models.LibrarySearchResponse, error) {
+			received = opts
+			return &models.LibrarySearchResponse{Query: opts.Query, Hits: []models.LibraryHit{}}, nil
+		},
+	}
+
+	cfg := config.MCPConfig{
+		MaxRequestSize: 5 * 1024 * 1024, // 5MB
+		RequestTimeout: 60 * time.Second,
+		Tools: map[string]bool{
+			"search_library": true,
+		},
+	}
+
+	server := NewServer(mockYT, cfg, slog.Default())
+
+	request := models.MCPRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  models.MCPMethodCallTool,
+		Params: map[string]any{
+			"name": "search_library",
+			"arguments": map[string]any{
+				"query":            `"machine learning" -ads`,
+				"channel":          "Science",
+				"published_after":  "2024-01-01",
+				"published_before": "2024-06-30",
+			},
+		},
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest("POST", "/mcp", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	server.HandleMCP(rec, req)
+
+	var response models.MCPResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Error != nil {
+		t.Fatalf("Unexpected error: %v", response.Error)
+	}
+	if received.Channel != "Science" || received.MaxResults != models.DefaultLibraryResults {
+		t.Errorf("Unexpected options: %+v", received)
+	}
+	if received.PublishedAfter == nil || received.PublishedBefore == nil ||
+		received.PublishedBefore.Format(time.DateTime) != "2024-06-30 23:59:59" {
+		t.Errorf("Unexpected date range: %v - %v", received.PublishedAfter, received.PublishedBefore)
+	}
+}
+
+func TestHandleMCP_CallTool_SemanticSearch(t *testing.T) {
+	var received models.SemanticSearchOptions
+	mockYT := &mockYouTubeService{
+		semanticSearchFunc: func(ctx context.Context, opts models.SemanticSearchOptions) (*models.SemanticSearchResponse, error) {
+			received = opts
+			return nil, &models.TranscriptError{Type: models.ErrorTypeEmbeddingFailed, Message: "Failed to embed query"}
+		},
+	}
+
+	cfg := config.MCPConfig{
+		MaxRequestSize: 5 * 1024 * 1024, // 5MB
+		RequestTimeout: 60 * time.Second,
+		Tools: map[string]bool{
+			"semantic_search": true,
+		},
+	}
+
+	server := NewServer(mockYT, cfg, slog.Default())
+
+	request := models.MCPRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  models.MCPMethodCallTool,
+		Params: map[string]any{
+			"name": "semantic_search",
+			"arguments": map[string]any{
+				"query":    "how do I save money",
+				"language": "en",
+			},
+		},
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest("POST", "/mcp", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	server.HandleMCP(rec, req)
+
+	var response models.MCPResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Error == nil || response.Error.Code != models.MCPErrorCodeServerError {
+		t.Fatalf("Expected a server error, got %+v", response.Error)
+	}
+	if received.Language != "en" || received.MaxResults != models.DefaultSemanticResults {
+		t.Errorf("Unexpected options: %+v", received)
+	}
+}
+
+func TestHandleMCP_CallTool_LocateQuote(t *testing.T) {
+	var receivedID string
+	var received models.QuoteOptions
+	mockYT := &mockYouTubeService{
+		locateQuoteFunc: func(ctx context.Context, videoID string, opts models.QuoteOptions) (*models.LocateQuoteResponse, error) {
+			receivedID, received = videoID, opts
+			return &models.LocateQuoteResponse{VideoID: videoID, Quote: opts.Quote, Matches: []models.QuoteMatch{}}, nil
+		},
+	}
+
+	cfg := config.MCPConfig{
+		MaxRequestSize: 5 * 1024 * 1024, // 5MB
+		RequestTimeout: 60 * time.Second,
+		Tools: map[string]bool{
+			"locate_quote": true,
+		},
+	}
+
+	server := NewServer(mockYT, cfg, slog.Default())
+
+	tests := []struct {
+		name          string
+		arguments     map[string]any
+		minConfidence float64
+	}{
+		{
+			name:          "default confidence",
+			arguments:     map[string]any{"video_identifier": "dQw4w9WgXcQ", "quote": "stay hungry"},
+			minConfidence: models.DefaultQuoteConfidence,
+		},
+		{
+			name:          "explicit zero confidence",
+			arguments:     map[string]any{"video_identifier": "dQw4w9WgXcQ", "quote": "stay hungry", "min_confidence": 0},
+			minConfidence: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := models.MCPRequest{
+				JSONRPC: "2.0",
+				ID:      1,
+				Method:  models.MCPMethodCallTool,
+				Params: map[string]any{
+					"name":      "locate_quote",
+					"arguments": tt.arguments,
+				},
+			}
+			body, err := json.Marshal(request)
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+			req := httptest.NewRequest("POST", "/mcp", bytes.NewReader(body))
+			rec := httptest.NewRecorder()
+
+			server.HandleMCP(rec, req)
+
+			var response models.MCPResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Error != nil {
+				t.Fatalf("Unexpected error: %v", response.Error)
+			}
+			if receivedID != "dQw4w9WgXcQ" || received.MaxResults != models.DefaultQuoteMatches || received.MinConfidence != tt.minConfidence {
+				t.Errorf("Unexpected arguments: %s %+v", receivedID, received)
+			}
+		})
+	}
+}
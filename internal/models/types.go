@@ -2,15 +2,35 @@
 package models
 
 import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
-// TranscriptSegment represents a single segment of transcript with timing information
+// TranscriptSegment represents a single segment of transcript with timing information. Sound
+// events such as [Music] and the >> speaker-change marker found in the caption text are also
+// recorded in SoundEvents and SpeakerChange. When the captions mark speakers, Turn numbers the
+// speaker turn the segment belongs to from 1 and Speaker labels it. Words holds the timing of
+// each word when the caption format provides it.
 type TranscriptSegment struct {
+	Text          string           `json:"text"`
+	Start         float64          `json:"start"`
+	Duration      float64          `json:"duration"`
+	End           float64          `json:"end,omitempty"`
+	SoundEvents   []string         `json:"sound_events,omitempty"`
+	SpeakerChange bool             `json:"speaker_change,omitempty"`
+	Turn          int              `json:"turn,omitempty"`
+	Speaker       string           `json:"speaker,omitempty"`
+	Words         []TranscriptWord `json:"words,omitempty"`
+}
+
+// TranscriptWord is a word of a transcript segment with its own timing
+type TranscriptWord struct {
 	Text     string  `json:"text"`
 	Start    float64 `json:"start"`
 	Duration float64 `json:"duration"`
-	End      float64 `json:"end,omitempty"`
 }
 
 // TranscriptResponse represents the complete transcript response with metadata
@@ -25,7 +45,10 @@ type TranscriptResponse struct {
 	Metadata        TranscriptMetadata  `json:"metadata"`
 	WordCount       int                 `json:"word_count"`
 	CharCount       int                 `json:"char_count"`
+	TokenCount      int                 `json:"token_count,omitempty"`
 	DurationSeconds float64             `json:"duration_seconds"`
+	Truncated       bool                `json:"truncated,omitempty"`
+	Chapters        []ChapterTranscript `json:"chapters,omitempty"`
 }
 
 // TranscriptMetadata contains detailed metadata about the transcript
@@ -40,6 +63,10 @@ type TranscriptMetadata struct {
 	ViewCount           int64     `json:"view_count,omitempty"`
 	LikeCount           int64     `json:"like_count,omitempty"`
 	CommentCount        int64     `json:"comment_count,omitempty"`
+	IsTranslated        bool      `json:"is_translated,omitempty"`
+	SourceLanguage      string    `json:"source_language,omitempty"`
+	TargetLanguage      string    `json:"target_language,omitempty"`
+	TranslationProvider string    `json:"translation_provider,omitempty"`
 }
 
 // MultipleTranscriptResponse represents response for multiple videos
@@ -124,24 +151,32 @@ func (e *MCPError) Error() string {
 type GetTranscriptParams struct {
 	VideoIdentifier    string   `json:"video_identifier" validate:"required"`
 	Languages          []string `json:"languages,omitempty"`
+	TokenStrategy      string   `json:"token_strategy,omitempty" validate:"omitempty,oneof=truncate sample compress"`
+	MaxTokens          int      `json:"max_tokens,omitempty" validate:"omitempty,min=1"`
 	PreserveFormatting bool     `json:"preserve_formatting,omitempty"`
 	IncludeMetadata    bool     `json:"include_metadata,omitempty"`
 	IncludeTimestamps  bool     `json:"include_timestamps,omitempty"`
+	SplitByChapter     bool     `json:"split_by_chapter,omitempty"`
+	Annotations        string   `json:"annotations,omitempty" validate:"omitempty,oneof=keep strip tag"`
+	IncludeWordTimings bool     `json:"include_word_timings,omitempty"`
 }
 
 // GetMultipleTranscriptsParams represents parameters for batch processing
 type GetMultipleTranscriptsParams struct {
 	VideoIdentifiers []string `json:"video_identifiers" validate:"required,min=1,max=50"`
 	Languages        []string `json:"languages,omitempty"`
+	TokenStrategy    string   `json:"token_strategy,omitempty" validate:"omitempty,oneof=truncate sample compress"`
+	MaxTokens        int      `json:"max_tokens,omitempty" validate:"omitempty,min=1"`
 	ContinueOnError  bool     `json:"continue_on_error,omitempty"`
 	IncludeMetadata  bool     `json:"include_metadata,omitempty"`
 	Parallel         bool     `json:"parallel,omitempty"`
+	Annotations      string   `json:"annotations,omitempty" validate:"omitempty,oneof=keep strip tag"`
 }
 
 // TranslateTranscriptParams represents parameters for translation
 type TranslateTranscriptParams struct {
 	VideoIdentifier    string `json:"video_identifier" validate:"required"`
-	TargetLanguage     string `json:"target_language" validate:"required,len=2"`
+	TargetLanguage     string `json:"target_language" validate:"required,min=2,max=10"`
 	SourceLanguage     string `json:"source_language,omitempty"`
 	PreserveTimestamps bool   `json:"preserve_timestamps,omitempty"`
 }
@@ -151,8 +186,732 @@ type FormatTranscriptParams struct {
 	VideoIdentifier   string `json:"video_identifier" validate:"required"`
 	FormatType        string `json:"format_type,omitempty"`
 	TimestampFormat   string `json:"timestamp_format,omitempty"`
+	TokenStrategy     string `json:"token_strategy,omitempty" validate:"omitempty,oneof=truncate sample compress"`
 	MaxLineLength     int    `json:"max_line_length,omitempty"`
+	MaxTokens         int    `json:"max_tokens,omitempty" validate:"omitempty,min=1"`
 	IncludeTimestamps bool   `json:"include_timestamps,omitempty"`
+	Annotations       string `json:"annotations,omitempty" validate:"omitempty,oneof=keep strip tag"`
+	// Paragraph boundaries for the paragraphs format
+	ParagraphPause   float64 `json:"paragraph_pause,omitempty" validate:"omitempty,gt=0"`
+	ParagraphSeconds float64 `json:"paragraph_seconds,omitempty" validate:"omitempty,gt=0"`
+	ParagraphWords   int     `json:"paragraph_words,omitempty" validate:"omitempty,min=1"`
+}
+
+// TranscriptOptions controls optional post-processing of a fetched transcript
+type TranscriptOptions struct {
+	TokenStrategy      string `json:"token_strategy,omitempty"`
+	Annotations        string `json:"annotations,omitempty"` // one of the AnnotationMode constants
+	MaxTokens          int    `json:"max_tokens,omitempty"`
+	SplitByChapter     bool   `json:"split_by_chapter,omitempty"`
+	IncludeWordTimings bool   `json:"include_word_timings,omitempty"` // keep the words of each segment
+}
+
+// GetPlaylistTranscriptsParams represents parameters for the get_playlist_transcripts tool
+type GetPlaylistTranscriptsParams struct {
+	PlaylistIdentifier string   `json:"playlist_identifier" validate:"required"`
+	Languages          []string `json:"languages,omitempty"`
+	Order              string   `json:"order,omitempty" validate:"omitempty,oneof=playlist reverse shortest longest"`
+	TokenStrategy      string   `json:"token_strategy,omitempty" validate:"omitempty,oneof=truncate sample compress"`
+	MaxVideos          int      `json:"max_videos,omitempty" validate:"omitempty,min=1,max=200"`
+	MaxTokens          int      `json:"max_tokens,omitempty" validate:"omitempty,min=1"`
+	SkipUnavailable    bool     `json:"skip_unavailable,omitempty"`
+}
+
+// PlaylistOptions controls which playlist videos are fetched and how
+type PlaylistOptions struct {
+	Order           string            `json:"order,omitempty"`
+	Languages       []string          `json:"languages,omitempty"`
+	Transcript      TranscriptOptions `json:"transcript"`
+	MaxVideos       int               `json:"max_videos,omitempty"`
+	SkipUnavailable bool              `json:"skip_unavailable,omitempty"`
+}
+
+// PlaylistVideo is one entry of a playlist
+type PlaylistVideo struct {
+	VideoID         string  `json:"video_id"`
+	Title           string  `json:"title,omitempty"`
+	Position        int     `json:"position"`
+	DurationSeconds float64 `json:"duration_seconds,omitempty"`
+	Available       bool    `json:"available"`
+}
+
+// PlaylistTranscriptsResponse holds the transcripts of a playlist's videos alongside the playlist entries
+type PlaylistTranscriptsResponse struct {
+	*MultipleTranscriptResponse
+	PlaylistID    string          `json:"playlist_id"`
+	PlaylistTitle string          `json:"playlist_title,omitempty"`
+	Videos        []PlaylistVideo `json:"videos"`
+	SkippedCount  int             `json:"skipped_count,omitempty"`
+}
+
+// ListChannelVideosParams represents parameters for the list_channel_videos tool
+type ListChannelVideosParams struct {
+	ChannelIdentifier  string   `json:"channel_identifier" validate:"required"`
+	PublishedAfter     string   `json:"published_after,omitempty"`
+	PublishedBefore    string   `json:"published_before,omitempty"`
+	PageToken          string   `json:"page_token,omitempty"`
+	Languages          []string `json:"languages,omitempty"`
+	TokenStrategy      string   `json:"token_strategy,omitempty" validate:"omitempty,oneof=truncate sample compress"`
+	MaxResults         int      `json:"max_results,omitempty" validate:"omitempty,min=1,max=500"`
+	MaxTokens          int      `json:"max_tokens,omitempty" validate:"omitempty,min=1"`
+	IncludeTranscripts bool     `json:"include_transcripts,omitempty"`
+}
+
+// ChannelVideosOptions controls which uploads list_channel_videos returns
+type ChannelVideosOptions struct {
+	PublishedAfter     *time.Time        `json:"published_after,omitempty"`
+	PublishedBefore    *time.Time        `json:"published_before,omitempty"`
+	PageToken          string            `json:"page_token,omitempty"`
+	Languages          []string          `json:"languages,omitempty"`
+	Transcript         TranscriptOptions `json:"transcript"`
+	MaxResults         int               `json:"max_results,omitempty"`
+	IncludeTranscripts bool              `json:"include_transcripts,omitempty"`
+}
+
+// ChannelVideo is one upload of a channel. PublishedAt is derived from the relative
+// time YouTube shows ("3 weeks ago") and is only as precise as that text.
+type ChannelVideo struct {
+	PublishedAt     *time.Time `json:"published_at,omitempty"`
+	VideoID         string     `json:"video_id"`
+	Title           string     `json:"title"`
+	PublishedText   string     `json:"published_text,omitempty"`
+	Duration        string     `json:"duration,omitempty"`
+	URL             string     `json:"url"`
+	DurationSeconds float64    `json:"duration_seconds,omitempty"`
+	ViewCount       int64      `json:"view_count,omitempty"`
+}
+
+// ChannelVideosResponse lists a page of a channel's uploads, newest first
+type ChannelVideosResponse struct {
+	Transcripts   *MultipleTranscriptResponse `json:"transcripts,omitempty"`
+	ChannelID     string                      `json:"channel_id,omitempty"`
+	ChannelTitle  string                      `json:"channel_title,omitempty"`
+	NextPageToken string                      `json:"next_page_token,omitempty"`
+	Videos        []ChannelVideo              `json:"videos"`
+}
+
+// SearchVideosParams represents parameters for the search_videos tool
+type SearchVideosParams struct {
+	Query       string `json:"query" validate:"required"`
+	Duration    string `json:"duration,omitempty" validate:"omitempty,oneof=short medium long"`
+	UploadDate  string `json:"upload_date,omitempty" validate:"omitempty,oneof=hour today week month year"`
+	MaxResults  int    `json:"max_results,omitempty" validate:"omitempty,min=1,max=100"`
+	HasCaptions bool   `json:"has_captions,omitempty"`
+}
+
+// VideoSearchOptions controls a YouTube video search
+type VideoSearchOptions struct {
+	Query       string `json:"query"`
+	Duration    string `json:"duration,omitempty"`
+	UploadDate  string `json:"upload_date,omitempty"`
+	MaxResults  int    `json:"max_results,omitempty"`
+	HasCaptions bool   `json:"has_captions,omitempty"`
+}
+
+// VideoSearchResult is one video found by search_videos
+type VideoSearchResult struct {
+	VideoID         string  `json:"video_id"`
+	Title           string  `json:"title"`
+	ChannelName     string  `json:"channel_name,omitempty"`
+	ChannelID       string  `json:"channel_id,omitempty"`
+	Duration        string  `json:"duration,omitempty"`
+	PublishedText   string  `json:"published_text,omitempty"`
+	Snippet         string  `json:"snippet,omitempty"`
+	URL             string  `json:"url"`
+	DurationSeconds float64 `json:"duration_seconds,omitempty"`
+	ViewCount       int64   `json:"view_count,omitempty"`
+	HasCaptions     bool    `json:"has_captions,omitempty"`
+}
+
+// SearchVideosResponse lists the videos found for a query
+type SearchVideosResponse struct {
+	Query            string              `json:"query"`
+	Results          []VideoSearchResult `json:"results"`
+	EstimatedResults int64               `json:"estimated_results,omitempty"`
+}
+
+// GetLiveChatReplayParams represents parameters for the get_live_chat_replay tool
+type GetLiveChatReplayParams struct {
+	VideoIdentifier   string      `json:"video_identifier" validate:"required"`
+	Start             *TimeOffset `json:"start,omitempty"`
+	End               *TimeOffset `json:"end,omitempty"`
+	MessageTypes      []string    `json:"message_types,omitempty" validate:"omitempty,dive,oneof=text super_chat super_sticker membership"`
+	Languages         []string    `json:"languages,omitempty"`
+	MaxMessages       int         `json:"max_messages,omitempty" validate:"omitempty,min=1,max=10000"`
+	IncludeTranscript bool        `json:"include_transcript,omitempty"`
+	PageToken         string      `json:"page_token,omitempty"`
+}
+
+// LiveChatOptions controls which part of a chat replay GetLiveChatReplay returns.
+// Nil bounds mean the start and end of the stream; empty MessageTypes means every type.
+// PageToken continues a replay from the NextPageToken of an earlier response.
+type LiveChatOptions struct {
+	Start             *float64 `json:"start,omitempty"`
+	End               *float64 `json:"end,omitempty"`
+	MessageTypes      []string `json:"message_types,omitempty"`
+	Languages         []string `json:"languages,omitempty"`
+	MaxMessages       int      `json:"max_messages,omitempty"`
+	IncludeTranscript bool     `json:"include_transcript,omitempty"`
+	PageToken         string   `json:"page_token,omitempty"`
+}
+
+// LiveChatMessage is one message of a live chat replay
+type LiveChatMessage struct {
+	ID              string     `json:"id,omitempty"`
+	Type            string     `json:"type"`
+	Author          string     `json:"author"`
+	AuthorChannelID string     `json:"author_channel_id,omitempty"`
+	Message         string     `json:"message,omitempty"`
+	Amount          string     `json:"amount,omitempty"`
+	TimeText        string     `json:"time_text,omitempty"`
+	Timestamp       *time.Time `json:"timestamp,omitempty"`
+	Offset          float64    `json:"offset"`
+}
+
+// TimelineEntry is a caption segment or chat message on a merged stream timeline
+type TimelineEntry struct {
+	Source string  `json:"source"`
+	Type   string  `json:"type,omitempty"`
+	Author string  `json:"author,omitempty"`
+	Text   string  `json:"text"`
+	Amount string  `json:"amount,omitempty"`
+	Offset float64 `json:"offset"`
+}
+
+// LiveChatReplayResponse represents the chat replay of a past live stream
+type LiveChatReplayResponse struct {
+	VideoID            string            `json:"video_id"`
+	Title              string            `json:"title,omitempty"`
+	Messages           []LiveChatMessage `json:"messages"`
+	Timeline           []TimelineEntry   `json:"timeline,omitempty"`
+	TranscriptLanguage string            `json:"transcript_language,omitempty"`
+	TranscriptError    *TranscriptError  `json:"transcript_error,omitempty"`
+	Start              float64           `json:"start"`
+	End                float64           `json:"end,omitempty"`
+	MessageCount       int               `json:"message_count"`
+	SuperChatCount     int               `json:"super_chat_count"`
+	Truncated          bool              `json:"truncated,omitempty"`
+	NextPageToken      string            `json:"next_page_token,omitempty"`
+}
+
+// GetBilingualTranscriptParams represents parameters for the get_bilingual_transcript tool
+type GetBilingualTranscriptParams struct {
+	VideoIdentifier   string `json:"video_identifier" validate:"required"`
+	PrimaryLanguage   string `json:"primary_language" validate:"required,min=2,max=10"`
+	SecondaryLanguage string `json:"secondary_language" validate:"required,min=2,max=10,nefield=PrimaryLanguage"`
+	FormatType        string `json:"format_type,omitempty" validate:"omitempty,oneof=json markdown srt vtt"`
+}
+
+// BilingualOptions selects the two languages of a bilingual transcript and how it is rendered
+type BilingualOptions struct {
+	PrimaryLanguage   string `json:"primary_language"`
+	SecondaryLanguage string `json:"secondary_language"`
+	FormatType        string `json:"format_type,omitempty"`
+}
+
+// BilingualSegment pairs the text of two languages spoken over the same stretch of a video
+type BilingualSegment struct {
+	Primary   string  `json:"primary"`
+	Secondary string  `json:"secondary"`
+	Start     float64 `json:"start"`
+	End       float64 `json:"end"`
+}
+
+// BilingualTranscriptResponse represents a transcript aligned in two languages
+type BilingualTranscriptResponse struct {
+	VideoID             string             `json:"video_id"`
+	Title               string             `json:"title,omitempty"`
+	PrimaryLanguage     string             `json:"primary_language"`
+	SecondaryLanguage   string             `json:"secondary_language"`
+	FormatType          string             `json:"format_type"`
+	FormattedText       string             `json:"formatted_text,omitempty"`
+	Segments            []BilingualSegment `json:"segments"`
+	PrimaryTranslated   bool               `json:"primary_translated,omitempty"`
+	SecondaryTranslated bool               `json:"secondary_translated,omitempty"`
+	Resegmented         bool               `json:"resegmented,omitempty"`
+}
+
+// TranscriptReference identifies one caption track of a video by language and track type
+type TranscriptReference struct {
+	VideoIdentifier string `json:"video_identifier" validate:"required"`
+	Language        string `json:"language,omitempty" validate:"omitempty,min=2,max=10"`
+	TrackType       string `json:"track_type,omitempty" validate:"omitempty,oneof=manual auto"`
+}
+
+// CompareTranscriptsParams represents parameters for the compare_transcripts tool
+type CompareTranscriptsParams struct {
+	Reference      TranscriptReference `json:"reference"`
+	Candidate      TranscriptReference `json:"candidate"`
+	MaxDifferences int                 `json:"max_differences,omitempty" validate:"omitempty,min=1,max=5000"`
+}
+
+// ComparedTranscript describes one side of a transcript comparison
+type ComparedTranscript struct {
+	VideoID   string `json:"video_id"`
+	Title     string `json:"title,omitempty"`
+	Language  string `json:"language"`
+	TrackType string `json:"track_type"`
+	WordCount int    `json:"word_count"`
+}
+
+// TranscriptDifference is a run of words that differs between the reference and the candidate.
+// Each side's start is where the run begins in that transcript, or where it would be inserted.
+type TranscriptDifference struct {
+	Operation      string  `json:"operation"`
+	ReferenceText  string  `json:"reference_text,omitempty"`
+	CandidateText  string  `json:"candidate_text,omitempty"`
+	ReferenceStart float64 `json:"reference_start"`
+	CandidateStart float64 `json:"candidate_start"`
+}
+
+// TranscriptComparisonResponse represents a word-level comparison of two transcripts
+type TranscriptComparisonResponse struct {
+	Reference       ComparedTranscript     `json:"reference"`
+	Candidate       ComparedTranscript     `json:"candidate"`
+	Similarity      float64                `json:"similarity"`
+	WordErrorRate   float64                `json:"word_error_rate"`
+	MatchedWords    int                    `json:"matched_words"`
+	Substitutions   int                    `json:"substitutions"`
+	Deletions       int                    `json:"deletions"`
+	Insertions      int                    `json:"insertions"`
+	Differences     []TranscriptDifference `json:"differences"`
+	DifferenceCount int                    `json:"difference_count"`
+	Truncated       bool                   `json:"truncated,omitempty"`
+	Approximate     bool                   `json:"approximate,omitempty"` // too different to align word by word
+}
+
+// AnalyzeTranscriptParams represents parameters for the analyze_transcript tool
+type AnalyzeTranscriptParams struct {
+	VideoIdentifier string   `json:"video_identifier" validate:"required"`
+	Languages       []string `json:"languages,omitempty"`
+	WindowSeconds   int      `json:"window_seconds,omitempty" validate:"omitempty,min=10,max=3600"`
+	PauseSeconds    float64  `json:"pause_seconds,omitempty" validate:"omitempty,gt=0,lte=60"`
+	TopTerms        int      `json:"top_terms,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// AnalysisOptions controls how a transcript is analyzed
+type AnalysisOptions struct {
+	Languages     []string `json:"languages,omitempty"`
+	WindowSeconds int      `json:"window_seconds,omitempty"`
+	PauseSeconds  float64  `json:"pause_seconds,omitempty"`
+	TopTerms      int      `json:"top_terms,omitempty"`
+}
+
+// SpeakingRateWindow is the speaking rate over one fixed-length window of a video
+type SpeakingRateWindow struct {
+	Start          float64 `json:"start"`
+	End            float64 `json:"end"`
+	WordCount      int     `json:"word_count"`
+	WordsPerMinute float64 `json:"words_per_minute"`
+}
+
+// TranscriptPause is a stretch without speech, either silent or covered by non-speech captions
+// such as [Music]
+type TranscriptPause struct {
+	Start    float64  `json:"start"`
+	End      float64  `json:"end"`
+	Duration float64  `json:"duration"`
+	Kind     string   `json:"kind"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// VocabularyStats describes how varied the words of a transcript are
+type VocabularyStats struct {
+	UniqueWords    int     `json:"unique_words"`
+	TypeTokenRatio float64 `json:"type_token_ratio"` // unique words divided by all words
+	HapaxRatio     float64 `json:"hapax_ratio"`      // share of unique words used exactly once
+}
+
+// TermCount is a word or phrase and how often it occurs
+type TermCount struct {
+	Term  string `json:"term"`
+	Count int    `json:"count"`
+}
+
+// TranscriptAnalysis represents speaking rate, pause and vocabulary statistics of a transcript
+type TranscriptAnalysis struct {
+	VideoID           string               `json:"video_id"`
+	Title             string               `json:"title,omitempty"`
+	Language          string               `json:"language"`
+	DurationSeconds   float64              `json:"duration_seconds"`
+	SpeechSeconds     float64              `json:"speech_seconds"`
+	WordCount         int                  `json:"word_count"`
+	WordsPerMinute    float64              `json:"words_per_minute"`
+	Windows           []SpeakingRateWindow `json:"windows"`
+	Pauses            []TranscriptPause    `json:"pauses"`
+	TotalPauseSeconds float64              `json:"total_pause_seconds"`
+	LongestPause      float64              `json:"longest_pause"`
+	TagCounts         map[string]int       `json:"tag_counts"`
+	Vocabulary        VocabularyStats      `json:"vocabulary"`
+	TopUnigrams       []TermCount          `json:"top_unigrams"`
+	TopBigrams        []TermCount          `json:"top_bigrams"`
+	TopTrigrams       []TermCount          `json:"top_trigrams"`
+}
+
+// ExtractKeywordsParams represents parameters for the extract_keywords tool
+type ExtractKeywordsParams struct {
+	VideoIdentifiers []string `json:"video_identifiers" validate:"required,min=1,max=50"`
+	Languages        []string `json:"languages,omitempty"`
+	MaxKeywords      int      `json:"max_keywords,omitempty" validate:"omitempty,min=1,max=100"`
+	MaxThemes        int      `json:"max_themes,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// KeywordOptions controls keyword extraction
+type KeywordOptions struct {
+	Languages   []string `json:"languages,omitempty"`
+	MaxKeywords int      `json:"max_keywords,omitempty"`
+	MaxThemes   int      `json:"max_themes,omitempty"`
+}
+
+// Keyphrase is a word or phrase that sets one video apart from the others, with the start of every
+// segment it appears in
+type Keyphrase struct {
+	Phrase     string    `json:"phrase"`
+	Score      float64   `json:"score"`
+	Count      int       `json:"count"`
+	Timestamps []float64 `json:"timestamps"`
+}
+
+// VideoKeywords lists the distinctive keyphrases of one video
+type VideoKeywords struct {
+	VideoID    string      `json:"video_id"`
+	Title      string      `json:"title,omitempty"`
+	Language   string      `json:"language"`
+	Keyphrases []Keyphrase `json:"keyphrases"`
+}
+
+// KeywordTheme is a word or phrase shared by several videos of a batch
+type KeywordTheme struct {
+	Phrase     string   `json:"phrase"`
+	VideoCount int      `json:"video_count"`
+	Count      int      `json:"count"`
+	VideoIDs   []string `json:"video_ids"`
+}
+
+// KeywordsResponse represents the keyphrases of a batch of videos and the themes they share
+type KeywordsResponse struct {
+	Videos []VideoKeywords   `json:"videos"`
+	Themes []KeywordTheme    `json:"themes"`
+	Errors []TranscriptError `json:"errors,omitempty"`
+}
+
+// SearchLibraryParams represents parameters for the search_library tool
+type SearchLibraryParams struct {
+	Query           string `json:"query" validate:"required,min=1,max=500"`
+	Channel         string `json:"channel,omitempty"`
+	Language        string `json:"language,omitempty" validate:"omitempty,min=2,max=10"`
+	PublishedAfter  string `json:"published_after,omitempty"`
+	PublishedBefore string `json:"published_before,omitempty"`
+	MaxResults      int    `json:"max_results,omitempty" validate:"omitempty,min=1,max=200"`
+}
+
+// LibrarySearchOptions is a query against the transcript library and the filters applied to it
+type LibrarySearchOptions struct {
+	Query           string     `json:"query"`
+	Channel         string     `json:"channel,omitempty"`
+	Language        string     `json:"language,omitempty"`
+	PublishedAfter  *time.Time `json:"published_after,omitempty"`
+	PublishedBefore *time.Time `json:"published_before,omitempty"`
+	MaxResults      int        `json:"max_results,omitempty"`
+}
+
+// LibraryHit is a transcript segment of the library that matches a query
+type LibraryHit struct {
+	VideoID     string  `json:"video_id"`
+	Title       string  `json:"title,omitempty"`
+	ChannelName string  `json:"channel_name,omitempty"`
+	Language    string  `json:"language"`
+	PublishedAt string  `json:"published_at,omitempty"`
+	Text        string  `json:"text"`
+	Start       float64 `json:"start"`
+	End         float64 `json:"end"`
+	Score       float64 `json:"score"`
+	URL         string  `json:"url"`
+}
+
+// LibrarySearchResponse represents the ranked hits of a library search
+type LibrarySearchResponse struct {
+	Query              string       `json:"query"`
+	Hits               []LibraryHit `json:"hits"`
+	TotalHits          int          `json:"total_hits"`
+	IndexedTranscripts int          `json:"indexed_transcripts"`
+	Truncated          bool         `json:"truncated,omitempty"`
+}
+
+// SemanticSearchParams represents parameters for the semantic_search tool
+type SemanticSearchParams struct {
+	Query      string `json:"query" validate:"required,min=1,max=1000"`
+	Channel    string `json:"channel,omitempty"`
+	Language   string `json:"language,omitempty" validate:"omitempty,min=2,max=10"`
+	MaxResults int    `json:"max_results,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// SemanticSearchOptions is a natural language query against the embedded chunks of the library
+type SemanticSearchOptions struct {
+	Query      string `json:"query"`
+	Channel    string `json:"channel,omitempty"`
+	Language   string `json:"language,omitempty"`
+	MaxResults int    `json:"max_results,omitempty"`
+}
+
+// SemanticSearchResponse represents the library chunks closest in meaning to a query. Hit scores
+// are cosine similarities, and hit text spans a whole chunk of the transcript.
+type SemanticSearchResponse struct {
+	Query          string       `json:"query"`
+	Model          string       `json:"model"`
+	Hits           []LibraryHit `json:"hits"`
+	SearchedChunks int          `json:"searched_chunks"`
+	NewlyEmbedded  int          `json:"newly_embedded,omitempty"` // transcripts embedded for this search
+}
+
+// LocateQuoteParams represents parameters for the locate_quote tool
+type LocateQuoteParams struct {
+	VideoIdentifier string   `json:"video_identifier" validate:"required"`
+	Quote           string   `json:"quote" validate:"required,min=1,max=1000"`
+	Languages       []string `json:"languages,omitempty"`
+	MaxResults      int      `json:"max_results,omitempty" validate:"omitempty,min=1,max=20"`
+	MinConfidence   float64  `json:"min_confidence,omitempty" validate:"omitempty,min=0,max=1"`
+}
+
+// QuoteOptions controls how LocateQuote matches a quote. Matches below MinConfidence are left out.
+type QuoteOptions struct {
+	Quote         string   `json:"quote"`
+	Languages     []string `json:"languages,omitempty"`
+	MaxResults    int      `json:"max_results,omitempty"`
+	MinConfidence float64  `json:"min_confidence,omitempty"`
+}
+
+// QuoteMatch is a place in a transcript where a quote was said. Text is the transcript's own
+// wording, and the times span the caption segments the quote was found in.
+type QuoteMatch struct {
+	Text         string  `json:"text"`
+	Confidence   float64 `json:"confidence"`
+	Start        float64 `json:"start"`
+	End          float64 `json:"end"`
+	SegmentIndex int     `json:"segment_index"`
+	SegmentCount int     `json:"segment_count"`
+	URL          string  `json:"url"`
+	Citation     string  `json:"citation"`
+}
+
+// LocateQuoteResponse represents the best matches of a quote in a video's transcript
+type LocateQuoteResponse struct {
+	VideoID     string       `json:"video_id"`
+	Title       string       `json:"title,omitempty"`
+	ChannelName string       `json:"channel_name,omitempty"`
+	Language    string       `json:"language"`
+	Quote       string       `json:"quote"`
+	Matches     []QuoteMatch `json:"matches"`
+}
+
+// GetChaptersParams represents parameters for the get_chapters tool
+type GetChaptersParams struct {
+	VideoIdentifier string `json:"video_identifier" validate:"required"`
+}
+
+// Chapter is a titled section of a video
+type Chapter struct {
+	Title string  `json:"title"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	URL   string  `json:"url"`
+}
+
+// ChaptersResponse lists the chapters of a video and where they were found
+type ChaptersResponse struct {
+	VideoID         string    `json:"video_id"`
+	Title           string    `json:"title,omitempty"`
+	Source          string    `json:"source,omitempty"`
+	Chapters        []Chapter `json:"chapters"`
+	DurationSeconds float64   `json:"duration_seconds,omitempty"`
+}
+
+// ChapterTranscript is the part of a transcript that falls within one chapter
+type ChapterTranscript struct {
+	Title        string  `json:"title"`
+	Start        float64 `json:"start"`
+	End          float64 `json:"end"`
+	Text         string  `json:"text"`
+	SegmentIndex int     `json:"segment_index"`
+	SegmentCount int     `json:"segment_count"`
+}
+
+// FormatOptions controls how a transcript is rendered by FormatTranscript
+type FormatOptions struct {
+	FormatType        string            `json:"format_type"`
+	Transcript        TranscriptOptions `json:"transcript"`
+	Paragraphs        ParagraphOptions  `json:"paragraphs"`
+	IncludeTimestamps bool              `json:"include_timestamps,omitempty"`
+}
+
+// ParagraphOptions controls where the paragraphs format starts a new paragraph: after a silence of
+// PauseSeconds, or at the first sentence end once a paragraph is TargetSeconds or TargetWords long.
+// Zero values use the defaults; TargetSeconds only defaults when TargetWords is not set either.
+type ParagraphOptions struct {
+	PauseSeconds  float64 `json:"pause_seconds,omitempty"`
+	TargetSeconds float64 `json:"target_seconds,omitempty"`
+	TargetWords   int     `json:"target_words,omitempty"`
+}
+
+// SearchTranscriptParams represents parameters for searching within a transcript
+type SearchTranscriptParams struct {
+	VideoIdentifier string   `json:"video_identifier" validate:"required"`
+	Query           string   `json:"query" validate:"required"`
+	Mode            string   `json:"mode,omitempty" validate:"omitempty,oneof=keyword phrase regex"`
+	Languages       []string `json:"languages,omitempty"`
+	ContextSegments int      `json:"context_segments,omitempty" validate:"omitempty,min=0,max=20"`
+	MaxResults      int      `json:"max_results,omitempty" validate:"omitempty,min=1,max=500"`
+	CaseSensitive   bool     `json:"case_sensitive,omitempty"`
+	KeepDiacritics  bool     `json:"keep_diacritics,omitempty"`
+}
+
+// TranscriptSearchOptions controls how SearchTranscript matches a query
+type TranscriptSearchOptions struct {
+	Query           string   `json:"query"`
+	Mode            string   `json:"mode"`
+	Languages       []string `json:"languages,omitempty"`
+	ContextSegments int      `json:"context_segments"`
+	MaxResults      int      `json:"max_results,omitempty"`
+	CaseSensitive   bool     `json:"case_sensitive"`
+	FoldDiacritics  bool     `json:"fold_diacritics"`
+}
+
+// TranscriptSearchMatch represents a single hit within a transcript
+type TranscriptSearchMatch struct {
+	Text          string  `json:"text"`
+	ContextBefore string  `json:"context_before,omitempty"`
+	ContextAfter  string  `json:"context_after,omitempty"`
+	URL           string  `json:"url"`
+	Start         float64 `json:"start"`
+	End           float64 `json:"end"`
+	SegmentIndex  int     `json:"segment_index"`
+	SegmentCount  int     `json:"segment_count"`
+}
+
+// SearchTranscriptResponse represents the result of searching a transcript
+type SearchTranscriptResponse struct {
+	VideoID      string                  `json:"video_id"`
+	Title        string                  `json:"title,omitempty"`
+	Language     string                  `json:"language"`
+	Query        string                  `json:"query"`
+	Mode         string                  `json:"mode"`
+	Matches      []TranscriptSearchMatch `json:"matches"`
+	TotalMatches int                     `json:"total_matches"`
+}
+
+// GetTranscriptRangeParams represents parameters for extracting part of a transcript
+type GetTranscriptRangeParams struct {
+	VideoIdentifier   string      `json:"video_identifier" validate:"required"`
+	Start             *TimeOffset `json:"start,omitempty"`
+	End               *TimeOffset `json:"end,omitempty"`
+	FormatType        string      `json:"format_type,omitempty" validate:"omitempty,oneof=plain_text paragraphs sentences srt vtt json"`
+	Languages         []string    `json:"languages,omitempty"`
+	IncludeTimestamps bool        `json:"include_timestamps,omitempty"`
+	RebaseTimestamps  bool        `json:"rebase_timestamps,omitempty"`
+}
+
+// TranscriptRangeOptions controls which part of a transcript GetTranscriptRange returns.
+// A nil Start falls back to the t= offset of a pasted URL, a nil End means the end of the video.
+type TranscriptRangeOptions struct {
+	Start     *float64      `json:"start,omitempty"`
+	End       *float64      `json:"end,omitempty"`
+	Languages []string      `json:"languages,omitempty"`
+	Format    FormatOptions `json:"format"`
+	Rebase    bool          `json:"rebase,omitempty"`
+}
+
+// TranscriptRangeResponse represents the part of a transcript between two offsets
+type TranscriptRangeResponse struct {
+	VideoID       string              `json:"video_id"`
+	Title         string              `json:"title,omitempty"`
+	Language      string              `json:"language"`
+	FormatType    string              `json:"format_type"`
+	FormattedText string              `json:"formatted_text"`
+	URL           string              `json:"url"`
+	Transcript    []TranscriptSegment `json:"transcript"`
+	Start         float64             `json:"start"`
+	End           float64             `json:"end"`
+	WordCount     int                 `json:"word_count"`
+	Rebased       bool                `json:"rebased,omitempty"`
+}
+
+// TimeOffset is a position in a video in seconds.
+// It unmarshals from a JSON number or from strings such as "90", "12:30", "01:02:03.5" or "1h2m3s".
+type TimeOffset float64
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *TimeOffset) UnmarshalJSON(data []byte) error {
+	var number float64
+	if err := json.Unmarshal(data, &number); err == nil {
+		if number < 0 {
+			return fmt.Errorf("time offset must not be negative: %v", number)
+		}
+		*t = TimeOffset(number)
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("time offset must be a number of seconds or a HH:MM:SS string")
+	}
+
+	seconds, err := ParseTimeOffset(text)
+	if err != nil {
+		return err
+	}
+	*t = TimeOffset(seconds)
+	return nil
+}
+
+// ParseTimeOffset parses seconds ("90"), clock ("12:30", "01:02:03.5") or duration ("1h2m3s", "90s") notation
+func ParseTimeOffset(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("empty time offset")
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds < 0 {
+			return 0, fmt.Errorf("time offset must not be negative: %s", value)
+		}
+		return seconds, nil
+	}
+
+	if strings.Contains(value, ":") {
+		parts := strings.Split(value, ":")
+		if len(parts) > 3 {
+			return 0, fmt.Errorf("invalid time offset: %s", value)
+		}
+		total := 0.0
+		for _, part := range parts {
+			number, err := strconv.ParseFloat(part, 64)
+			if err != nil || number < 0 {
+				return 0, fmt.Errorf("invalid time offset: %s", value)
+			}
+			total = total*60 + number
+		}
+		return total, nil
+	}
+
+	// YouTube's t= parameter uses the same units as Go durations, without fractions
+	duration, err := time.ParseDuration(strings.ToLower(value))
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid time offset: %s", value)
+	}
+	return duration.Seconds(), nil
+}
+
+// ParseDate parses a calendar date ("2024-03-01") or an RFC 3339 timestamp
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, nil
+	}
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD or RFC 3339", value)
+	}
+	return date, nil
 }
 
 // ListLanguagesParams represents parameters for listing languages
@@ -229,21 +988,42 @@ type MCPContent struct {
 
 // VideoInfo represents basic video information
 type VideoInfo struct {
-	UploadDate    time.Time `json:"upload_date,omitempty"`
-	ThumbnailURL  string    `json:"thumbnail_url,omitempty"`
-	Description   string    `json:"description,omitempty"`
-	Duration      string    `json:"duration,omitempty"`
-	ChannelID     string    `json:"channel_id,omitempty"`
-	ChannelName   string    `json:"channel_name,omitempty"`
-	ID            string    `json:"id"`
-	Title         string    `json:"title"`
-	ViewCount     int64     `json:"view_count,omitempty"`
-	LikeCount     int64     `json:"like_count,omitempty"`
-	DislikeCount  int64     `json:"dislike_count,omitempty"`
-	CommentCount  int64     `json:"comment_count,omitempty"`
-	IsLiveContent bool      `json:"is_live_content,omitempty"`
-	IsPrivate     bool      `json:"is_private,omitempty"`
-	IsDeleted     bool      `json:"is_deleted,omitempty"`
+	UploadDate      time.Time   `json:"upload_date,omitempty"`
+	PublishDate     time.Time   `json:"publish_date,omitempty"`
+	ThumbnailURL    string      `json:"thumbnail_url,omitempty"`
+	Description     string      `json:"description,omitempty"`
+	Duration        string      `json:"duration,omitempty"`
+	ChannelID       string      `json:"channel_id,omitempty"`
+	ChannelName     string      `json:"channel_name,omitempty"`
+	Category        string      `json:"category,omitempty"`
+	ID              string      `json:"id"`
+	Title           string      `json:"title"`
+	Keywords        []string    `json:"keywords,omitempty"`
+	Thumbnails      []Thumbnail `json:"thumbnails,omitempty"`
+	DurationSeconds float64     `json:"duration_seconds,omitempty"`
+	ViewCount       int64       `json:"view_count,omitempty"`
+	LikeCount       int64       `json:"like_count,omitempty"`
+	DislikeCount    int64       `json:"dislike_count,omitempty"`
+	CommentCount    int64       `json:"comment_count,omitempty"`
+	IsLiveContent   bool        `json:"is_live_content,omitempty"`
+	IsLive          bool        `json:"is_live,omitempty"`
+	IsUpcoming      bool        `json:"is_upcoming,omitempty"`
+	IsPrivate       bool        `json:"is_private,omitempty"`
+	IsUnlisted      bool        `json:"is_unlisted,omitempty"`
+	IsFamilySafe    bool        `json:"is_family_safe,omitempty"`
+	IsDeleted       bool        `json:"is_deleted,omitempty"`
+}
+
+// Thumbnail is one size of a video thumbnail
+type Thumbnail struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+}
+
+// GetVideoMetadataParams represents parameters for the get_video_metadata tool
+type GetVideoMetadataParams struct {
+	VideoIdentifier string `json:"video_identifier" validate:"required"`
 }
 
 // CacheEntry represents a cached transcript entry
@@ -257,19 +1037,24 @@ type CacheEntry struct {
 
 // ErrorType constants
 const (
-	ErrorTypeVideoUnavailable     = "VIDEO_UNAVAILABLE"
-	ErrorTypeNoTranscriptFound    = "NO_TRANSCRIPT_FOUND"
-	ErrorTypeTranscriptsDisabled  = "TRANSCRIPTS_DISABLED"
-	ErrorTypeInvalidVideoID       = "INVALID_VIDEO_ID"
-	ErrorTypeNetworkError         = "NETWORK_ERROR"
-	ErrorTypeRateLimitExceeded    = "RATE_LIMIT_EXCEEDED"
-	ErrorTypeLanguageNotAvailable = "LANGUAGE_NOT_AVAILABLE"
-	ErrorTypeInternalError        = "INTERNAL_ERROR"
-	ErrorTypeValidationError      = "VALIDATION_ERROR"
-	ErrorTypeAuthenticationError  = "AUTHENTICATION_ERROR"
-	ErrorTypeParsingError         = "PARSING_ERROR"
-	ErrorTypeTimeout              = "TIMEOUT_ERROR"
-	ErrorTypeCaptchaRequired      = "CAPTCHA_REQUIRED"
+	ErrorTypeVideoUnavailable        = "VIDEO_UNAVAILABLE"
+	ErrorTypeNoTranscriptFound       = "NO_TRANSCRIPT_FOUND"
+	ErrorTypeTranscriptsDisabled     = "TRANSCRIPTS_DISABLED"
+	ErrorTypeInvalidVideoID          = "INVALID_VIDEO_ID"
+	ErrorTypeNetworkError            = "NETWORK_ERROR"
+	ErrorTypeRateLimitExceeded       = "RATE_LIMIT_EXCEEDED"
+	ErrorTypeLanguageNotAvailable    = "LANGUAGE_NOT_AVAILABLE"
+	ErrorTypeInternalError           = "INTERNAL_ERROR"
+	ErrorTypeValidationError         = "VALIDATION_ERROR"
+	ErrorTypeAuthenticationError     = "AUTHENTICATION_ERROR"
+	ErrorTypeParsingError            = "PARSING_ERROR"
+	ErrorTypeTimeout                 = "TIMEOUT_ERROR"
+	ErrorTypeCaptchaRequired         = "CAPTCHA_REQUIRED"
+	ErrorTypeNoChatReplay            = "NO_CHAT_REPLAY"
+	ErrorTypeTranslationNotAvailable = "TRANSLATION_NOT_AVAILABLE"
+	ErrorTypeTranslationFailed       = "TRANSLATION_FAILED"
+	ErrorTypeLibraryUnavailable      = "LIBRARY_UNAVAILABLE"
+	ErrorTypeEmbeddingFailed         = "EMBEDDING_FAILED"
 )
 
 // MCP Method constants
@@ -291,23 +1076,120 @@ const (
 	ToolTranslateTranscript    = "translate_transcript"
 	ToolFormatTranscript       = "format_transcript"
 	ToolListLanguages          = "list_available_languages"
+	ToolSearchTranscript       = "search_transcript"
+	ToolGetTranscriptRange     = "get_transcript_range"
+	ToolGetChapters            = "get_chapters"
+	ToolGetVideoMetadata       = "get_video_metadata"
+	ToolGetPlaylistTranscripts = "get_playlist_transcripts"
+	ToolListChannelVideos      = "list_channel_videos"
+	ToolSearchVideos           = "search_videos"
+	ToolGetLiveChatReplay      = "get_live_chat_replay"
+	ToolGetBilingualTranscript = "get_bilingual_transcript"
+	ToolCompareTranscripts     = "compare_transcripts"
+	ToolAnalyzeTranscript      = "analyze_transcript"
+	ToolExtractKeywords        = "extract_keywords"
+	ToolSearchLibrary          = "search_library"
+	ToolSemanticSearch         = "semantic_search"
+	ToolLocateQuote            = "locate_quote"
+)
+
+// Playlist order constants
+const (
+	PlaylistOrderPlaylist = "playlist" // the order of the playlist
+	PlaylistOrderReverse  = "reverse"  // last entry first
+	PlaylistOrderShortest = "shortest" // shortest video first
+	PlaylistOrderLongest  = "longest"  // longest video first
+)
+
+// Chapter source constants
+const (
+	ChapterSourceMarkers     = "markers"     // chapter markers published in the page data
+	ChapterSourceDescription = "description" // timestamps parsed from the video description
+)
+
+// Live chat message type constants
+const (
+	ChatMessageTypeText         = "text"
+	ChatMessageTypeSuperChat    = "super_chat"
+	ChatMessageTypeSuperSticker = "super_sticker"
+	ChatMessageTypeMembership   = "membership"
+)
+
+// Timeline source constants
+const (
+	TimelineSourceCaption = "caption"
+	TimelineSourceChat    = "chat"
+)
+
+// Video search duration filter constants
+const (
+	SearchDurationShort  = "short"  // under 4 minutes
+	SearchDurationMedium = "medium" // 4 to 20 minutes
+	SearchDurationLong   = "long"   // over 20 minutes
+)
+
+// Video search upload date filter constants
+const (
+	SearchUploadHour  = "hour"
+	SearchUploadToday = "today"
+	SearchUploadWeek  = "week"
+	SearchUploadMonth = "month"
+	SearchUploadYear  = "year"
+)
+
+// Search mode constants
+const (
+	SearchModeKeyword = "keyword" // any of the query words
+	SearchModePhrase  = "phrase"  // the exact word sequence, across segment boundaries
+	SearchModeRegex   = "regex"   // a regular expression over the transcript text
 )
 
 // Format type constants
 const (
-	FormatTypePlainText  = "plain_text"
-	FormatTypeParagraphs = "paragraphs"
-	FormatTypeSentences  = "sentences"
-	FormatTypeSRT        = "srt"
-	FormatTypeVTT        = "vtt"
-	FormatTypeJSON       = "json"
+	FormatTypePlainText    = "plain_text"
+	FormatTypeParagraphs   = "paragraphs"
+	FormatTypeSentences    = "sentences"
+	FormatTypeSRT          = "srt"
+	FormatTypeVTT          = "vtt"
+	FormatTypeJSON         = "json"
+	FormatTypeMarkdown     = "markdown"
+	FormatTypeSpeakerTurns = "speaker_turns"
+	FormatTypeVTTKaraoke   = "vtt_karaoke"
 )
 
 // Transcript type constants
 const (
-	TranscriptTypeManual    = "manual"
-	TranscriptTypeGenerated = "generated"
-	TranscriptTypeAuto      = "auto"
+	TranscriptTypeManual     = "manual"
+	TranscriptTypeGenerated  = "generated"
+	TranscriptTypeAuto       = "auto"
+	TranscriptTypeTranslated = "translated" // machine-translated by YouTube from another track
+)
+
+// Transcript difference operation constants
+const (
+	DiffOperationInsert  = "insert"  // words only in the candidate
+	DiffOperationDelete  = "delete"  // words only in the reference
+	DiffOperationReplace = "replace" // words that differ on both sides
+)
+
+// Transcript pause kind constants
+const (
+	PauseKindSilence   = "silence"    // no captions at all
+	PauseKindNonSpeech = "non_speech" // only captions such as [Music] or [Applause]
+)
+
+// Token budget strategy constants
+const (
+	TokenStrategyTruncate = "truncate" // keep the beginning of the transcript
+	TokenStrategySample   = "sample"   // keep segments evenly spread across the timeline
+	TokenStrategyCompress = "compress" // drop filler words before sampling
+)
+
+// Annotation mode constants for sound events and speaker-change markers in caption text
+const (
+	AnnotationModeKeep  = "keep"  // leave annotations as captioned
+	AnnotationModeStrip = "strip" // remove annotations from the text
+	AnnotationModeTag   = "tag"   // rewrite annotations as [event] tags and >> markers
 )
 
 // Timestamp format constants
@@ -332,17 +1214,37 @@ const (
 	CacheKeyPrefixTranscript = "transcript:"
 	CacheKeyPrefixLanguages  = "languages:"
 	CacheKeyPrefixVideoInfo  = "videoinfo:"
+	CacheKeyPrefixChapters   = "chapters:"
 	CacheKeyPrefixError      = "error:"
 )
 
 // Default values
 const (
-	DefaultLanguage      = "en"
-	DefaultFormatType    = FormatTypePlainText
-	DefaultMaxLineLength = 80
-	DefaultCacheTTL      = 24 * time.Hour
-	DefaultErrorCacheTTL = 15 * time.Minute
-	DefaultTimeout       = 30 * time.Second
-	DefaultRetryAttempts = 3
-	DefaultRetryDelay    = time.Second
+	DefaultLanguage        = "en"
+	DefaultFormatType      = FormatTypePlainText
+	DefaultMaxLineLength   = 80
+	DefaultTokenStrategy   = TokenStrategyTruncate
+	DefaultSearchContext   = 1
+	DefaultSearchResults   = 50
+	DefaultPlaylistVideos  = 50
+	DefaultChannelVideos   = 50
+	DefaultSearchVideos    = 20
+	DefaultChatMessages    = 1000
+	DefaultMaxDifferences  = 200
+	DefaultAnalysisWindow  = 60
+	DefaultPauseSeconds    = 2.0
+	DefaultTopTerms        = 20
+	DefaultMaxKeywords     = 10
+	DefaultMaxThemes       = 20
+	DefaultLibraryResults  = 20
+	DefaultSemanticResults = 10
+	DefaultQuoteMatches    = 3
+	DefaultQuoteConfidence = 0.6
+	DefaultParagraphPause  = 2.0
+	DefaultParagraphLength = 45.0
+	DefaultCacheTTL        = 24 * time.Hour
+	DefaultErrorCacheTTL   = 15 * time.Minute
+	DefaultTimeout         = 30 * time.Second
+	DefaultRetryAttempts   = 3
+	DefaultRetryDelay      = time.Second
 )
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 )
@@ -198,3 +199,52 @@ func TestCacheEntry(t *testing.T) {
 		t.Errorf("Expected hit count 5, got %d", entry.HitCount)
 	}
 }
+
+func TestParseTimeOffset(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{input: "90", want: 90},
+		{input: "90.5", want: 90.5},
+		{input: "12:30", want: 750},
+		{input: "01:02:03.5", want: 3723.5},
+		{input: "1h2m3s", want: 3723},
+		{input: "83s", want: 83},
+		{input: "", wantErr: true},
+		{input: "-5", wantErr: true},
+		{input: "1:2:3:4", wantErr: true},
+		{input: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseTimeOffset(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTimeOffset(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTimeOffset(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeOffset_UnmarshalJSON(t *testing.T) {
+	var params GetTranscriptRangeParams
+	if err := json.Unmarshal([]byte(`{"video_identifier":"x","start":"12:30","end":1080}`), &params); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if params.Start == nil || *params.Start != 750 {
+		t.Errorf("Expected start 750, got %v", params.Start)
+	}
+	if params.End == nil || *params.End != 1080 {
+		t.Errorf("Expected end 1080, got %v", params.End)
+	}
+
+	if err := json.Unmarshal([]byte(`{"start":true}`), &params); err == nil {
+		t.Error("Expected error for boolean offset")
+	}
+}
@@ -0,0 +1,91 @@
+// Package textseg splits transcript text into words, the same way for every feature that counts,
+// indexes or matches them.
+package textseg
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Class is the kind of characters a token is made of
+type Class int
+
+// Character classes used to split text into tokens
+const (
+	ClassNone Class = iota
+	ClassWord
+	ClassHan
+	ClassHiragana
+	ClassKatakana
+)
+
+// Token is a lower-cased run of characters of one class
+type Token struct {
+	Text  string
+	Class Class
+}
+
+// Tokens splits text into lower-cased tokens. Words are separated by spaces and punctuation.
+// Japanese and Chinese have no spaces, so runs of one script (kanji, hiragana or katakana) form a
+// token each.
+func Tokens(text string) []Token {
+	var tokens []Token
+	var current []rune
+	currentClass := ClassNone
+
+	flush := func() {
+		if token := strings.Trim(string(current), "'"); token != "" {
+			tokens = append(tokens, Token{Text: token, Class: currentClass})
+		}
+		current = current[:0]
+	}
+
+	for _, r := range text {
+		class := classOf(r, currentClass)
+		if class != currentClass {
+			flush()
+		}
+		if r == '’' {
+			r = '\''
+		}
+		if class != ClassNone {
+			current = append(current, unicode.ToLower(r))
+		}
+		currentClass = class
+	}
+	flush()
+
+	return tokens
+}
+
+// Words returns the text of the tokens of text
+func Words(text string) []string {
+	tokens := Tokens(text)
+	words := make([]string, len(tokens))
+	for i, token := range tokens {
+		words[i] = token.Text
+	}
+	return words
+}
+
+// classOf classifies a character, given the class of the character before it
+func classOf(r rune, previous Class) Class {
+	switch {
+	case unicode.Is(unicode.Han, r) || r == '々':
+		// The iteration mark repeats the ideograph before it
+		return ClassHan
+	case unicode.Is(unicode.Hiragana, r):
+		return ClassHiragana
+	case unicode.Is(unicode.Katakana, r):
+		return ClassKatakana
+	case r == 'ー' && (previous == ClassKatakana || previous == ClassHiragana):
+		// The prolonged sound mark belongs to the kana before it
+		return previous
+	case unicode.IsLetter(r) || unicode.IsNumber(r):
+		return ClassWord
+	case (r == '\'' || r == '’') && previous == ClassWord:
+		return ClassWord
+	default:
+		return ClassNone
+	}
+}
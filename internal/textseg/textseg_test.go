@@ -0,0 +1,38 @@
+package textseg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"It’s the Machine-Learning era!", "it's|the|machine|learning|era"},
+		{"機械学習はとても面白いです", "機械学習|はとても|面白|いです"},
+		{"コンピューターを使う", "コンピューター|を|使|う"},
+		{"人々が集まる", "人々|が|集|まる"},
+		{"dogs' toys", "dogs|toys"},
+	}
+
+	for _, tt := range tests {
+		if result := strings.Join(Words(tt.text), "|"); result != tt.expected {
+			t.Errorf("Words(%q) = %q, expected %q", tt.text, result, tt.expected)
+		}
+	}
+}
+
+func TestTokensClasses(t *testing.T) {
+	tokens := Tokens("AI と機械学習")
+	expected := []Token{{"ai", ClassWord}, {"と", ClassHiragana}, {"機械学習", ClassHan}}
+	if len(tokens) != len(expected) {
+		t.Fatalf("Tokens() = %+v, expected %+v", tokens, expected)
+	}
+	for i := range tokens {
+		if tokens[i] != expected[i] {
+			t.Errorf("token %d = %+v, expected %+v", i, tokens[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package youtube
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+	"github.com/youtube-transcript-mcp/internal/textseg"
+)
+
+// englishStopwords are common English function words left out of top terms
+var englishStopwords = toSet(strings.Fields(`
+	a about above after again against all also am an and any are aren't as at be because been before
+	being below between both but by can can't could couldn't did didn't do does doesn't doing don't down
+	during each even few for from further get gets getting go going gonna got had hadn't has hasn't have
+	haven't having he he'd he'll he's her here here's hers herself him himself his how how's i i'd i'll
+	i'm i've if in into is isn't it it's its itself just know let's like me more most mustn't my myself
+	no nor not now of off oh ok okay on once one only or other ought our ours ourselves out over own
+	really right same say says she she'd she'll she's should shouldn't so some such than that that's the
+	their theirs them themselves then there there's these they they'd they'll they're they've thing
+	things think this those through to too um uh under until up very wanna was wasn't way we we'd we'll
+	we're we've well were weren't what what's when when's where where's which while who who's whom why
+	why's will with won't would wouldn't yeah yes you you'd you'll you're you've your yours yourself
+	yourselves
+`))
+
+// japaneseStopwords are common Japanese words written in kanji or katakana that carry little meaning
+// on their own. Hiragana runs are mostly particles and inflections and are always skipped.
+var japaneseStopwords = toSet(strings.Fields(`
+	私 僕 俺 自分 今 今日 本当 感じ 事 時 方 人 中 上 下 前 後 等 的 者 為 何 一 二 三 色々 皆 皆さん
+	様 場合 部分 気 所 物 次 訳 位 毎
+`))
+
+// AnalyzeTranscript computes speaking rate, pause, caption tag and vocabulary statistics of a
+// video's transcript
+func (s *Service) AnalyzeTranscript(ctx context.Context, videoIdentifier string, opts models.AnalysisOptions) (*models.TranscriptAnalysis, error) {
+	transcript, err := s.GetTranscript(ctx, videoIdentifier, opts.Languages, false)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := analyzeSegments(transcript.Transcript, opts)
+	analysis.VideoID = transcript.VideoID
+	analysis.Title = transcript.Title
+	analysis.Language = transcript.Language
+	return analysis, nil
+}
+
+// analyzedSegment is a caption segment with its non-speech tags separated from its words
+type analyzedSegment struct {
+	start  float64
+	end    float64
+	tokens []string
+	tags   []string
+}
+
+// analyzeSegments computes the statistics of a transcript. Segments holding only tags or symbols,
+// such as "[Music]" or "♪♪", count as non-speech.
+func analyzeSegments(segments []models.TranscriptSegment, opts models.AnalysisOptions) *models.TranscriptAnalysis {
+	if opts.WindowSeconds <= 0 {
+		opts.WindowSeconds = models.DefaultAnalysisWindow
+	}
+	if opts.PauseSeconds <= 0 {
+		opts.PauseSeconds = models.DefaultPauseSeconds
+	}
+	if opts.TopTerms <= 0 {
+		opts.TopTerms = models.DefaultTopTerms
+	}
+
+	analysis := &models.TranscriptAnalysis{
+		Windows:   []models.SpeakingRateWindow{},
+		Pauses:    []models.TranscriptPause{},
+		TagCounts: map[string]int{},
+	}
+
+	var speech, nonSpeech []analyzedSegment
+	for _, segment := range segments {
+		analyzed := analyzeSegment(segment)
+		for _, tag := range analyzed.tags {
+			analysis.TagCounts[tag]++
+		}
+		analysis.DurationSeconds = max(analysis.DurationSeconds, analyzed.end)
+		if len(analyzed.tokens) == 0 {
+			nonSpeech = append(nonSpeech, analyzed)
+			continue
+		}
+		speech = append(speech, analyzed)
+		analysis.WordCount += len(analyzed.tokens)
+	}
+
+	// Overlapping captions would count the same stretch twice
+	for i := range speech {
+		end := speech[i].end
+		if i+1 < len(speech) {
+			end = min(end, speech[i+1].start)
+		}
+		analysis.SpeechSeconds += max(end-speech[i].start, 0)
+	}
+	if analysis.DurationSeconds > 0 {
+		analysis.WordsPerMinute = roundHundredths(float64(analysis.WordCount) / (analysis.DurationSeconds / 60))
+	}
+	analysis.SpeechSeconds = roundHundredths(analysis.SpeechSeconds)
+	analysis.DurationSeconds = roundHundredths(analysis.DurationSeconds)
+
+	analysis.Windows = speakingRateWindows(speech, analysis.DurationSeconds, float64(opts.WindowSeconds))
+	analysis.Pauses = findPauses(speech, nonSpeech, opts.PauseSeconds)
+	for _, pause := range analysis.Pauses {
+		analysis.TotalPauseSeconds += pause.Duration
+		analysis.LongestPause = max(analysis.LongestPause, pause.Duration)
+	}
+	analysis.TotalPauseSeconds = roundHundredths(analysis.TotalPauseSeconds)
+
+	analysis.Vocabulary, analysis.TopUnigrams, analysis.TopBigrams, analysis.TopTrigrams = termStatistics(speech, opts.TopTerms)
+
+	return analysis
+}
+
+// analyzeSegment separates a segment's tags from its words
+func analyzeSegment(segment models.TranscriptSegment) analyzedSegment {
+	analyzed := analyzedSegment{start: segment.Start, end: segmentEnd(segment)}
+	for _, match := range annotationPattern.FindAllString(segment.Text, -1) {
+		if match != speakerChangeMarker {
+			analyzed.tags = append(analyzed.tags, soundEventName(match))
+		}
+	}
+	analyzed.tokens = textseg.Words(stripAnnotations(segment.Text))
+	return analyzed
+}
+
+// isStopword reports whether a token is left out of top terms
+func isStopword(token string) bool {
+	if englishStopwords[token] || japaneseStopwords[token] || fillerWords[token] {
+		return true
+	}
+	// Stray letters such as the "s" of a possessive carry no meaning
+	if len(token) == 1 {
+		return true
+	}
+	for _, r := range token {
+		if !unicode.Is(unicode.Hiragana, r) && r != 'ー' {
+			return false
+		}
+	}
+	return true
+}
+
+// speakingRateWindows spreads each segment's words evenly over its duration and counts them per window
+func speakingRateWindows(speech []analyzedSegment, duration, windowSeconds float64) []models.SpeakingRateWindow {
+	count := int(math.Ceil(duration / windowSeconds))
+	words := make([]float64, count)
+	for _, segment := range speech {
+		length := segment.end - segment.start
+		if length <= 0 {
+			if index := int(segment.start / windowSeconds); index < count {
+				words[index] += float64(len(segment.tokens))
+			}
+			continue
+		}
+		for index := int(segment.start / windowSeconds); index < count; index++ {
+			windowStart := float64(index) * windowSeconds
+			if windowStart >= segment.end {
+				break
+			}
+			overlap := min(segment.end, windowStart+windowSeconds) - max(segment.start, windowStart)
+			words[index] += float64(len(segment.tokens)) * overlap / length
+		}
+	}
+
+	windows := make([]models.SpeakingRateWindow, 0, count)
+	for index, wordCount := range words {
+		start := float64(index) * windowSeconds
+		end := min(start+windowSeconds, duration)
+		window := models.SpeakingRateWindow{
+			Start:     start,
+			End:       end,
+			WordCount: int(math.Round(wordCount)),
+		}
+		if end > start {
+			window.WordsPerMinute = roundHundredths(wordCount / ((end - start) / 60))
+		}
+		windows = append(windows, window)
+	}
+	return windows
+}
+
+// findPauses returns the stretches of at least threshold seconds without speech, from the start of
+// the video to the last caption. A stretch overlapped by non-speech captions takes their tags.
+func findPauses(speech, nonSpeech []analyzedSegment, threshold float64) []models.TranscriptPause {
+	pauses := []models.TranscriptPause{}
+	addPause := func(start, end float64) {
+		if end-start < threshold {
+			return
+		}
+		pause := models.TranscriptPause{
+			Start:    roundHundredths(start),
+			End:      roundHundredths(end),
+			Duration: roundHundredths(end - start),
+			Kind:     models.PauseKindSilence,
+		}
+		for _, segment := range nonSpeech {
+			if segment.start < end && segment.end > start {
+				pause.Kind = models.PauseKindNonSpeech
+				for _, tag := range segment.tags {
+					if !slices.Contains(pause.Tags, tag) {
+						pause.Tags = append(pause.Tags, tag)
+					}
+				}
+			}
+		}
+		pauses = append(pauses, pause)
+	}
+
+	cursor := 0.0
+	for _, segment := range speech {
+		addPause(cursor, segment.start)
+		cursor = max(cursor, segment.end)
+	}
+
+	// Trailing music or applause after the last words
+	trailingEnd := cursor
+	for _, segment := range nonSpeech {
+		trailingEnd = max(trailingEnd, segment.end)
+	}
+	addPause(cursor, trailingEnd)
+
+	return pauses
+}
+
+// termStatistics computes vocabulary richness over all words and the most frequent terms without
+// stopwords. Stopwords break phrases, so n-grams never span them.
+func termStatistics(speech []analyzedSegment, topTerms int) (models.VocabularyStats, []models.TermCount, []models.TermCount, []models.TermCount) {
+	frequencies := make(map[string]int)
+	total := 0
+	ngrams := [3]map[string]int{{}, {}, {}}
+	var run []string
+
+	for _, segment := range speech {
+		for _, token := range segment.tokens {
+			frequencies[token]++
+			total++
+
+			if isStopword(token) {
+				run = run[:0]
+				continue
+			}
+			run = append(run, token)
+			for n := 1; n <= min(len(run), 3); n++ {
+				ngrams[n-1][joinTerms(run[len(run)-n:])]++
+			}
+		}
+	}
+
+	var vocabulary models.VocabularyStats
+	vocabulary.UniqueWords = len(frequencies)
+	if total > 0 {
+		hapaxes := 0
+		for _, count := range frequencies {
+			if count == 1 {
+				hapaxes++
+			}
+		}
+		vocabulary.TypeTokenRatio = roundRatio(float64(len(frequencies)) / float64(total))
+		vocabulary.HapaxRatio = roundRatio(float64(hapaxes) / float64(len(frequencies)))
+	}
+
+	// A phrase said once is not a recurring term
+	return vocabulary, topTermCounts(ngrams[0], topTerms, 1), topTermCounts(ngrams[1], topTerms, 2), topTermCounts(ngrams[2], topTerms, 2)
+}
+
+// joinTerms joins tokens into a phrase, without spaces between Japanese or Chinese tokens
+func joinTerms(tokens []string) string {
+	var builder strings.Builder
+	for i, token := range tokens {
+		if i > 0 {
+			previous, _ := utf8.DecodeLastRuneInString(tokens[i-1])
+			next, _ := utf8.DecodeRuneInString(token)
+			if !isCJK(previous) || !isCJK(next) {
+				builder.WriteByte(' ')
+			}
+		}
+		builder.WriteString(token)
+	}
+	return builder.String()
+}
+
+// topTermCounts returns the n most frequent terms seen at least minCount times, most frequent first
+func topTermCounts(counts map[string]int, n, minCount int) []models.TermCount {
+	terms := make([]models.TermCount, 0, len(counts))
+	for term, count := range counts {
+		if count >= minCount {
+			terms = append(terms, models.TermCount{Term: term, Count: count})
+		}
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Count != terms[j].Count {
+			return terms[i].Count > terms[j].Count
+		}
+		return terms[i].Term < terms[j].Term
+	})
+	if len(terms) > n {
+		terms = terms[:n]
+	}
+	return terms
+}
+
+// toSet builds a lookup set from words
+func toSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
+
+// roundHundredths rounds a value to two decimal places
+func roundHundredths(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,89 @@
+package youtube
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestAnalyzeSegments(t *testing.T) {
+	segments := []models.TranscriptSegment{
+		{Text: "[Music]", Start: 0, Duration: 5},
+		{Text: "welcome to machine learning", Start: 5, Duration: 5},
+		{Text: "machine learning is fun", Start: 10, Duration: 5},
+		{Text: "[Applause]", Start: 16, Duration: 2},
+		{Text: "Machine learning rocks [Laughter]", Start: 20, Duration: 10},
+		{Text: "♪♪", Start: 30, Duration: 4},
+	}
+
+	analysis := analyzeSegments(segments, models.AnalysisOptions{WindowSeconds: 20, TopTerms: 2})
+
+	if analysis.WordCount != 11 || analysis.DurationSeconds != 34 || analysis.SpeechSeconds != 20 {
+		t.Errorf("Unexpected totals: %d words, %v seconds, %v speaking", analysis.WordCount, analysis.DurationSeconds, analysis.SpeechSeconds)
+	}
+	if analysis.WordsPerMinute != 19.41 {
+		t.Errorf("Expected 19.41 words per minute, got %v", analysis.WordsPerMinute)
+	}
+
+	if len(analysis.Windows) != 2 || analysis.Windows[0].WordCount != 8 || analysis.Windows[1].WordCount != 3 {
+		t.Fatalf("Unexpected windows: %+v", analysis.Windows)
+	}
+	if analysis.Windows[1].End != 34 || analysis.Windows[1].WordsPerMinute != 12.86 {
+		t.Errorf("Expected the last window to be cut at the end, got %+v", analysis.Windows[1])
+	}
+
+	// Music notes are a music tag as much as [Music] is
+	if analysis.TagCounts["music"] != 2 || analysis.TagCounts["applause"] != 1 || analysis.TagCounts["laughter"] != 1 {
+		t.Errorf("Unexpected tag counts: %v", analysis.TagCounts)
+	}
+
+	expectedPauses := []models.TranscriptPause{
+		{Start: 0, End: 5, Duration: 5, Kind: models.PauseKindNonSpeech, Tags: []string{"music"}},
+		{Start: 15, End: 20, Duration: 5, Kind: models.PauseKindNonSpeech, Tags: []string{"applause"}},
+		{Start: 30, End: 34, Duration: 4, Kind: models.PauseKindNonSpeech, Tags: []string{"music"}},
+	}
+	if len(analysis.Pauses) != len(expectedPauses) {
+		t.Fatalf("Expected %d pauses, got %+v", len(expectedPauses), analysis.Pauses)
+	}
+	for i, pause := range analysis.Pauses {
+		expected := expectedPauses[i]
+		if pause.Start != expected.Start || pause.End != expected.End || pause.Kind != expected.Kind || strings.Join(pause.Tags, ",") != strings.Join(expected.Tags, ",") {
+			t.Errorf("Pause %d: expected %+v, got %+v", i, expected, pause)
+		}
+	}
+	if analysis.TotalPauseSeconds != 14 || analysis.LongestPause != 5 {
+		t.Errorf("Unexpected pause totals: %v, %v", analysis.TotalPauseSeconds, analysis.LongestPause)
+	}
+
+	if len(analysis.TopUnigrams) != 2 || analysis.TopUnigrams[0] != (models.TermCount{Term: "learning", Count: 3}) {
+		t.Errorf("Unexpected top unigrams: %+v", analysis.TopUnigrams)
+	}
+	if len(analysis.TopBigrams) != 1 || analysis.TopBigrams[0] != (models.TermCount{Term: "machine learning", Count: 3}) {
+		t.Errorf("Unexpected top bigrams: %+v", analysis.TopBigrams)
+	}
+	if len(analysis.TopTrigrams) != 0 {
+		t.Errorf("Expected no repeated trigrams, got %+v", analysis.TopTrigrams)
+	}
+
+	if analysis.Vocabulary.UniqueWords != 7 || analysis.Vocabulary.TypeTokenRatio != 0.6364 || analysis.Vocabulary.HapaxRatio != 0.7143 {
+		t.Errorf("Unexpected vocabulary: %+v", analysis.Vocabulary)
+	}
+}
+
+func TestTopTermsJapanese(t *testing.T) {
+	segments := []models.TranscriptSegment{
+		{Text: "機械学習のモデルを作ります", Start: 0, Duration: 3},
+		{Text: "機械学習のモデルは便利です", Start: 3, Duration: 3},
+	}
+
+	analysis := analyzeSegments(segments, models.AnalysisOptions{})
+
+	terms := make([]string, 0, len(analysis.TopUnigrams))
+	for _, term := range analysis.TopUnigrams {
+		terms = append(terms, term.Term)
+	}
+	if strings.Join(terms, ",") != "モデル,機械学習,作,便利" {
+		t.Errorf("Unexpected Japanese terms: %v", terms)
+	}
+}
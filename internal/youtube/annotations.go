@@ -0,0 +1,112 @@
+package youtube
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// annotationPattern matches the non-speech annotations of captions: sound events in brackets such
+// as [Music] or ［拍手］, music notes, and the >> marker of a speaker change
+var annotationPattern = regexp.MustCompile(`\[([^\[\]]+)\]|［([^［］]+)］|[♪♫]+|>>`)
+
+// speakerChangeMarker is the caption marker of a new speaker
+const speakerChangeMarker = ">>"
+
+// soundEventNames maps the ways captions write a sound event to one name
+var soundEventNames = map[string]string{
+	"音楽":         "music",
+	"laugh":      "laughter",
+	"laughs":     "laughter",
+	"laughing":   "laughter",
+	"笑":          "laughter",
+	"笑い":         "laughter",
+	"applauding": "applause",
+	"clapping":   "applause",
+	"拍手":         "applause",
+}
+
+// annotateSegment records the sound events and speaker change marked in a segment's text. The
+// text itself is left as captioned.
+func annotateSegment(segment *models.TranscriptSegment) {
+	segment.SoundEvents = nil
+	segment.SpeakerChange = false
+	for _, match := range annotationPattern.FindAllString(segment.Text, -1) {
+		if match == speakerChangeMarker {
+			segment.SpeakerChange = true
+			continue
+		}
+		event := soundEventName(match)
+		if len(segment.SoundEvents) == 0 || segment.SoundEvents[len(segment.SoundEvents)-1] != event {
+			segment.SoundEvents = append(segment.SoundEvents, event)
+		}
+	}
+}
+
+// soundEventName returns the lower-cased name of a sound event annotation
+func soundEventName(annotation string) string {
+	if strings.ContainsAny(annotation, "♪♫") {
+		return "music"
+	}
+	name := strings.ToLower(strings.TrimSpace(strings.Trim(annotation, "[]［］")))
+	if canonical, ok := soundEventNames[name]; ok {
+		return canonical
+	}
+	return name
+}
+
+// stripAnnotations removes the annotations from caption text
+func stripAnnotations(text string) string {
+	return strings.Join(strings.Fields(annotationPattern.ReplaceAllString(text, " ")), " ")
+}
+
+// tagAnnotations rewrites the annotations of caption text in one form: sound events as lower-case
+// [name] tags, each once per run, and speaker changes as >>
+func tagAnnotations(text string) string {
+	previous := ""
+	tagged := annotationPattern.ReplaceAllStringFunc(text, func(match string) string {
+		if match == speakerChangeMarker {
+			previous = ""
+			return " " + speakerChangeMarker + " "
+		}
+		event := soundEventName(match)
+		if event == previous {
+			return " "
+		}
+		previous = event
+		return " [" + event + "] "
+	})
+	return strings.Join(strings.Fields(tagged), " ")
+}
+
+// applyAnnotationMode returns segments with their annotations kept as captioned, stripped or
+// tagged. Segments that only held annotations are dropped when stripping.
+func applyAnnotationMode(segments []models.TranscriptSegment, mode string) []models.TranscriptSegment {
+	if mode != models.AnnotationModeStrip && mode != models.AnnotationModeTag {
+		return segments
+	}
+
+	result := make([]models.TranscriptSegment, 0, len(segments))
+	for _, segment := range segments {
+		rewrite := tagAnnotations
+		if mode == models.AnnotationModeStrip {
+			rewrite = stripAnnotations
+		}
+		segment.Text = rewrite(segment.Text)
+		if segment.Text == "" {
+			continue
+		}
+		if segment.Words != nil {
+			words := make([]models.TranscriptWord, 0, len(segment.Words))
+			for _, word := range segment.Words {
+				if word.Text = rewrite(word.Text); word.Text != "" {
+					words = append(words, word)
+				}
+			}
+			segment.Words = words
+		}
+		result = append(result, segment)
+	}
+	return result
+}
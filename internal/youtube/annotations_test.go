@@ -0,0 +1,116 @@
+package youtube
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestAnnotateSegment(t *testing.T) {
+	tests := []struct {
+		text          string
+		events        []string
+		speakerChange bool
+	}{
+		{"plain words", nil, false},
+		{"[Music]", []string{"music"}, false},
+		{">> so what do you think", nil, true},
+		{">> [LAUGHS] that's funny [Applause]", []string{"laughter", "applause"}, true},
+		{"♪ la la ♪", []string{"music"}, false},
+		{"[Music] [Music] hello", []string{"music"}, false},
+		{"［拍手］ありがとう", []string{"applause"}, false},
+		{"[door slams]", []string{"door slams"}, false},
+	}
+
+	for _, tt := range tests {
+		segment := models.TranscriptSegment{Text: tt.text}
+		annotateSegment(&segment)
+		if !reflect.DeepEqual(segment.SoundEvents, tt.events) {
+			t.Errorf("annotateSegment(%q) events = %v, want %v", tt.text, segment.SoundEvents, tt.events)
+		}
+		if segment.SpeakerChange != tt.speakerChange {
+			t.Errorf("annotateSegment(%q) speaker change = %v, want %v", tt.text, segment.SpeakerChange, tt.speakerChange)
+		}
+		if segment.Text != tt.text {
+			t.Errorf("annotateSegment(%q) changed the text to %q", tt.text, segment.Text)
+		}
+	}
+}
+
+func TestStripAndTagAnnotations(t *testing.T) {
+	tests := []struct {
+		text     string
+		stripped string
+		tagged   string
+	}{
+		{"plain words", "plain words", "plain words"},
+		{"[Music]", "", "[music]"},
+		{"♪ la la ♪", "la la", "[music] la la"},
+		{">> [LAUGHS] hi", "hi", ">> [laughter] hi"},
+		{"[Music] [Music] hello>>there", "hello there", "[music] hello >> there"},
+		{"［拍手］ありがとう", "ありがとう", "[applause] ありがとう"},
+	}
+
+	for _, tt := range tests {
+		if got := stripAnnotations(tt.text); got != tt.stripped {
+			t.Errorf("stripAnnotations(%q) = %q, want %q", tt.text, got, tt.stripped)
+		}
+		if got := tagAnnotations(tt.text); got != tt.tagged {
+			t.Errorf("tagAnnotations(%q) = %q, want %q", tt.text, got, tt.tagged)
+		}
+	}
+}
+
+func TestApplyAnnotationMode(t *testing.T) {
+	segments := []models.TranscriptSegment{
+		{Text: "[Music]", Start: 0, SoundEvents: []string{"music"}},
+		{Text: ">> Welcome back", Start: 2, SpeakerChange: true},
+		{Text: "thanks [Laughter]", Start: 4, SoundEvents: []string{"laughter"}},
+	}
+
+	tests := []struct {
+		mode  string
+		texts []string
+	}{
+		{"", []string{"[Music]", ">> Welcome back", "thanks [Laughter]"}},
+		{models.AnnotationModeKeep, []string{"[Music]", ">> Welcome back", "thanks [Laughter]"}},
+		{models.AnnotationModeStrip, []string{"Welcome back", "thanks"}},
+		{models.AnnotationModeTag, []string{"[music]", ">> Welcome back", "thanks [laughter]"}},
+	}
+
+	for _, tt := range tests {
+		result := applyAnnotationMode(segments, tt.mode)
+		var texts []string
+		for _, segment := range result {
+			texts = append(texts, segment.Text)
+		}
+		if !reflect.DeepEqual(texts, tt.texts) {
+			t.Errorf("applyAnnotationMode(%q) = %q, want %q", tt.mode, texts, tt.texts)
+		}
+	}
+
+	stripped := applyAnnotationMode(segments, models.AnnotationModeStrip)
+	if !stripped[0].SpeakerChange || !reflect.DeepEqual(stripped[1].SoundEvents, []string{"laughter"}) {
+		t.Errorf("applyAnnotationMode(strip) lost the structured fields: %+v", stripped)
+	}
+	if segments[1].Text != ">> Welcome back" {
+		t.Errorf("applyAnnotationMode modified its input: %q", segments[1].Text)
+	}
+}
+
+func TestFormatAnnotatedSegments(t *testing.T) {
+	s := &Service{}
+	segments := []models.TranscriptSegment{
+		{Text: "so that's the plan", Start: 0, Duration: 2},
+		{Text: "[music]", Start: 2, Duration: 2, SoundEvents: []string{"music"}},
+		{Text: ">> sounds good to me", Start: 4, Duration: 2, SpeakerChange: true},
+	}
+
+	if got, want := s.formatAsParagraphs(segments, renderOptions{}), "so that's the plan [music]\n\n>> sounds good to me"; got != want {
+		t.Errorf("formatAsParagraphs() = %q, want %q", got, want)
+	}
+	if got, want := s.formatAsSentences(segments, false), "so that's the plan.\n[music]\n>> sounds good to me."; got != want {
+		t.Errorf("formatAsSentences() = %q, want %q", got, want)
+	}
+}
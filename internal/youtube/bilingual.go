@@ -0,0 +1,213 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// bilingualOverlapTolerance is how far, in seconds, segments of the two languages must overlap to be
+// paired. Boundaries that merely touch or graze each other do not pair segments.
+const bilingualOverlapTolerance = 0.2
+
+// GetBilingualTranscript returns a video's transcript in two languages aligned by time. Each language
+// comes from its own caption track, or from a translation when the video has none in that language.
+func (s *Service) GetBilingualTranscript(ctx context.Context, videoIdentifier string, opts models.BilingualOptions) (*models.BilingualTranscriptResponse, error) {
+	primary, err := s.TranslateTranscript(ctx, videoIdentifier, opts.PrimaryLanguage, "")
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := s.TranslateTranscript(ctx, videoIdentifier, opts.SecondaryLanguage, "")
+	if err != nil {
+		return nil, err
+	}
+
+	formatType := opts.FormatType
+	if formatType == "" {
+		formatType = models.FormatTypeJSON
+	}
+
+	segments, resegmented := alignBilingualSegments(primary.Transcript, secondary.Transcript)
+	response := &models.BilingualTranscriptResponse{
+		VideoID:             primary.VideoID,
+		Title:               primary.Title,
+		PrimaryLanguage:     primary.Language,
+		SecondaryLanguage:   secondary.Language,
+		FormatType:          formatType,
+		Segments:            segments,
+		PrimaryTranslated:   primary.Metadata.IsTranslated,
+		SecondaryTranslated: secondary.Metadata.IsTranslated,
+		Resegmented:         resegmented,
+	}
+
+	switch formatType {
+	case models.FormatTypeMarkdown:
+		response.FormattedText = formatBilingualMarkdown(segments, response.PrimaryLanguage, response.SecondaryLanguage)
+	case models.FormatTypeSRT:
+		response.FormattedText = s.formatAsSRT(dualLineSegments(segments))
+	case models.FormatTypeVTT:
+		response.FormattedText = s.formatAsVTT(dualLineSegments(segments))
+	case models.FormatTypeJSON:
+		jsonBytes, err := json.MarshalIndent(segments, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		response.FormattedText = string(jsonBytes)
+	default:
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeValidationError,
+			Message: fmt.Sprintf("Unsupported bilingual format: %s", formatType),
+			VideoID: primary.VideoID,
+		}
+	}
+
+	return response, nil
+}
+
+// bilingualPiece is a segment of one language with its end trimmed to the next segment's start
+type bilingualPiece struct {
+	text    string
+	start   float64
+	end     float64
+	primary bool
+}
+
+// alignBilingualSegments pairs the segments of two languages by time overlap. Each secondary segment
+// joins the primary segment it overlaps most, and consecutive primary segments that overlap the same
+// secondary segment most share a pair, so pairs stay as short as the segments of the two languages
+// allow however their boundaries are staggered. The second result reports whether any pair merged
+// segments.
+func alignBilingualSegments(primary, secondary []models.TranscriptSegment) ([]models.BilingualSegment, bool) {
+	primaryPieces := trimmedPieces(primary, true)
+	secondaryPieces := trimmedPieces(secondary, false)
+
+	// group[i] is the pair of primary piece i; consecutive pieces mostly covered by the same
+	// secondary piece share it
+	group := make([]int, len(primaryPieces))
+	groups := 0
+	for i, piece := range primaryPieces {
+		if i > 0 {
+			covering := mostOverlapping(piece, secondaryPieces)
+			if covering >= 0 && covering == mostOverlapping(primaryPieces[i-1], secondaryPieces) {
+				group[i] = group[i-1]
+				continue
+			}
+		}
+		group[i] = groups
+		groups++
+	}
+
+	members := make([][]bilingualPiece, groups)
+	for i, piece := range primaryPieces {
+		members[group[i]] = append(members[group[i]], piece)
+	}
+	for _, piece := range secondaryPieces {
+		if best := mostOverlapping(piece, primaryPieces); best >= 0 {
+			members[group[best]] = append(members[group[best]], piece)
+		} else {
+			// Secondary text nothing primary overlaps gets a pair of its own
+			members = append(members, []bilingualPiece{piece})
+		}
+	}
+
+	aligned := make([]models.BilingualSegment, 0, len(members))
+	resegmented := false
+	for _, pieces := range members {
+		var primaryTexts, secondaryTexts []string
+		// Pairs take the time of their primary segments, which never overlap, so cues do not either
+		pair := models.BilingualSegment{Start: pieces[0].start, End: pieces[0].end}
+		for _, piece := range pieces {
+			if piece.primary {
+				pair.End = piece.end
+				primaryTexts = append(primaryTexts, piece.text)
+			} else {
+				secondaryTexts = append(secondaryTexts, piece.text)
+			}
+		}
+		if len(primaryTexts) > 1 || len(secondaryTexts) > 1 {
+			resegmented = true
+		}
+		pair.Primary = strings.Join(primaryTexts, " ")
+		pair.Secondary = strings.Join(secondaryTexts, " ")
+		aligned = append(aligned, pair)
+	}
+	sort.SliceStable(aligned, func(i, j int) bool {
+		return aligned[i].Start < aligned[j].Start
+	})
+
+	return aligned, resegmented
+}
+
+// mostOverlapping returns the index of the piece among others that overlaps piece the longest, by more
+// than bilingualOverlapTolerance, or -1 when none does. Ties go to the earlier piece.
+func mostOverlapping(piece bilingualPiece, others []bilingualPiece) int {
+	best, bestOverlap := -1, bilingualOverlapTolerance
+	for i, other := range others {
+		if overlap := min(piece.end, other.end) - max(piece.start, other.start); overlap > bestOverlap {
+			best, bestOverlap = i, overlap
+		}
+	}
+	return best
+}
+
+// trimmedPieces converts the segments of one language, ending each where the next begins so that
+// overlapping captions of the same track do not count as overlapping the other language
+func trimmedPieces(segments []models.TranscriptSegment, primary bool) []bilingualPiece {
+	pieces := make([]bilingualPiece, 0, len(segments))
+	for i, segment := range segments {
+		end := segmentEnd(segment)
+		if i+1 < len(segments) {
+			if next := segments[i+1].Start; next > segment.Start && next < end {
+				end = next
+			}
+		}
+		pieces = append(pieces, bilingualPiece{
+			text:    strings.TrimSpace(segment.Text),
+			start:   segment.Start,
+			end:     end,
+			primary: primary,
+		})
+	}
+	return pieces
+}
+
+// dualLineSegments turns pairs into subtitle cues with the primary line above the secondary line
+func dualLineSegments(segments []models.BilingualSegment) []models.TranscriptSegment {
+	cues := make([]models.TranscriptSegment, 0, len(segments))
+	for _, segment := range segments {
+		lines := make([]string, 0, 2)
+		for _, text := range []string{segment.Primary, segment.Secondary} {
+			if text != "" {
+				lines = append(lines, text)
+			}
+		}
+		cues = append(cues, models.TranscriptSegment{
+			Text:     strings.Join(lines, "\n"),
+			Start:    segment.Start,
+			Duration: segment.End - segment.Start,
+			End:      segment.End,
+		})
+	}
+	return cues
+}
+
+// formatBilingualMarkdown renders pairs as a Markdown table with one column per language
+func formatBilingualMarkdown(segments []models.BilingualSegment, primaryLanguage, secondaryLanguage string) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "| Time | %s | %s |\n", primaryLanguage, secondaryLanguage)
+	builder.WriteString("|---|---|---|\n")
+	for _, segment := range segments {
+		fmt.Fprintf(&builder, "| %s | %s | %s |\n", formatClock(segment.Start), markdownCell(segment.Primary), markdownCell(segment.Secondary))
+	}
+	return strings.TrimSpace(builder.String())
+}
+
+// markdownCell escapes text for a single Markdown table cell
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,122 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestAlignBilingualSegments(t *testing.T) {
+	tests := []struct {
+		name        string
+		primary     []models.TranscriptSegment
+		secondary   []models.TranscriptSegment
+		expected    []models.BilingualSegment
+		resegmented bool
+	}{
+		{
+			name:      "matching boundaries",
+			primary:   []models.TranscriptSegment{{Text: "Hello", Start: 0, Duration: 2}, {Text: "world", Start: 2, Duration: 2}},
+			secondary: []models.TranscriptSegment{{Text: "Hallo", Start: 0, Duration: 2}, {Text: "Welt", Start: 2.1, Duration: 1.9}},
+			expected: []models.BilingualSegment{
+				{Primary: "Hello", Secondary: "Hallo", Start: 0, End: 2},
+				{Primary: "world", Secondary: "Welt", Start: 2, End: 4},
+			},
+		},
+		{
+			name:      "one segment spans two",
+			primary:   []models.TranscriptSegment{{Text: "Good morning", Start: 0, Duration: 4}, {Text: "everyone", Start: 4, Duration: 2}},
+			secondary: []models.TranscriptSegment{{Text: "Guten", Start: 0, Duration: 2}, {Text: "Morgen", Start: 2, Duration: 2}, {Text: "alle", Start: 4, Duration: 2}},
+			expected: []models.BilingualSegment{
+				{Primary: "Good morning", Secondary: "Guten Morgen", Start: 0, End: 4},
+				{Primary: "everyone", Secondary: "alle", Start: 4, End: 6},
+			},
+			resegmented: true,
+		},
+		{
+			name: "staggered boundaries stay bounded",
+			primary: []models.TranscriptSegment{
+				{Text: "a", Start: 0, Duration: 3}, {Text: "b", Start: 3, Duration: 3},
+				{Text: "c", Start: 6, Duration: 3}, {Text: "d", Start: 9, Duration: 3},
+			},
+			secondary: []models.TranscriptSegment{
+				{Text: "w", Start: 1, Duration: 3}, {Text: "x", Start: 4, Duration: 3},
+				{Text: "y", Start: 7, Duration: 3}, {Text: "z", Start: 10, Duration: 3},
+				{Text: "only", Start: 14, Duration: 1},
+			},
+			expected: []models.BilingualSegment{
+				{Primary: "a", Secondary: "w", Start: 0, End: 3},
+				{Primary: "b", Secondary: "x", Start: 3, End: 6},
+				{Primary: "c", Secondary: "y", Start: 6, End: 9},
+				{Primary: "d", Secondary: "z", Start: 9, End: 12},
+				{Secondary: "only", Start: 14, End: 15},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aligned, resegmented := alignBilingualSegments(tt.primary, tt.secondary)
+			if resegmented != tt.resegmented {
+				t.Errorf("Expected resegmented %v, got %v", tt.resegmented, resegmented)
+			}
+			if len(aligned) != len(tt.expected) {
+				t.Fatalf("Expected %d pairs, got %+v", len(tt.expected), aligned)
+			}
+			for i, pair := range aligned {
+				if pair != tt.expected[i] {
+					t.Errorf("Pair %d: expected %+v, got %+v", i, tt.expected[i], pair)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatBilingualMarkdown(t *testing.T) {
+	segments := []models.BilingualSegment{{Primary: "a | b", Secondary: "c\nd", Start: 65, End: 70}}
+	expected := "| Time | en | de |\n|---|---|---|\n| 1:05 | a \\| b | c d |"
+	if result := formatBilingualMarkdown(segments, "en", "de"); result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
+
+func TestGetBilingualTranscript(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/watch", func(w http.ResponseWriter, r *http.Request) {
+		captionURL := "http://" + r.Host + "/api/timedtext"
+		fmt.Fprintf(w, `<script>var ytInitialPlayerResponse = {"videoDetails":{"videoId":"dQw4w9WgXcQ","title":"Demo"},"captions":{"playerCaptionsTracklistRenderer":{`+
+			`"captionTracks":[{"baseUrl":"%[1]s?lang=en","languageCode":"en","isTranslatable":true,"isDefault":true}],`+
+			`"translationLanguages":[{"languageCode":"de","languageName":{"simpleText":"German"}}]}}};</script>`, captionURL)
+	})
+	mux.HandleFunc("/api/timedtext", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tlang") == "de" {
+			_, _ = fmt.Fprint(w, `<transcript><text start="0" dur="4">Guten Morgen</text></transcript>`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `<transcript><text start="0" dur="2">Good</text><text start="2" dur="2">morning</text></transcript>`)
+	})
+
+	service := newTestService(t, mux)
+
+	response, err := service.GetBilingualTranscript(context.Background(), "dQw4w9WgXcQ", models.BilingualOptions{
+		PrimaryLanguage:   "en",
+		SecondaryLanguage: "de",
+		FormatType:        models.FormatTypeSRT,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response.PrimaryTranslated || !response.SecondaryTranslated || !response.Resegmented {
+		t.Errorf("Unexpected flags: %+v", response)
+	}
+	if len(response.Segments) != 1 || response.Segments[0].Primary != "Good morning" {
+		t.Fatalf("Unexpected segments: %+v", response.Segments)
+	}
+	if !strings.Contains(response.FormattedText, "00:00:00,000 --> 00:00:04,000\nGood morning\nGuten Morgen") {
+		t.Errorf("Expected a dual-line cue, got %q", response.FormattedText)
+	}
+}
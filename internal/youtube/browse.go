@@ -0,0 +1,339 @@
+package youtube
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// maxContinuationPages caps how many continuation pages a single listing follows
+const maxContinuationPages = 50
+
+// defaultInnertubeClientVersion is used when a page does not publish its web client version
+const defaultInnertubeClientVersion = "2.20240101.00.00"
+
+var (
+	innertubeAPIKeyPattern        = regexp.MustCompile(`"INNERTUBE_API_KEY"\s*:\s*"([^"]+)"`)
+	innertubeClientVersionPattern = regexp.MustCompile(`"INNERTUBE_CLIENT_VERSION"\s*:\s*"([^"]+)"`)
+)
+
+// innertubeConfig holds the client settings a page publishes for innertube API calls
+type innertubeConfig struct {
+	APIKey        string
+	ClientVersion string
+}
+
+// extractInnertubeConfig reads the innertube API key and client version from a page's ytcfg
+func extractInnertubeConfig(html string) innertubeConfig {
+	cfg := innertubeConfig{ClientVersion: defaultInnertubeClientVersion}
+	if matches := innertubeAPIKeyPattern.FindStringSubmatch(html); matches != nil {
+		cfg.APIKey = matches[1]
+	}
+	if matches := innertubeClientVersionPattern.FindStringSubmatch(html); matches != nil {
+		cfg.ClientVersion = matches[1]
+	}
+	return cfg
+}
+
+// RunsText is a text field that YouTube sends either as simpleText or as formatted runs
+type RunsText struct {
+	SimpleText string `json:"simpleText"`
+	Runs       []struct {
+		Text string `json:"text"`
+	} `json:"runs"`
+}
+
+// String returns the plain text of the field
+func (t RunsText) String() string {
+	if t.SimpleText != "" {
+		return t.SimpleText
+	}
+	var builder strings.Builder
+	for _, run := range t.Runs {
+		builder.WriteString(run.Text)
+	}
+	return builder.String()
+}
+
+// ContinuationItemRenderer points at the next page of a listing
+type ContinuationItemRenderer struct {
+	ContinuationEndpoint struct {
+		ContinuationCommand struct {
+			Token string `json:"token"`
+		} `json:"continuationCommand"`
+	} `json:"continuationEndpoint"`
+}
+
+// BrowseItem is one entry of a browse listing; exactly one renderer is set
+type BrowseItem struct {
+	PlaylistVideoRenderer *PlaylistVideoRenderer `json:"playlistVideoRenderer,omitempty"`
+	RichItemRenderer      *struct {
+		Content struct {
+			VideoRenderer *VideoRenderer `json:"videoRenderer,omitempty"`
+		} `json:"content"`
+	} `json:"richItemRenderer,omitempty"`
+	GridVideoRenderer        *VideoRenderer            `json:"gridVideoRenderer,omitempty"`
+	ContinuationItemRenderer *ContinuationItemRenderer `json:"continuationItemRenderer,omitempty"`
+}
+
+// videoRenderer returns the video of a channel grid entry, or nil for other entries
+func (i BrowseItem) videoRenderer() *VideoRenderer {
+	if i.RichItemRenderer != nil && i.RichItemRenderer.Content.VideoRenderer != nil {
+		return i.RichItemRenderer.Content.VideoRenderer
+	}
+	return i.GridVideoRenderer
+}
+
+// VideoRenderer describes a video tile in channel grids and search results
+type VideoRenderer struct {
+	VideoID           string   `json:"videoId"`
+	Title             RunsText `json:"title"`
+	PublishedTimeText RunsText `json:"publishedTimeText"`
+	LengthText        RunsText `json:"lengthText"`
+	ViewCountText     RunsText `json:"viewCountText"`
+	OwnerText         struct {
+		Runs []struct {
+			Text               string `json:"text"`
+			NavigationEndpoint struct {
+				BrowseEndpoint struct {
+					BrowseID string `json:"browseId"`
+				} `json:"browseEndpoint"`
+			} `json:"navigationEndpoint"`
+		} `json:"runs"`
+	} `json:"ownerText"`
+	Badges []struct {
+		MetadataBadgeRenderer struct {
+			Label string `json:"label"`
+		} `json:"metadataBadgeRenderer"`
+	} `json:"badges"`
+	DetailedMetadataSnippets []struct {
+		SnippetText RunsText `json:"snippetText"`
+	} `json:"detailedMetadataSnippets"`
+}
+
+// PlaylistVideoRenderer describes a video entry of a playlist
+type PlaylistVideoRenderer struct {
+	VideoID       string   `json:"videoId"`
+	Title         RunsText `json:"title"`
+	Index         RunsText `json:"index"`
+	LengthSeconds string   `json:"lengthSeconds"`
+	IsPlayable    bool     `json:"isPlayable"`
+}
+
+// BrowseData holds the parts of a browse page's ytInitialData that the service reads
+type BrowseData struct {
+	Contents struct {
+		TwoColumnBrowseResultsRenderer struct {
+			Tabs []struct {
+				TabRenderer struct {
+					Title    string `json:"title"`
+					Selected bool   `json:"selected"`
+					Content  struct {
+						RichGridRenderer struct {
+							Contents []BrowseItem `json:"contents"`
+						} `json:"richGridRenderer"`
+						SectionListRenderer struct {
+							Contents []struct {
+								ItemSectionRenderer struct {
+									Contents []struct {
+										PlaylistVideoListRenderer struct {
+											Contents []BrowseItem `json:"contents"`
+										} `json:"playlistVideoListRenderer"`
+									} `json:"contents"`
+								} `json:"itemSectionRenderer"`
+							} `json:"contents"`
+						} `json:"sectionListRenderer"`
+					} `json:"content"`
+				} `json:"tabRenderer"`
+			} `json:"tabs"`
+		} `json:"twoColumnBrowseResultsRenderer"`
+	} `json:"contents"`
+	Metadata struct {
+		PlaylistMetadataRenderer struct {
+			Title string `json:"title"`
+		} `json:"playlistMetadataRenderer"`
+		ChannelMetadataRenderer struct {
+			Title            string `json:"title"`
+			ExternalID       string `json:"externalId"`
+			VanityChannelURL string `json:"vanityChannelUrl"`
+		} `json:"channelMetadataRenderer"`
+	} `json:"metadata"`
+	Alerts []struct {
+		AlertRenderer struct {
+			Type string   `json:"type"`
+			Text RunsText `json:"text"`
+		} `json:"alertRenderer"`
+	} `json:"alerts"`
+}
+
+// playlistItems returns the entries of the playlist video list on the selected tab
+func (d *BrowseData) playlistItems() ([]BrowseItem, bool) {
+	for _, tab := range d.Contents.TwoColumnBrowseResultsRenderer.Tabs {
+		for _, section := range tab.TabRenderer.Content.SectionListRenderer.Contents {
+			for _, item := range section.ItemSectionRenderer.Contents {
+				if items := item.PlaylistVideoListRenderer.Contents; len(items) > 0 {
+					return items, true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+// gridItems returns the entries of the video grid on the selected tab
+func (d *BrowseData) gridItems() ([]BrowseItem, bool) {
+	for _, tab := range d.Contents.TwoColumnBrowseResultsRenderer.Tabs {
+		if !tab.TabRenderer.Selected {
+			continue
+		}
+		if items := tab.TabRenderer.Content.RichGridRenderer.Contents; len(items) > 0 {
+			return items, true
+		}
+	}
+	return nil, false
+}
+
+// alertText returns the first error alert shown on the page, such as "This playlist does not exist."
+func (d *BrowseData) alertText() string {
+	for _, alert := range d.Alerts {
+		if alert.AlertRenderer.Type == "ERROR" {
+			return alert.AlertRenderer.Text.String()
+		}
+	}
+	return ""
+}
+
+// ContinuationResponse is an innertube response carrying the next page of a listing
+type ContinuationResponse struct {
+	OnResponseReceivedActions []struct {
+		AppendContinuationItemsAction struct {
+			ContinuationItems []BrowseItem `json:"continuationItems"`
+		} `json:"appendContinuationItemsAction"`
+		ReloadContinuationItemsCommand struct {
+			ContinuationItems []BrowseItem `json:"continuationItems"`
+		} `json:"reloadContinuationItemsCommand"`
+	} `json:"onResponseReceivedActions"`
+}
+
+// items returns every listing entry carried by the response
+func (r *ContinuationResponse) items() []BrowseItem {
+	var items []BrowseItem
+	for _, action := range r.OnResponseReceivedActions {
+		items = append(items, action.AppendContinuationItemsAction.ContinuationItems...)
+		items = append(items, action.ReloadContinuationItemsCommand.ContinuationItems...)
+	}
+	return items
+}
+
+// continuationToken returns the token of the last continuation entry in items
+func continuationToken(items []BrowseItem) string {
+	for i := len(items) - 1; i >= 0; i-- {
+		if renderer := items[i].ContinuationItemRenderer; renderer != nil {
+			return renderer.ContinuationEndpoint.ContinuationCommand.Token
+		}
+	}
+	return ""
+}
+
+// fetchContinuation fetches the next page of a browse listing, waiting for the rate limiters first
+func (s *Service) fetchContinuation(ctx context.Context, cfg innertubeConfig, token string, id string) (*ContinuationResponse, error) {
+	body, err := s.postInnertube(ctx, "browse", cfg, map[string]any{"continuation": token}, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var response ContinuationResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeParsingError,
+			Message: fmt.Sprintf("Failed to parse continuation response: %s", err.Error()),
+			VideoID: id,
+		}
+	}
+	return &response, nil
+}
+
+// postInnertube calls an innertube API endpoint as the web client. It waits for the rate limiters and
+// reports the outcome to the adaptive backoff like the page fetches do.
+func (s *Service) postInnertube(ctx context.Context, endpoint string, cfg innertubeConfig, payload map[string]any, id string) ([]byte, error) {
+	if waitErr := s.waitForRateLimit(ctx); waitErr != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeRateLimitExceeded,
+			Message: fmt.Sprintf("Rate limit exceeded: %s", waitErr.Error()),
+			VideoID: id,
+		}
+	}
+
+	request := map[string]any{
+		"context": map[string]any{
+			"client": map[string]any{
+				"clientName":    "WEB",
+				"clientVersion": cfg.ClientVersion,
+				"hl":            "en",
+			},
+		},
+	}
+	for key, value := range payload {
+		request[key] = value
+	}
+
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	endpointURL := s.youtubeURL("/youtubei/v1/" + endpoint + "?prettyPrint=false")
+	if cfg.APIKey != "" {
+		endpointURL += "&key=" + url.QueryEscape(cfg.APIKey)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpointURL, bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", s.config.UserAgent)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	req.Header.Set("X-Youtube-Client-Name", "1")
+	req.Header.Set("X-Youtube-Client-Version", cfg.ClientVersion)
+
+	resp, err := s.doHTTPRequestWithRetry(ctx, req)
+	if err != nil {
+		s.recordRateLimitFailure(err)
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeNetworkError,
+			Message: fmt.Sprintf("Failed to call %s: %s", endpoint, err.Error()),
+			VideoID: id,
+		}
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			slog.Warn("Failed to close response body", "error", closeErr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeNetworkError,
+			Message: fmt.Sprintf("HTTP error: %d", resp.StatusCode),
+			VideoID: id,
+		}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordRateLimitSuccess()
+	return body, nil
+}
@@ -0,0 +1,314 @@
+package youtube
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+var (
+	// channelIDPattern matches a bare channel ID
+	channelIDPattern = regexp.MustCompile(`^UC[a-zA-Z0-9_-]{22}$`)
+	// relativeTimePattern matches the relative publish times of video tiles, such as "Streamed 3 weeks ago"
+	relativeTimePattern = regexp.MustCompile(`(\d+)\s+(second|minute|hour|day|week|month|year)s?\s+ago`)
+)
+
+// relativeTimeUnits are the lengths of the units used in relative publish times
+var relativeTimeUnits = map[string]time.Duration{
+	"second": time.Second,
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    24 * time.Hour,
+	"week":   7 * 24 * time.Hour,
+	"month":  30 * 24 * time.Hour,
+	"year":   365 * 24 * time.Hour,
+}
+
+// ListChannelVideos lists a channel's uploads, newest first, one page at a time. With
+// IncludeTranscripts set the listed videos are fed into the batch transcript path, whose
+// per-video fetches wait for the minute and hourly rate limiters.
+func (s *Service) ListChannelVideos(ctx context.Context, channelIdentifier string, opts models.ChannelVideosOptions) (*models.ChannelVideosResponse, error) {
+	channelPath, err := extractChannelPath(channelIdentifier)
+	if err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeValidationError,
+			Message: fmt.Sprintf("Invalid channel identifier: %s", err.Error()),
+			VideoID: channelIdentifier,
+		}
+	}
+
+	maxResults := opts.MaxResults
+	if maxResults <= 0 {
+		maxResults = models.DefaultChannelVideos
+	}
+
+	offset, continuation, err := decodeChannelPageToken(opts.PageToken)
+	if err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeValidationError,
+			Message: err.Error(),
+			VideoID: channelIdentifier,
+		}
+	}
+
+	response := &models.ChannelVideosResponse{
+		Videos: make([]models.ChannelVideo, 0, maxResults),
+	}
+
+	// Continuation pages are fetched without the channel page; the default web client settings suffice
+	cfg := innertubeConfig{ClientVersion: defaultInnertubeClientVersion}
+	var items []BrowseItem
+	if continuation == "" {
+		items, cfg, err = s.fetchChannelGrid(ctx, channelPath, response)
+	} else {
+		var page *ContinuationResponse
+		if page, err = s.fetchContinuation(ctx, cfg, continuation, channelPath); err == nil {
+			items = page.items()
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for page := 0; ; page++ {
+		videos := make([]*VideoRenderer, 0, len(items))
+		for _, item := range items {
+			if renderer := item.videoRenderer(); renderer != nil && renderer.VideoID != "" {
+				videos = append(videos, renderer)
+			}
+		}
+		next := continuationToken(items)
+
+		done := false
+		for i := min(offset, len(videos)); i < len(videos) && !done; i++ {
+			video, earliest, latest, dated := channelVideoFromRenderer(videos[i], now)
+
+			// Uploads are listed newest first, so the first video older than the range ends the listing
+			if opts.PublishedAfter != nil && dated && latest.Before(*opts.PublishedAfter) {
+				next = ""
+				done = true
+				break
+			}
+			if !matchesPublishRange(opts, dated, earliest, latest) {
+				continue
+			}
+
+			response.Videos = append(response.Videos, video)
+			if len(response.Videos) >= maxResults {
+				done = true
+				if i+1 < len(videos) {
+					response.NextPageToken = encodeChannelPageToken(i+1, continuation)
+				} else if next != "" {
+					response.NextPageToken = encodeChannelPageToken(0, next)
+				}
+			}
+		}
+		if done || next == "" || page >= maxContinuationPages {
+			break
+		}
+
+		result, err := s.fetchContinuation(ctx, cfg, next, channelPath)
+		if err != nil {
+			s.logger.Warn("Failed to fetch channel continuation", "channel", channelPath, "error", err)
+			response.NextPageToken = encodeChannelPageToken(0, next)
+			break
+		}
+		items = result.items()
+		continuation = next
+		offset = 0
+	}
+
+	if opts.IncludeTranscripts && len(response.Videos) > 0 {
+		videoIDs := make([]string, len(response.Videos))
+		for i, video := range response.Videos {
+			videoIDs[i] = video.VideoID
+		}
+		batch, err := s.GetMultipleTranscriptsWithOptions(ctx, videoIDs, opts.Languages, true, opts.Transcript)
+		if err != nil {
+			return nil, err
+		}
+		orderBatchResults(batch, videoIDs)
+		response.Transcripts = batch
+	}
+
+	return response, nil
+}
+
+// fetchChannelGrid fetches the first page of a channel's videos tab and fills the channel details of response
+func (s *Service) fetchChannelGrid(ctx context.Context, channelPath string, response *models.ChannelVideosResponse) ([]BrowseItem, innertubeConfig, error) {
+	if waitErr := s.waitForRateLimit(ctx); waitErr != nil {
+		return nil, innertubeConfig{}, &models.TranscriptError{
+			Type:    models.ErrorTypeRateLimitExceeded,
+			Message: fmt.Sprintf("Rate limit exceeded: %s", waitErr.Error()),
+			VideoID: channelPath,
+		}
+	}
+
+	html, err := s.fetchPage(ctx, s.youtubeURL(channelPath+"/videos"), channelPath)
+	if err != nil {
+		s.recordRateLimitFailure(err)
+		return nil, innertubeConfig{}, err
+	}
+	s.recordRateLimitSuccess()
+
+	var browseData BrowseData
+	raw, ok := extractJSONVariable(html, "ytInitialData")
+	if ok {
+		ok = json.Unmarshal(raw, &browseData) == nil
+	}
+	if !ok {
+		return nil, innertubeConfig{}, &models.TranscriptError{
+			Type:    models.ErrorTypeParsingError,
+			Message: "Failed to extract channel data from page",
+			VideoID: channelPath,
+		}
+	}
+
+	response.ChannelID = browseData.Metadata.ChannelMetadataRenderer.ExternalID
+	response.ChannelTitle = browseData.Metadata.ChannelMetadataRenderer.Title
+
+	// A channel without uploads has no grid; that is an empty listing rather than an error
+	items, _ := browseData.gridItems()
+	if response.ChannelID == "" && len(items) == 0 {
+		message := browseData.alertText()
+		if message == "" {
+			message = "Channel not found or has no videos tab"
+		}
+		return nil, innertubeConfig{}, &models.TranscriptError{
+			Type:    models.ErrorTypeVideoUnavailable,
+			Message: message,
+			VideoID: channelPath,
+		}
+	}
+
+	return items, extractInnertubeConfig(html), nil
+}
+
+// channelVideoFromRenderer converts a grid tile. The returned bounds bracket the real publish time,
+// since "3 weeks ago" means anywhere from three to just under four weeks.
+func channelVideoFromRenderer(renderer *VideoRenderer, now time.Time) (video models.ChannelVideo, earliest, latest time.Time, dated bool) {
+	video = models.ChannelVideo{
+		VideoID:       renderer.VideoID,
+		Title:         renderer.Title.String(),
+		PublishedText: renderer.PublishedTimeText.String(),
+		Duration:      renderer.LengthText.String(),
+		URL:           buildTimestampURL(renderer.VideoID, 0),
+	}
+	if seconds, err := models.ParseTimeOffset(video.Duration); err == nil {
+		video.DurationSeconds = seconds
+	}
+	if fields := strings.Fields(renderer.ViewCountText.String()); len(fields) > 0 {
+		if views, ok := parseCompactCount(fields[0]); ok {
+			video.ViewCount = views
+		}
+	}
+
+	earliest, latest, dated = relativeTimeRange(video.PublishedText, now)
+	if dated {
+		video.PublishedAt = &latest
+	}
+	return video, earliest, latest, dated
+}
+
+// relativeTimeRange converts "N units ago" into the range of times it can stand for
+func relativeTimeRange(text string, now time.Time) (time.Time, time.Time, bool) {
+	matches := relativeTimePattern.FindStringSubmatch(strings.ToLower(text))
+	if matches == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	count, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	unit := relativeTimeUnits[matches[2]]
+	latest := now.Add(-time.Duration(count) * unit)
+	return latest.Add(-unit), latest, true
+}
+
+// matchesPublishRange reports whether a video may have been published within the requested dates.
+// Undated videos, such as upcoming premieres, only match when no range is set.
+func matchesPublishRange(opts models.ChannelVideosOptions, dated bool, earliest, latest time.Time) bool {
+	if opts.PublishedAfter == nil && opts.PublishedBefore == nil {
+		return true
+	}
+	if !dated {
+		return false
+	}
+	if opts.PublishedAfter != nil && latest.Before(*opts.PublishedAfter) {
+		return false
+	}
+	if opts.PublishedBefore != nil && earliest.After(*opts.PublishedBefore) {
+		return false
+	}
+	return true
+}
+
+// encodeChannelPageToken packs the position in a listing into an opaque page token. An empty
+// continuation refers to the first page, served by the channel page itself.
+func encodeChannelPageToken(offset int, continuation string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset) + "|" + continuation))
+}
+
+// decodeChannelPageToken unpacks a token made by encodeChannelPageToken
+func decodeChannelPageToken(token string) (int, string, error) {
+	if token == "" {
+		return 0, "", nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid page token")
+	}
+	offsetText, continuation, found := strings.Cut(string(raw), "|")
+	offset, err := strconv.Atoi(offsetText)
+	if !found || err != nil || offset < 0 {
+		return 0, "", fmt.Errorf("invalid page token")
+	}
+	return offset, continuation, nil
+}
+
+// extractChannelPath returns the site path of a channel from an @handle, a channel ID, or a
+// /@handle, /channel/, /c/ or /user/ URL
+func extractChannelPath(identifier string) (string, error) {
+	identifier = strings.TrimSpace(identifier)
+	if channelIDPattern.MatchString(identifier) {
+		return "/channel/" + identifier, nil
+	}
+	if strings.HasPrefix(identifier, "@") && len(identifier) > 1 && !strings.ContainsAny(identifier, "/?#") {
+		return "/" + url.PathEscape(identifier), nil
+	}
+
+	raw := identifier
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + strings.TrimPrefix(raw, "/")
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("could not extract channel from: %s", identifier)
+	}
+
+	// A path without a host, such as "channel/UC..." or "c/name", is parsed as a host
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if !strings.Contains(parsed.Host, ".") {
+		segments = append([]string{parsed.Host}, segments...)
+	}
+
+	switch {
+	case len(segments) >= 1 && strings.HasPrefix(segments[0], "@") && len(segments[0]) > 1:
+		return "/" + url.PathEscape(segments[0]), nil
+	case len(segments) >= 2 && segments[0] == "channel" && channelIDPattern.MatchString(segments[1]):
+		return "/channel/" + segments[1], nil
+	case len(segments) >= 2 && (segments[0] == "c" || segments[0] == "user") && segments[1] != "":
+		return "/" + segments[0] + "/" + url.PathEscape(segments[1]), nil
+	}
+
+	return "", fmt.Errorf("could not extract channel from: %s", identifier)
+}
@@ -0,0 +1,151 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestExtractChannelPath(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"@GoogleDevelopers", "/@GoogleDevelopers", false},
+		{"UC_x5XG1OV2P6uZZ5FSM9Ttw", "/channel/UC_x5XG1OV2P6uZZ5FSM9Ttw", false},
+		{"https://www.youtube.com/@GoogleDevelopers/videos", "/@GoogleDevelopers", false},
+		{"https://www.youtube.com/channel/UC_x5XG1OV2P6uZZ5FSM9Ttw", "/channel/UC_x5XG1OV2P6uZZ5FSM9Ttw", false},
+		{"youtube.com/c/GoogleDevelopers/featured", "/c/GoogleDevelopers", false},
+		{"/user/GoogleDevelopers", "/user/GoogleDevelopers", false},
+		{"channel/UC_x5XG1OV2P6uZZ5FSM9Ttw", "/channel/UC_x5XG1OV2P6uZZ5FSM9Ttw", false},
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ", "", true},
+		{"@", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := extractChannelPath(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extractChannelPath(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("extractChannelPath(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRelativeTimeRange(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	earliest, latest, ok := relativeTimeRange("Streamed 3 weeks ago", now)
+	if !ok {
+		t.Fatal("Expected relative time to parse")
+	}
+	if !latest.Equal(now.Add(-21 * 24 * time.Hour)) {
+		t.Errorf("Unexpected latest time %v", latest)
+	}
+	if !earliest.Equal(now.Add(-28 * 24 * time.Hour)) {
+		t.Errorf("Unexpected earliest time %v", earliest)
+	}
+
+	if _, _, ok := relativeTimeRange("Premieres tomorrow", now); ok {
+		t.Error("Expected non-relative text to be rejected")
+	}
+}
+
+func TestChannelPageTokenRoundTrip(t *testing.T) {
+	token := encodeChannelPageToken(7, "abc|def")
+	offset, continuation, err := decodeChannelPageToken(token)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if offset != 7 || continuation != "abc|def" {
+		t.Errorf("Expected 7 and 'abc|def', got %d and %q", offset, continuation)
+	}
+
+	if _, _, err := decodeChannelPageToken("not a token!"); err == nil {
+		t.Error("Expected error for invalid token")
+	}
+}
+
+// channelGridItem renders a channel grid tile for the fake channel pages
+func channelGridItem(videoID, published string) string {
+	return fmt.Sprintf(`{"richItemRenderer":{"content":{"videoRenderer":{"videoId":%q,"title":{"runs":[{"text":"Video %s"}]},`+
+		`"publishedTimeText":{"simpleText":%q},"lengthText":{"simpleText":"12:34"},"viewCountText":{"simpleText":"1,234 views"}}}}}`, videoID, videoID, published)
+}
+
+func TestListChannelVideos(t *testing.T) {
+	channelPage := `<script>var ytInitialData = {"metadata":{"channelMetadataRenderer":{"title":"Example","externalId":"UC_x5XG1OV2P6uZZ5FSM9Ttw"}},` +
+		`"contents":{"twoColumnBrowseResultsRenderer":{"tabs":[{"tabRenderer":{"title":"Home"}},{"tabRenderer":{"title":"Videos","selected":true,"content":{"richGridRenderer":{"contents":[` +
+		channelGridItem("aaaaaaaaaaa", "2 days ago") + `,` +
+		channelGridItem("bbbbbbbbbbb", "3 weeks ago") + `,` +
+		`{"continuationItemRenderer":{"continuationEndpoint":{"continuationCommand":{"token":"grid-2"}}}}]}}}}]}}};</script>`
+	continuation := `{"onResponseReceivedActions":[{"appendContinuationItemsAction":{"continuationItems":[` +
+		channelGridItem("ccccccccccc", "2 months ago") + `,` +
+		channelGridItem("ddddddddddd", "2 years ago") + `]}}]}`
+
+	var continuationRequests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/@example/videos", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, channelPage)
+	})
+	mux.HandleFunc("/youtubei/v1/browse", func(w http.ResponseWriter, r *http.Request) {
+		continuationRequests++
+		_, _ = io.WriteString(w, continuation)
+	})
+
+	service := newTestService(t, mux)
+	ctx := context.Background()
+
+	// Paging splits a page and resumes from the token
+	first, err := service.ListChannelVideos(ctx, "@example", models.ChannelVideosOptions{MaxResults: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if first.ChannelTitle != "Example" || len(first.Videos) != 1 || first.Videos[0].VideoID != "aaaaaaaaaaa" {
+		t.Fatalf("Unexpected first page: %+v", first)
+	}
+	if first.Videos[0].DurationSeconds != 754 || first.Videos[0].ViewCount != 1234 || first.Videos[0].PublishedAt == nil {
+		t.Errorf("Unexpected video details: %+v", first.Videos[0])
+	}
+
+	second, err := service.ListChannelVideos(ctx, "@example", models.ChannelVideosOptions{MaxResults: 2, PageToken: first.NextPageToken})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(second.Videos) != 2 || second.Videos[0].VideoID != "bbbbbbbbbbb" || second.Videos[1].VideoID != "ccccccccccc" {
+		t.Fatalf("Unexpected second page: %+v", second.Videos)
+	}
+
+	// A lower date bound stops the listing at the first older video
+	after := time.Now().AddDate(0, -6, 0)
+	recent, err := service.ListChannelVideos(ctx, "@example", models.ChannelVideosOptions{PublishedAfter: &after})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(recent.Videos) != 3 || recent.NextPageToken != "" {
+		t.Errorf("Expected 3 videos and no next page, got %d and %q", len(recent.Videos), recent.NextPageToken)
+	}
+
+	// An upper date bound skips the newest uploads
+	before := time.Now().AddDate(0, 0, -10)
+	older, err := service.ListChannelVideos(ctx, "@example", models.ChannelVideosOptions{PublishedBefore: &before})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ids := make([]string, len(older.Videos))
+	for i, video := range older.Videos {
+		ids[i] = video.VideoID
+	}
+	if strings.Join(ids, ",") != "bbbbbbbbbbb,ccccccccccc,ddddddddddd" {
+		t.Errorf("Unexpected videos before bound: %v", ids)
+	}
+}
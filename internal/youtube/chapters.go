@@ -0,0 +1,241 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// minDescriptionChapters is the fewest timestamps YouTube accepts as a chapter list
+const minDescriptionChapters = 3
+
+var (
+	// descriptionLeadingTimestamp matches "0:00 Intro", "(1:02:03) - Outro" and "• 12:30 | Demo"
+	descriptionLeadingTimestamp = regexp.MustCompile(`^\s*(?:[-–—•*▶►]\s*)?[\[(]?((?:\d{1,2}:)?\d{1,2}:\d{2})[\])]?\s*(?:[-–—:|.]\s*)?(\S.*?)\s*$`)
+	// descriptionTrailingTimestamp matches "Intro - 0:00" and "Outro (1:02:03)"
+	descriptionTrailingTimestamp = regexp.MustCompile(`^\s*(?:[-–—•*▶►]\s*)?(\S.*?)\s*(?:[-–—:|]\s*)?[\[(]?((?:\d{1,2}:)?\d{1,2}:\d{2})[\])]?\s*$`)
+)
+
+// GetChapters returns the chapters of a video. Official chapter markers from the page data are
+// preferred; timestamps in the description are used when the page has none.
+func (s *Service) GetChapters(ctx context.Context, videoIdentifier string) (*models.ChaptersResponse, error) {
+	videoID, err := s.extractVideoID(videoIdentifier)
+	if err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeInvalidVideoID,
+			Message: fmt.Sprintf("Invalid video identifier: %s", err.Error()),
+			VideoID: videoIdentifier,
+		}
+	}
+
+	// Check cache
+	cacheKey := fmt.Sprintf("%s%s", models.CacheKeyPrefixChapters, videoID)
+	if cached, found := s.cache.Get(ctx, cacheKey); found {
+		if chapters, ok := cached.(*models.ChaptersResponse); ok {
+			return chapters, nil
+		}
+	}
+
+	if waitErr := s.waitForRateLimit(ctx); waitErr != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeRateLimitExceeded,
+			Message: fmt.Sprintf("Rate limit exceeded: %s", waitErr.Error()),
+			VideoID: videoID,
+		}
+	}
+
+	videoData, err := s.fetchVideoData(ctx, videoID)
+	if err != nil {
+		s.recordRateLimitFailure(err)
+		return nil, err
+	}
+	s.recordRateLimitSuccess()
+
+	response := chaptersFromVideoData(videoData)
+
+	if err := s.cache.Set(ctx, cacheKey, response, s.metadataTTL()); err != nil {
+		s.logger.Warn("Failed to cache chapters response", "error", err)
+	}
+
+	return response, nil
+}
+
+// chaptersFromVideoData builds the chapter list of a video from its page data
+func chaptersFromVideoData(videoData *VideoData) *models.ChaptersResponse {
+	response := &models.ChaptersResponse{
+		VideoID:         videoData.VideoID,
+		Title:           videoData.Title,
+		Chapters:        []models.Chapter{},
+		DurationSeconds: videoData.LengthSeconds,
+	}
+
+	var markers []chapterMarker
+	if videoData.InitialData != nil {
+		markers = videoData.InitialData.chapterMarkers()
+	}
+	if len(markers) > 0 {
+		response.Source = models.ChapterSourceMarkers
+	} else if markers = parseDescriptionChapters(videoData.Description); len(markers) > 0 {
+		response.Source = models.ChapterSourceDescription
+	}
+
+	response.Chapters = buildChapters(videoData.VideoID, markers, videoData.LengthSeconds)
+	return response
+}
+
+// parseDescriptionChapters reads a chapter list from description timestamps. Like YouTube, it
+// requires the list to start at 0:00, to have at least three entries and to be in ascending order.
+func parseDescriptionChapters(description string) []chapterMarker {
+	var runs [][]chapterMarker
+	var current []chapterMarker
+
+	for _, line := range strings.Split(description, "\n") {
+		marker, ok := parseDescriptionLine(line)
+		if !ok {
+			continue
+		}
+		if len(current) > 0 && marker.Start <= current[len(current)-1].Start {
+			runs = append(runs, current)
+			current = nil
+		}
+		current = append(current, marker)
+	}
+	runs = append(runs, current)
+
+	for _, run := range runs {
+		if len(run) >= minDescriptionChapters && run[0].Start == 0 {
+			return run
+		}
+	}
+	return nil
+}
+
+// parseDescriptionLine extracts a chapter marker from a single description line
+func parseDescriptionLine(line string) (chapterMarker, bool) {
+	var timestamp, title string
+	if matches := descriptionLeadingTimestamp.FindStringSubmatch(line); matches != nil {
+		timestamp, title = matches[1], matches[2]
+	} else if matches := descriptionTrailingTimestamp.FindStringSubmatch(line); matches != nil {
+		title, timestamp = matches[1], matches[2]
+	} else {
+		return chapterMarker{}, false
+	}
+
+	start, err := models.ParseTimeOffset(timestamp)
+	if err != nil {
+		return chapterMarker{}, false
+	}
+
+	return chapterMarker{Title: strings.TrimSpace(title), Start: start}, true
+}
+
+// buildChapters orders the markers and gives each chapter an end time. The last chapter ends at the
+// video duration; when the duration is unknown its end is left at zero.
+func buildChapters(videoID string, markers []chapterMarker, duration float64) []models.Chapter {
+	sorted := append([]chapterMarker(nil), markers...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	chapters := make([]models.Chapter, 0, len(sorted))
+	for _, marker := range sorted {
+		if n := len(chapters); n > 0 && chapters[n-1].Start == marker.Start {
+			continue
+		}
+		chapters = append(chapters, models.Chapter{
+			Title: marker.Title,
+			Start: marker.Start,
+			URL:   buildTimestampURL(videoID, marker.Start),
+		})
+	}
+
+	for i := range chapters {
+		if i+1 < len(chapters) {
+			chapters[i].End = chapters[i+1].Start
+		} else if duration > chapters[i].Start {
+			chapters[i].End = duration
+		}
+	}
+
+	return chapters
+}
+
+// splitTranscriptByChapter groups the transcript segments under the chapters of the video and
+// rewrites the formatted text with a heading per chapter
+func (s *Service) splitTranscriptByChapter(ctx context.Context, transcript *models.TranscriptResponse) *models.TranscriptResponse {
+	chapters, err := s.GetChapters(ctx, transcript.VideoID)
+	if err != nil {
+		s.logger.Warn("Failed to fetch chapters, returning unsplit transcript",
+			slog.String("video_id", transcript.VideoID), slog.String("error", err.Error()))
+		return transcript
+	}
+	if len(chapters.Chapters) == 0 {
+		return transcript
+	}
+
+	result := *transcript
+	result.Chapters = groupSegmentsByChapter(transcript.Transcript, chapters.Chapters)
+	if result.FormattedText != "" {
+		result.FormattedText = formatChapterText(result.Chapters)
+		result.TokenCount = estimateTokens(result.FormattedText)
+	}
+	return &result
+}
+
+// groupSegmentsByChapter assigns each segment to the chapter its start falls in. Segments before the
+// first chapter belong to it; chapters without segments are left out.
+func groupSegmentsByChapter(segments []models.TranscriptSegment, chapters []models.Chapter) []models.ChapterTranscript {
+	grouped := make([]models.ChapterTranscript, 0, len(chapters))
+	chapterIndex := 0
+	for i, segment := range segments {
+		for chapterIndex+1 < len(chapters) && segment.Start >= chapters[chapterIndex+1].Start {
+			chapterIndex++
+		}
+
+		chapter := chapters[chapterIndex]
+		if n := len(grouped); n == 0 || grouped[n-1].Title != chapter.Title || grouped[n-1].Start != chapter.Start {
+			grouped = append(grouped, models.ChapterTranscript{
+				Title:        chapter.Title,
+				Start:        chapter.Start,
+				End:          chapter.End,
+				SegmentIndex: i,
+			})
+		}
+
+		current := &grouped[len(grouped)-1]
+		if current.Text != "" {
+			current.Text += " "
+		}
+		current.Text += segment.Text
+		current.SegmentCount++
+	}
+	return grouped
+}
+
+// formatChapterText renders grouped chapters as Markdown sections
+func formatChapterText(chapters []models.ChapterTranscript) string {
+	var builder strings.Builder
+	for i, chapter := range chapters {
+		if i > 0 {
+			builder.WriteString("\n\n")
+		}
+		builder.WriteString(fmt.Sprintf("## %s [%s]\n\n", chapter.Title, formatClock(chapter.Start)))
+		builder.WriteString(chapter.Text)
+	}
+	return builder.String()
+}
+
+// formatClock renders seconds the way YouTube shows them, as M:SS or H:MM:SS
+func formatClock(seconds float64) string {
+	total := int(seconds)
+	hours := total / 3600
+	minutes := (total % 3600) / 60
+	secs := total % 60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, secs)
+	}
+	return fmt.Sprintf("%d:%02d", minutes, secs)
+}
@@ -0,0 +1,146 @@
+package youtube
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestParseDescriptionChapters(t *testing.T) {
+	tests := []struct {
+		name           string
+		description    string
+		expectedTitles []string
+		expectedStarts []float64
+	}{
+		{
+			name:           "leading timestamps",
+			description:    "Great video!\n\n0:00 Intro\n1:30 - Setup\n(12:05) Demo\n1:02:03 | Outro\n\nFollow me",
+			expectedTitles: []string{"Intro", "Setup", "Demo", "Outro"},
+			expectedStarts: []float64{0, 90, 725, 3723},
+		},
+		{
+			name:           "trailing timestamps",
+			description:    "Intro - 0:00\nMain topic (2:10)\nWrap up 5:00",
+			expectedTitles: []string{"Intro", "Main topic", "Wrap up"},
+			expectedStarts: []float64{0, 130, 300},
+		},
+		{
+			name:        "must start at zero",
+			description: "0:30 Intro\n1:30 Setup\n2:30 Demo",
+		},
+		{
+			name:        "needs three entries",
+			description: "0:00 Intro\n1:30 Setup",
+		},
+		{
+			name:           "later run ignored after a valid list",
+			description:    "0:00 Intro\n1:00 Middle\n2:00 End\n\nSee also 0:45 in part one",
+			expectedTitles: []string{"Intro", "Middle", "End"},
+			expectedStarts: []float64{0, 60, 120},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markers := parseDescriptionChapters(tt.description)
+			if len(markers) != len(tt.expectedTitles) {
+				t.Fatalf("Expected %d chapters, got %d: %+v", len(tt.expectedTitles), len(markers), markers)
+			}
+			for i, marker := range markers {
+				if marker.Title != tt.expectedTitles[i] {
+					t.Errorf("Chapter %d: expected title %q, got %q", i, tt.expectedTitles[i], marker.Title)
+				}
+				if marker.Start != tt.expectedStarts[i] {
+					t.Errorf("Chapter %d: expected start %v, got %v", i, tt.expectedStarts[i], marker.Start)
+				}
+			}
+		})
+	}
+}
+
+func TestChaptersFromVideoDataPrefersMarkers(t *testing.T) {
+	html := `<script>var ytInitialPlayerResponse = {"videoDetails":{"videoId":"abc","title":"T","shortDescription":"0:00 A\n1:00 B\n2:00 C","lengthSeconds":"300","viewCount":"1"}};</script>` +
+		`<script>var ytInitialData = {"playerOverlays":{"playerOverlayRenderer":{"decoratedPlayerBarRenderer":{"decoratedPlayerBarRenderer":{"playerBar":{"multiMarkersPlayerBarRenderer":{"markersMap":[{"key":"DESCRIPTION_CHAPTERS","value":{"chapters":[` +
+		`{"chapterRenderer":{"title":{"simpleText":"Opening {braces};"},"timeRangeStartMillis":0}},` +
+		`{"chapterRenderer":{"title":{"simpleText":"Body"},"timeRangeStartMillis":45500}}]}}]}}}}}}};</script>`
+
+	service := &Service{}
+	videoData, err := service.parseVideoData(html, "abc")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if videoData.LengthSeconds != 300 {
+		t.Errorf("Expected length 300, got %v", videoData.LengthSeconds)
+	}
+
+	response := chaptersFromVideoData(videoData)
+	if response.Source != models.ChapterSourceMarkers {
+		t.Errorf("Expected source %q, got %q", models.ChapterSourceMarkers, response.Source)
+	}
+	if len(response.Chapters) != 2 {
+		t.Fatalf("Expected 2 chapters, got %d", len(response.Chapters))
+	}
+	if response.Chapters[0].Title != "Opening {braces};" {
+		t.Errorf("Unexpected first title %q", response.Chapters[0].Title)
+	}
+	if response.Chapters[0].End != 45.5 || response.Chapters[1].End != 300 {
+		t.Errorf("Unexpected chapter ends: %+v", response.Chapters)
+	}
+	if response.Chapters[1].URL != "https://youtu.be/abc?t=45" {
+		t.Errorf("Unexpected chapter URL %q", response.Chapters[1].URL)
+	}
+}
+
+func TestChaptersFromVideoDataFallsBackToDescription(t *testing.T) {
+	videoData := &VideoData{
+		VideoID:     "abc",
+		Description: "0:00 A\n1:00 B\n2:00 C",
+	}
+
+	response := chaptersFromVideoData(videoData)
+	if response.Source != models.ChapterSourceDescription {
+		t.Errorf("Expected source %q, got %q", models.ChapterSourceDescription, response.Source)
+	}
+	if len(response.Chapters) != 3 {
+		t.Fatalf("Expected 3 chapters, got %d", len(response.Chapters))
+	}
+	if response.Chapters[2].End != 0 {
+		t.Errorf("Expected unknown end for last chapter, got %v", response.Chapters[2].End)
+	}
+}
+
+func TestGroupSegmentsByChapter(t *testing.T) {
+	segments := []models.TranscriptSegment{
+		{Text: "hello", Start: 0, Duration: 5},
+		{Text: "welcome", Start: 5, Duration: 5},
+		{Text: "first point", Start: 61, Duration: 5},
+		{Text: "goodbye", Start: 200, Duration: 5},
+	}
+	chapters := []models.Chapter{
+		{Title: "Intro", Start: 0, End: 60},
+		{Title: "Point", Start: 60, End: 120},
+		{Title: "Skipped", Start: 120, End: 180},
+		{Title: "Outro", Start: 180, End: 240},
+	}
+
+	grouped := groupSegmentsByChapter(segments, chapters)
+	if len(grouped) != 3 {
+		t.Fatalf("Expected 3 groups, got %d: %+v", len(grouped), grouped)
+	}
+	if grouped[0].Text != "hello welcome" || grouped[0].SegmentCount != 2 {
+		t.Errorf("Unexpected first group: %+v", grouped[0])
+	}
+	if grouped[2].Title != "Outro" || grouped[2].SegmentIndex != 3 {
+		t.Errorf("Unexpected last group: %+v", grouped[2])
+	}
+
+	text := formatChapterText(grouped)
+	if !strings.HasPrefix(text, "## Intro [0:00]\n\nhello welcome") {
+		t.Errorf("Unexpected chapter text: %q", text)
+	}
+	if !strings.Contains(text, "## Outro [3:00]") {
+		t.Errorf("Expected outro heading in %q", text)
+	}
+}
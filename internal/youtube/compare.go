@@ -0,0 +1,387 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// maxAlignmentEdits bounds the edit distance the word alignment searches for. Transcripts further
+// apart than this are compared as one replacement between their common beginning and end.
+const maxAlignmentEdits = 2000
+
+// transcriptWord is a word of a transcript with its comparison key and estimated start time
+type transcriptWord struct {
+	text  string
+	key   int
+	start float64
+}
+
+// wordMatch pairs the index of a reference word with the index of the equal candidate word
+type wordMatch struct {
+	reference int
+	candidate int
+}
+
+// CompareTranscripts compares two caption tracks word by word. The reference is treated as ground
+// truth, so the word error rate estimates how far the candidate, typically an automatic track, is
+// from a manual one.
+func (s *Service) CompareTranscripts(ctx context.Context, reference, candidate models.TranscriptReference, maxDifferences int) (*models.TranscriptComparisonResponse, error) {
+	referenceTranscript, err := s.fetchReferencedTranscript(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+	candidateTranscript, err := s.fetchReferencedTranscript(ctx, candidate)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxDifferences <= 0 {
+		maxDifferences = models.DefaultMaxDifferences
+	}
+
+	keys := make(map[string]int)
+	referenceWords := transcriptWords(referenceTranscript.Transcript, keys)
+	candidateWords := transcriptWords(candidateTranscript.Transcript, keys)
+
+	matches, exact := alignWords(wordKeys(referenceWords), wordKeys(candidateWords), maxAlignmentEdits)
+
+	response := &models.TranscriptComparisonResponse{
+		Reference:    comparedTranscript(referenceTranscript, len(referenceWords)),
+		Candidate:    comparedTranscript(candidateTranscript, len(candidateWords)),
+		MatchedWords: len(matches),
+		Differences:  []models.TranscriptDifference{},
+		Approximate:  !exact,
+	}
+
+	// Every gap between two matched words is one difference
+	i, j := 0, 0
+	for _, match := range append(matches, wordMatch{len(referenceWords), len(candidateWords)}) {
+		deleted, inserted := match.reference-i, match.candidate-j
+		if deleted > 0 || inserted > 0 {
+			substituted := min(deleted, inserted)
+			response.Substitutions += substituted
+			response.Deletions += deleted - substituted
+			response.Insertions += inserted - substituted
+
+			response.DifferenceCount++
+			if len(response.Differences) < maxDifferences {
+				response.Differences = append(response.Differences, models.TranscriptDifference{
+					Operation:      diffOperation(deleted, inserted),
+					ReferenceText:  joinWords(referenceWords[i:match.reference]),
+					CandidateText:  joinWords(candidateWords[j:match.candidate]),
+					ReferenceStart: wordStart(referenceWords, i),
+					CandidateStart: wordStart(candidateWords, j),
+				})
+			}
+		}
+		i, j = match.reference+1, match.candidate+1
+	}
+	response.Truncated = response.DifferenceCount > len(response.Differences)
+
+	response.Similarity = 1
+	if total := len(referenceWords) + len(candidateWords); total > 0 {
+		response.Similarity = roundRatio(float64(2*len(matches)) / float64(total))
+	}
+	edits := response.Substitutions + response.Deletions + response.Insertions
+	if len(referenceWords) > 0 {
+		response.WordErrorRate = roundRatio(float64(edits) / float64(len(referenceWords)))
+	} else if edits > 0 {
+		response.WordErrorRate = 1
+	}
+
+	return response, nil
+}
+
+// fetchReferencedTranscript fetches the caption track a reference points at. Without a language the
+// configured default languages are used; without a track type manual and automatic tracks both qualify.
+func (s *Service) fetchReferencedTranscript(ctx context.Context, reference models.TranscriptReference) (*models.TranscriptResponse, error) {
+	videoID, err := s.extractVideoID(reference.VideoIdentifier)
+	if err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeInvalidVideoID,
+			Message: fmt.Sprintf("Invalid video identifier: %s", err.Error()),
+			VideoID: reference.VideoIdentifier,
+		}
+	}
+
+	cacheKey := fmt.Sprintf("%s%s:%s:track=%s", models.CacheKeyPrefixTranscript, videoID, reference.Language, reference.TrackType)
+	if cached, found := s.cache.Get(ctx, cacheKey); found {
+		if transcript, ok := cached.(*models.TranscriptResponse); ok {
+			return transcript, nil
+		}
+	}
+
+	if waitErr := s.waitForRateLimit(ctx); waitErr != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeRateLimitExceeded,
+			Message: fmt.Sprintf("Rate limit exceeded: %s", waitErr.Error()),
+			VideoID: videoID,
+		}
+	}
+
+	videoData, err := s.fetchVideoData(ctx, videoID)
+	if err != nil {
+		s.recordRateLimitFailure(err)
+		return nil, err
+	}
+
+	tracks := filterTracksByType(videoData.CaptionTracks, reference.TrackType)
+	if len(tracks) == 0 {
+		message := "No captions found"
+		if reference.TrackType != "" {
+			message = fmt.Sprintf("No %s captions found", reference.TrackType)
+		}
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeNoTranscriptFound,
+			Message: message,
+			VideoID: videoID,
+		}
+	}
+
+	var track *CaptionTrack
+	if reference.Language != "" {
+		track = findTrackByLanguage(tracks, reference.Language)
+	} else {
+		track = s.selectBestTrack(tracks, s.config.DefaultLanguages)
+	}
+	if track == nil {
+		return nil, &models.TranscriptError{
+			Type:        models.ErrorTypeLanguageNotAvailable,
+			Message:     fmt.Sprintf("No captions available in %q", reference.Language),
+			VideoID:     videoID,
+			Suggestions: s.getAvailableLanguageCodes(tracks),
+		}
+	}
+
+	segments, err := s.fetchTranscriptFromTrack(ctx, track, false)
+	if err != nil {
+		s.recordRateLimitFailure(err)
+		return nil, err
+	}
+
+	response := s.buildTranscriptResponse(videoData, track, segments, false)
+	s.addToLibrary(response)
+	if err := s.cache.Set(ctx, cacheKey, response, s.config.RequestTimeout); err != nil {
+		s.logger.Warn("Failed to cache transcript response", "error", err)
+	}
+	s.recordRateLimitSuccess()
+
+	return response, nil
+}
+
+// filterTracksByType keeps the manual or automatic tracks, or all tracks when trackType is empty
+func filterTracksByType(tracks []CaptionTrack, trackType string) []CaptionTrack {
+	if trackType == "" {
+		return tracks
+	}
+	filtered := make([]CaptionTrack, 0, len(tracks))
+	for _, track := range tracks {
+		if (track.Kind == "asr") == (trackType == models.TranscriptTypeAuto) {
+			filtered = append(filtered, track)
+		}
+	}
+	return filtered
+}
+
+// comparedTranscript describes a transcript taking part in a comparison
+func comparedTranscript(transcript *models.TranscriptResponse, wordCount int) models.ComparedTranscript {
+	trackType := models.TranscriptTypeManual
+	if transcript.TranscriptType == models.TranscriptTypeAuto {
+		trackType = models.TranscriptTypeAuto
+	}
+	return models.ComparedTranscript{
+		VideoID:   transcript.VideoID,
+		Title:     transcript.Title,
+		Language:  transcript.Language,
+		TrackType: trackType,
+		WordCount: wordCount,
+	}
+}
+
+// transcriptWords splits segments into words, spreading each segment's words evenly over its
+// duration. Words compare equal regardless of case and surrounding punctuation; keys interns the
+// normalized words so both transcripts share the same numbering.
+func transcriptWords(segments []models.TranscriptSegment, keys map[string]int) []transcriptWord {
+	var words []transcriptWord
+	for _, segment := range segments {
+		fields := strings.Fields(segment.Text)
+		duration := segmentEnd(segment) - segment.Start
+		for i, field := range fields {
+			normalized := normalizeWord(field)
+			if normalized == "" {
+				continue
+			}
+			key, ok := keys[normalized]
+			if !ok {
+				key = len(keys)
+				keys[normalized] = key
+			}
+			words = append(words, transcriptWord{
+				text:  field,
+				key:   key,
+				start: segment.Start + duration*float64(i)/float64(len(fields)),
+			})
+		}
+	}
+	return words
+}
+
+// normalizeWord lower-cases a word and strips the punctuation around it
+func normalizeWord(word string) string {
+	return strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}))
+}
+
+// wordKeys returns the comparison keys of words
+func wordKeys(words []transcriptWord) []int {
+	keys := make([]int, len(words))
+	for i, word := range words {
+		keys[i] = word.key
+	}
+	return keys
+}
+
+// joinWords joins the original text of words
+func joinWords(words []transcriptWord) string {
+	texts := make([]string, len(words))
+	for i, word := range words {
+		texts[i] = word.text
+	}
+	return strings.Join(texts, " ")
+}
+
+// wordStart returns the start of the word at index, or of the last word when index is past the end
+func wordStart(words []transcriptWord, index int) float64 {
+	if len(words) == 0 {
+		return 0
+	}
+	return words[min(index, len(words)-1)].start
+}
+
+// diffOperation names a difference by which sides have words
+func diffOperation(deleted, inserted int) string {
+	switch {
+	case deleted == 0:
+		return models.DiffOperationInsert
+	case inserted == 0:
+		return models.DiffOperationDelete
+	default:
+		return models.DiffOperationReplace
+	}
+}
+
+// roundRatio rounds a ratio to four decimal places
+func roundRatio(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
+
+// alignWords returns the pairs of equal words in a longest common subsequence of a and b. The second
+// result is false when the sequences differ by more than maxEdits words; only their common prefix
+// and suffix are matched then.
+func alignWords(a, b []int, maxEdits int) ([]wordMatch, bool) {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	matches := make([]wordMatch, 0, prefix+suffix)
+	for i := 0; i < prefix; i++ {
+		matches = append(matches, wordMatch{i, i})
+	}
+
+	middle, exact := myersMatches(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix], maxEdits)
+	for _, match := range middle {
+		matches = append(matches, wordMatch{match.reference + prefix, match.candidate + prefix})
+	}
+
+	for i := suffix; i > 0; i-- {
+		matches = append(matches, wordMatch{len(a) - i, len(b) - i})
+	}
+	return matches, exact
+}
+
+// myersMatches finds the equal pairs of a shortest edit script between a and b with Myers' O(ND)
+// algorithm, giving up once the edit distance exceeds maxEdits
+func myersMatches(a, b []int, maxEdits int) ([]wordMatch, bool) {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return nil, true
+	}
+
+	limit := min(n+m, maxEdits)
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	// trace[d] holds the furthest x reached on each diagonal k in [-d, d] after d edits, at k+d
+	trace := make([][]int, 0, limit+1)
+
+	for d := 0; d <= limit; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+				return backtrackMatches(trace, n, m), true
+			}
+		}
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+	}
+
+	return nil, false
+}
+
+// backtrackMatches walks a Myers trace back from (n, m) and collects the diagonal moves in order
+func backtrackMatches(trace [][]int, n, m int) []wordMatch {
+	var matches []wordMatch
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		previous := trace[d-1]
+		k := x - y
+
+		var previousK int
+		if k == -d || (k != d && previous[k-1+d-1] < previous[k+1+d-1]) {
+			previousK = k + 1
+		} else {
+			previousK = k - 1
+		}
+		previousX := previous[previousK+d-1]
+		previousY := previousX - previousK
+
+		for x > previousX && y > previousY {
+			x--
+			y--
+			matches = append(matches, wordMatch{x, y})
+		}
+		x, y = previousX, previousY
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		matches = append(matches, wordMatch{x, y})
+	}
+
+	for i, j := 0, len(matches)-1; i < j; i, j = i+1, j-1 {
+		matches[i], matches[j] = matches[j], matches[i]
+	}
+	return matches
+}
@@ -0,0 +1,101 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestAlignWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        []int
+		b        []int
+		maxEdits int
+		expected []wordMatch
+		exact    bool
+	}{
+		{"identical", []int{1, 2, 3}, []int{1, 2, 3}, 10, []wordMatch{{0, 0}, {1, 1}, {2, 2}}, true},
+		{"substitution", []int{1, 2, 3}, []int{1, 4, 3}, 10, []wordMatch{{0, 0}, {2, 2}}, true},
+		{"insertion and deletion", []int{1, 2, 3, 4}, []int{2, 3, 5, 4}, 10, []wordMatch{{1, 0}, {2, 1}, {3, 3}}, true},
+		{"empty candidate", []int{1, 2}, nil, 10, []wordMatch{}, true},
+		{"too different", []int{1, 2, 3, 4, 9}, []int{1, 5, 6, 7, 9}, 2, []wordMatch{{0, 0}, {4, 4}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, exact := alignWords(tt.a, tt.b, tt.maxEdits)
+			if exact != tt.exact {
+				t.Errorf("Expected exact %v, got %v", tt.exact, exact)
+			}
+			if len(matches) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, matches)
+			}
+			for i := range matches {
+				if matches[i] != tt.expected[i] {
+					t.Errorf("Expected %v, got %v", tt.expected, matches)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestCompareTranscripts(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/watch", func(w http.ResponseWriter, r *http.Request) {
+		captionURL := "http://" + r.Host + "/api/timedtext"
+		fmt.Fprintf(w, `<script>var ytInitialPlayerResponse = {"videoDetails":{"videoId":"dQw4w9WgXcQ","title":"Demo"},"captions":{"playerCaptionsTracklistRenderer":{`+
+			`"captionTracks":[{"baseUrl":"%[1]s?kind=asr","languageCode":"en","kind":"asr"},{"baseUrl":"%[1]s","languageCode":"en"}]}}};</script>`, captionURL)
+	})
+	mux.HandleFunc("/api/timedtext", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("kind") == "asr" {
+			_, _ = fmt.Fprint(w, `<transcript><text start="0" dur="2">the quick brown</text><text start="2" dur="2">fox jumped over</text><text start="4" dur="2">lazy dogs today</text></transcript>`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `<transcript><text start="0" dur="2">The quick brown</text><text start="2" dur="2">fox jumps over</text><text start="4" dur="2">the lazy dogs.</text></transcript>`)
+	})
+
+	service := newTestService(t, mux)
+
+	response, err := service.CompareTranscripts(context.Background(),
+		models.TranscriptReference{VideoIdentifier: "dQw4w9WgXcQ", TrackType: models.TranscriptTypeManual},
+		models.TranscriptReference{VideoIdentifier: "dQw4w9WgXcQ", Language: "en", TrackType: models.TranscriptTypeAuto},
+		1,
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if response.Reference.TrackType != models.TranscriptTypeManual || response.Candidate.TrackType != models.TranscriptTypeAuto {
+		t.Errorf("Unexpected track types %s and %s", response.Reference.TrackType, response.Candidate.TrackType)
+	}
+	if response.MatchedWords != 7 || response.Substitutions != 1 || response.Deletions != 1 || response.Insertions != 1 {
+		t.Errorf("Unexpected counts: %+v", response)
+	}
+	if response.WordErrorRate != 0.3333 || response.Similarity != 0.7778 {
+		t.Errorf("Unexpected scores: WER %v, similarity %v", response.WordErrorRate, response.Similarity)
+	}
+	if response.DifferenceCount != 3 || !response.Truncated || len(response.Differences) != 1 {
+		t.Fatalf("Expected the differences to be truncated to one, got %+v", response.Differences)
+	}
+	first := response.Differences[0]
+	if first.Operation != models.DiffOperationReplace || first.ReferenceText != "jumps" || first.CandidateText != "jumped" {
+		t.Errorf("Unexpected difference: %+v", first)
+	}
+	if first.ReferenceStart < 2.6 || first.ReferenceStart > 2.7 {
+		t.Errorf("Expected the difference to start about 2.67s in, got %v", first.ReferenceStart)
+	}
+
+	_, err = service.CompareTranscripts(context.Background(),
+		models.TranscriptReference{VideoIdentifier: "dQw4w9WgXcQ", Language: "de"},
+		models.TranscriptReference{VideoIdentifier: "dQw4w9WgXcQ"},
+		0,
+	)
+	if transcriptErr, ok := err.(*models.TranscriptError); !ok || transcriptErr.Type != models.ErrorTypeLanguageNotAvailable {
+		t.Errorf("Expected language not available error, got %v", err)
+	}
+}
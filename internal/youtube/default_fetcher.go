@@ -111,6 +111,31 @@ func (s *EnhancedService) GetTranscript(ctx context.Context, videoIdentifier str
 	return response, nil
 }
 
+// GetTranscriptWithOptions overrides the original method so options apply to the composite fetcher result.
+// Word timings only come from the service's own fetch; the fallback fetchers are used without them.
+func (s *EnhancedService) GetTranscriptWithOptions(ctx context.Context, videoIdentifier string, languages []string, preserveFormatting bool, opts models.TranscriptOptions) (*models.TranscriptResponse, error) {
+	var transcript *models.TranscriptResponse
+	if opts.IncludeWordTimings {
+		timed, err := s.Service.getTranscript(ctx, videoIdentifier, languages, preserveFormatting, true)
+		if err != nil {
+			s.logger.Debug("Failed to fetch word timings, falling back to the composite fetcher", "error", err)
+		}
+		transcript = timed
+	}
+	if transcript == nil {
+		var err error
+		if transcript, err = s.GetTranscript(ctx, videoIdentifier, languages, preserveFormatting); err != nil {
+			return nil, err
+		}
+	}
+
+	result := s.applyTranscriptOptions(transcript, opts, opts.MaxTokens)
+	if opts.SplitByChapter {
+		result = s.splitTranscriptByChapter(ctx, result)
+	}
+	return result, nil
+}
+
 // ListAvailableLanguages overrides to use composite fetcher
 func (s *EnhancedService) ListAvailableLanguages(ctx context.Context, videoIdentifier string) (*models.AvailableLanguagesResponse, error) {
 	videoID, err := s.extractVideoID(videoIdentifier)
@@ -0,0 +1,103 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/youtube-transcript-mcp/internal/config"
+)
+
+// openAIAPIURL is the embeddings API used when only an embedding API key is configured
+const openAIAPIURL = "https://api.openai.com"
+
+// defaultEmbeddingModel is used when no embedding model is configured
+const defaultEmbeddingModel = "text-embedding-3-small"
+
+// Embedder turns text into vectors whose cosine similarity reflects similarity in meaning. It is
+// used by semantic search over the transcript library.
+type Embedder interface {
+	// Model identifies the embedding model; vectors of different models are never compared
+	Model() string
+	// Embed returns one vector per text, in order
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// NewEmbedder creates the embedder configured in cfg, or nil when none is configured
+func NewEmbedder(cfg config.YouTubeConfig) Embedder {
+	if cfg.EmbeddingURL == "" && cfg.EmbeddingAPIKey == "" {
+		return nil
+	}
+
+	baseURL := cfg.EmbeddingURL
+	if baseURL == "" {
+		baseURL = openAIAPIURL
+	}
+	model := cfg.EmbeddingModel
+	if model == "" {
+		model = defaultEmbeddingModel
+	}
+
+	return &OpenAIEmbedder{
+		baseURL: strings.TrimSuffix(strings.TrimRight(baseURL, "/"), "/v1"),
+		apiKey:  cfg.EmbeddingAPIKey,
+		model:   model,
+		client:  &http.Client{Timeout: cfg.RequestTimeout},
+	}
+}
+
+// OpenAIEmbedder calls an OpenAI-compatible /v1/embeddings endpoint, as served by OpenAI and by
+// local servers such as Ollama, llama.cpp or text-embeddings-inference
+type OpenAIEmbedder struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+	model   string
+}
+
+// Model implements Embedder
+func (e *OpenAIEmbedder) Model() string {
+	return e.model
+}
+
+// Embed implements Embedder
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	request := map[string]any{
+		"model": e.model,
+		"input": texts,
+	}
+
+	var response struct {
+		Data []struct {
+			Embedding []float32 `json:"embedding"`
+			Index     int       `json:"index"`
+		} `json:"data"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	var headers map[string]string
+	if e.apiKey != "" {
+		headers = map[string]string{"Authorization": "Bearer " + e.apiKey}
+	}
+	if err := postJSON(ctx, e.client, e.baseURL+"/v1/embeddings", request, headers, &response); err != nil {
+		return nil, err
+	}
+	if response.Error != nil {
+		return nil, fmt.Errorf("embeddings: %s", response.Error.Message)
+	}
+	if len(response.Data) != len(texts) {
+		return nil, fmt.Errorf("embeddings: expected %d vectors, got %d", len(texts), len(response.Data))
+	}
+
+	// Results carry the index of their input and need not arrive in order
+	vectors := make([][]float32, len(texts))
+	for _, item := range response.Data {
+		if item.Index < 0 || item.Index >= len(texts) || vectors[item.Index] != nil {
+			return nil, fmt.Errorf("embeddings: unexpected index %d", item.Index)
+		}
+		vectors[item.Index] = item.Embedding
+	}
+	return vectors, nil
+}
@@ -0,0 +1,96 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/youtube-transcript-mcp/internal/config"
+)
+
+// embeddingConcepts maps words to the dimension of the concept they express, so that paraphrases
+// get similar vectors from the stand-in embedder
+var embeddingConcepts = map[string]int{
+	"car": 0, "cars": 0, "automobile": 0, "vehicle": 0, "vehicles": 0, "driving": 0,
+	"cook": 1, "cooking": 1, "recipe": 1, "kitchen": 1, "bake": 1, "bread": 1,
+	"money": 2, "budget": 2, "savings": 2, "finance": 2,
+}
+
+// embeddingStandIn is a local OpenAI-compatible embeddings server that counts concept words. It
+// returns results in reverse order, as the API is allowed to, and records the inputs of each call.
+func embeddingStandIn(t *testing.T, calls *[][]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			http.NotFound(w, r)
+			return
+		}
+		var request struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		if request.Model != "concepts" {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]string{"message": "model " + request.Model + " not found"}})
+			return
+		}
+		*calls = append(*calls, request.Input)
+
+		data := make([]map[string]any, 0, len(request.Input))
+		for i := len(request.Input) - 1; i >= 0; i-- {
+			vector := []float32{0, 0, 0, 0.1}
+			for _, word := range strings.Fields(strings.ToLower(request.Input[i])) {
+				if dimension, ok := embeddingConcepts[strings.Trim(word, ".,?!")]; ok {
+					vector[dimension]++
+				}
+			}
+			data = append(data, map[string]any{"index": i, "embedding": vector})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNewEmbedder(t *testing.T) {
+	if embedder := NewEmbedder(config.YouTubeConfig{}); embedder != nil {
+		t.Errorf("Expected no embedder without a URL or key, got %+v", embedder)
+	}
+
+	embedder := NewEmbedder(config.YouTubeConfig{EmbeddingAPIKey: "key"})
+	openAI, ok := embedder.(*OpenAIEmbedder)
+	if !ok || openAI.baseURL != openAIAPIURL || openAI.Model() != defaultEmbeddingModel {
+		t.Errorf("Expected an OpenAI embedder with the default model, got %+v", embedder)
+	}
+
+	embedder = NewEmbedder(config.YouTubeConfig{EmbeddingURL: "http://localhost:11434/v1/", EmbeddingModel: "nomic-embed-text"})
+	if local := embedder.(*OpenAIEmbedder); local.baseURL != "http://localhost:11434" || local.Model() != "nomic-embed-text" {
+		t.Errorf("Unexpected local embedder %+v", local)
+	}
+}
+
+func TestOpenAIEmbedder(t *testing.T) {
+	var calls [][]string
+	server := embeddingStandIn(t, &calls)
+
+	embedder := NewEmbedder(config.YouTubeConfig{EmbeddingURL: server.URL, EmbeddingModel: "concepts", RequestTimeout: 5 * time.Second})
+	vectors, err := embedder.Embed(context.Background(), []string{"a car", "bread and money"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(vectors) != 2 || vectors[0][0] != 1 || vectors[1][1] != 1 || vectors[1][2] != 1 {
+		t.Errorf("Expected vectors in input order, got %v", vectors)
+	}
+
+	embedder = NewEmbedder(config.YouTubeConfig{EmbeddingURL: server.URL, EmbeddingModel: "missing"})
+	if _, err := embedder.Embed(context.Background(), []string{"a car"}); err == nil || !strings.Contains(err.Error(), "model missing not found") {
+		t.Errorf("Expected the API's error message, got %v", err)
+	}
+}
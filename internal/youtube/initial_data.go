@@ -0,0 +1,257 @@
+package youtube
+
+import (
+	"encoding/json"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// InitialData holds the parts of a watch page's ytInitialData that the service reads
+type InitialData struct {
+	Contents struct {
+		TwoColumnWatchNextResults struct {
+			ConversationBar struct {
+				LiveChatRenderer *LiveChatRenderer `json:"liveChatRenderer,omitempty"`
+			} `json:"conversationBar"`
+		} `json:"twoColumnWatchNextResults"`
+	} `json:"contents"`
+	PlayerOverlays struct {
+		PlayerOverlayRenderer struct {
+			DecoratedPlayerBarRenderer struct {
+				DecoratedPlayerBarRenderer struct {
+					PlayerBar struct {
+						MultiMarkersPlayerBarRenderer struct {
+							MarkersMap []MarkersMapEntry `json:"markersMap"`
+						} `json:"multiMarkersPlayerBarRenderer"`
+					} `json:"playerBar"`
+				} `json:"decoratedPlayerBarRenderer"`
+			} `json:"decoratedPlayerBarRenderer"`
+		} `json:"playerOverlayRenderer"`
+	} `json:"playerOverlays"`
+	EngagementPanels []EngagementPanel `json:"engagementPanels"`
+	FrameworkUpdates struct {
+		EntityBatchUpdate struct {
+			Mutations []struct {
+				Payload struct {
+					LikeCountEntity *struct {
+						LikeCountIfIndifferentNumber string `json:"likeCountIfIndifferentNumber"`
+					} `json:"likeCountEntity,omitempty"`
+				} `json:"payload"`
+			} `json:"mutations"`
+		} `json:"entityBatchUpdate"`
+	} `json:"frameworkUpdates"`
+}
+
+// MarkersMapEntry is a named set of player bar markers, such as DESCRIPTION_CHAPTERS
+type MarkersMapEntry struct {
+	Key   string `json:"key"`
+	Value struct {
+		Chapters []struct {
+			ChapterRenderer struct {
+				Title                NameText `json:"title"`
+				TimeRangeStartMillis int64    `json:"timeRangeStartMillis"`
+			} `json:"chapterRenderer"`
+		} `json:"chapters"`
+	} `json:"value"`
+}
+
+// EngagementPanel is a side panel of the watch page, such as the chapter list
+type EngagementPanel struct {
+	EngagementPanelSectionListRenderer struct {
+		PanelIdentifier string `json:"panelIdentifier"`
+		Header          struct {
+			EngagementPanelTitleHeaderRenderer struct {
+				ContextualInfo struct {
+					Runs []struct {
+						Text string `json:"text"`
+					} `json:"runs"`
+				} `json:"contextualInfo"`
+			} `json:"engagementPanelTitleHeaderRenderer"`
+		} `json:"header"`
+		Content struct {
+			MacroMarkersListRenderer struct {
+				Contents []struct {
+					MacroMarkersListItemRenderer struct {
+						Title NameText `json:"title"`
+						OnTap struct {
+							WatchEndpoint struct {
+								StartTimeSeconds float64 `json:"startTimeSeconds"`
+							} `json:"watchEndpoint"`
+						} `json:"onTap"`
+					} `json:"macroMarkersListItemRenderer"`
+				} `json:"contents"`
+			} `json:"macroMarkersListRenderer"`
+		} `json:"content"`
+	} `json:"engagementPanelSectionListRenderer"`
+}
+
+// LiveChatRenderer is the chat panel of a live stream's watch page
+type LiveChatRenderer struct {
+	IsReplay      bool                 `json:"isReplay"`
+	Continuations []ReloadContinuation `json:"continuations"`
+	Header        struct {
+		LiveChatHeaderRenderer struct {
+			ViewSelector struct {
+				SortFilterSubMenuRenderer struct {
+					SubMenuItems []struct {
+						Title        string             `json:"title"`
+						Selected     bool               `json:"selected"`
+						Continuation ReloadContinuation `json:"continuation"`
+					} `json:"subMenuItems"`
+				} `json:"sortFilterSubMenuRenderer"`
+			} `json:"viewSelector"`
+		} `json:"liveChatHeaderRenderer"`
+	} `json:"header"`
+}
+
+// ReloadContinuation carries the token that loads a chat view from its start
+type ReloadContinuation struct {
+	ReloadContinuationData struct {
+		Continuation string `json:"continuation"`
+	} `json:"reloadContinuationData"`
+}
+
+// chatReplayContinuation returns the token of the full chat replay. The panel opens on "Top chat",
+// which hides messages YouTube deems low quality, so the unfiltered view is preferred when offered.
+func (d *InitialData) chatReplayContinuation() string {
+	renderer := d.Contents.TwoColumnWatchNextResults.ConversationBar.LiveChatRenderer
+	if renderer == nil {
+		return ""
+	}
+
+	items := renderer.Header.LiveChatHeaderRenderer.ViewSelector.SortFilterSubMenuRenderer.SubMenuItems
+	for _, item := range items {
+		if strings.HasPrefix(item.Title, "Live chat") {
+			if token := item.Continuation.ReloadContinuationData.Continuation; token != "" {
+				return token
+			}
+		}
+	}
+
+	for _, continuation := range renderer.Continuations {
+		if token := continuation.ReloadContinuationData.Continuation; token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// chapterMarker is a chapter start read from page data, before end times are known
+type chapterMarker struct {
+	Title string
+	Start float64
+}
+
+// chapterMarkers returns the official chapter markers of the video, preferring the player bar markers
+func (d *InitialData) chapterMarkers() []chapterMarker {
+	bar := d.PlayerOverlays.PlayerOverlayRenderer.DecoratedPlayerBarRenderer.DecoratedPlayerBarRenderer.PlayerBar
+	for _, entry := range bar.MultiMarkersPlayerBarRenderer.MarkersMap {
+		markers := make([]chapterMarker, 0, len(entry.Value.Chapters))
+		for _, chapter := range entry.Value.Chapters {
+			markers = append(markers, chapterMarker{
+				Title: strings.TrimSpace(chapter.ChapterRenderer.Title.SimpleText),
+				Start: float64(chapter.ChapterRenderer.TimeRangeStartMillis) / 1000.0,
+			})
+		}
+		if len(markers) > 0 {
+			return markers
+		}
+	}
+
+	for _, panel := range d.EngagementPanels {
+		items := panel.EngagementPanelSectionListRenderer.Content.MacroMarkersListRenderer.Contents
+		markers := make([]chapterMarker, 0, len(items))
+		for _, item := range items {
+			renderer := item.MacroMarkersListItemRenderer
+			if renderer.Title.SimpleText == "" {
+				continue
+			}
+			markers = append(markers, chapterMarker{
+				Title: strings.TrimSpace(renderer.Title.SimpleText),
+				Start: renderer.OnTap.WatchEndpoint.StartTimeSeconds,
+			})
+		}
+		if len(markers) > 0 {
+			return markers
+		}
+	}
+
+	return nil
+}
+
+// commentsPanelIdentifier identifies the engagement panel holding the comment section
+const commentsPanelIdentifier = "engagement-panel-comments-section"
+
+// likeCount returns the like count published in the page's entity updates
+func (d *InitialData) likeCount() (int64, bool) {
+	for _, mutation := range d.FrameworkUpdates.EntityBatchUpdate.Mutations {
+		if entity := mutation.Payload.LikeCountEntity; entity != nil {
+			return parseCompactCount(entity.LikeCountIfIndifferentNumber)
+		}
+	}
+	return 0, false
+}
+
+// commentCount returns the comment count shown in the header of the comments panel
+func (d *InitialData) commentCount() (int64, bool) {
+	for _, panel := range d.EngagementPanels {
+		renderer := panel.EngagementPanelSectionListRenderer
+		if renderer.PanelIdentifier != commentsPanelIdentifier {
+			continue
+		}
+		var text strings.Builder
+		for _, run := range renderer.Header.EngagementPanelTitleHeaderRenderer.ContextualInfo.Runs {
+			text.WriteString(run.Text)
+		}
+		return parseCompactCount(text.String())
+	}
+	return 0, false
+}
+
+// parseCompactCount parses counts as YouTube displays them, such as "18,234", "1.2K" or "3M"
+func parseCompactCount(text string) (int64, bool) {
+	text = strings.ReplaceAll(strings.TrimSpace(text), ",", "")
+	if text == "" {
+		return 0, false
+	}
+
+	multiplier := 1.0
+	switch strings.ToUpper(text[len(text)-1:]) {
+	case "K":
+		multiplier = 1e3
+	case "M":
+		multiplier = 1e6
+	case "B":
+		multiplier = 1e9
+	}
+	if multiplier != 1 {
+		text = text[:len(text)-1]
+	}
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value < 0 {
+		return 0, false
+	}
+	return int64(math.Round(value * multiplier)), true
+}
+
+// extractJSONVariable returns the JSON object assigned to a page variable such as ytInitialData.
+// It decodes exactly one JSON value, so braces inside strings cannot cut the object short.
+func extractJSONVariable(html, name string) ([]byte, bool) {
+	quoted := regexp.QuoteMeta(name)
+	pattern := regexp.MustCompile(`(?:var\s+` + quoted + `|window\["` + quoted + `"\])\s*=\s*`)
+
+	loc := pattern.FindStringIndex(html)
+	if loc == nil {
+		return nil, false
+	}
+
+	var raw json.RawMessage
+	if err := json.NewDecoder(strings.NewReader(html[loc[1]:])).Decode(&raw); err != nil {
+		return nil, false
+	}
+
+	return raw, true
+}
@@ -9,8 +9,26 @@ import (
 // ServiceInterface defines the interface for YouTube transcript operations
 type ServiceInterface interface {
 	GetTranscript(ctx context.Context, videoIdentifier string, languages []string, preserveFormatting bool) (*models.TranscriptResponse, error)
+	GetTranscriptWithOptions(ctx context.Context, videoIdentifier string, languages []string, preserveFormatting bool, opts models.TranscriptOptions) (*models.TranscriptResponse, error)
 	GetMultipleTranscripts(ctx context.Context, videoIdentifiers []string, languages []string, continueOnError bool) (*models.MultipleTranscriptResponse, error)
+	GetMultipleTranscriptsWithOptions(ctx context.Context, videoIdentifiers []string, languages []string, continueOnError bool, opts models.TranscriptOptions) (*models.MultipleTranscriptResponse, error)
 	ListAvailableLanguages(ctx context.Context, videoIdentifier string) (*models.AvailableLanguagesResponse, error)
 	TranslateTranscript(ctx context.Context, videoIdentifier, targetLanguage, sourceLanguage string) (*models.TranscriptResponse, error)
 	FormatTranscript(ctx context.Context, videoIdentifier, formatType string, includeTimestamps bool) (*models.TranscriptResponse, error)
+	FormatTranscriptWithOptions(ctx context.Context, videoIdentifier string, opts models.FormatOptions) (*models.TranscriptResponse, error)
+	SearchTranscript(ctx context.Context, videoIdentifier string, opts models.TranscriptSearchOptions) (*models.SearchTranscriptResponse, error)
+	GetTranscriptRange(ctx context.Context, videoIdentifier string, opts models.TranscriptRangeOptions) (*models.TranscriptRangeResponse, error)
+	GetChapters(ctx context.Context, videoIdentifier string) (*models.ChaptersResponse, error)
+	GetVideoMetadata(ctx context.Context, videoIdentifier string) (*models.VideoInfo, error)
+	GetPlaylistTranscripts(ctx context.Context, playlistIdentifier string, opts models.PlaylistOptions) (*models.PlaylistTranscriptsResponse, error)
+	ListChannelVideos(ctx context.Context, channelIdentifier string, opts models.ChannelVideosOptions) (*models.ChannelVideosResponse, error)
+	SearchVideos(ctx context.Context, opts models.VideoSearchOptions) (*models.SearchVideosResponse, error)
+	GetLiveChatReplay(ctx context.Context, videoIdentifier string, opts models.LiveChatOptions) (*models.LiveChatReplayResponse, error)
+	GetBilingualTranscript(ctx context.Context, videoIdentifier string, opts models.BilingualOptions) (*models.BilingualTranscriptResponse, error)
+	CompareTranscripts(ctx context.Context, reference, candidate models.TranscriptReference, maxDifferences int) (*models.TranscriptComparisonResponse, error)
+	AnalyzeTranscript(ctx context.Context, videoIdentifier string, opts models.AnalysisOptions) (*models.TranscriptAnalysis, error)
+	ExtractKeywords(ctx context.Context, videoIdentifiers []string, opts models.KeywordOptions) (*models.KeywordsResponse, error)
+	SearchLibrary(ctx context.Context, opts models.LibrarySearchOptions) (*models.LibrarySearchResponse, error)
+	SemanticSearch(ctx context.Context, opts models.SemanticSearchOptions) (*models.SemanticSearchResponse, error)
+	LocateQuote(ctx context.Context, videoIdentifier string, opts models.QuoteOptions) (*models.LocateQuoteResponse, error)
 }
@@ -0,0 +1,79 @@
+package youtube
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// vttTextEscaper escapes the characters WebVTT cue text reserves for markup
+var vttTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// formatAsVTTKaraoke renders segments as WebVTT cues with an inline timestamp tag before every word
+// and each word in a <c> span, the way YouTube's own karaoke captions highlight words as they are
+// said. Timestamp tags must fall strictly inside their cue and increase, so words the captions time
+// at or before the previous tag are shown with it.
+func (s *Service) formatAsVTTKaraoke(segments []models.TranscriptSegment) string {
+	var builder strings.Builder
+	builder.WriteString("WEBVTT\n\n")
+
+	for _, segment := range segments {
+		words := segmentWordTimings(segment)
+		if len(words) == 0 {
+			continue
+		}
+
+		start := segment.Start
+		end := max(segmentEnd(segment), start+0.001)
+		builder.WriteString(s.formatVTTTime(start))
+		builder.WriteString(" --> ")
+		builder.WriteString(s.formatVTTTime(end))
+		builder.WriteString("\n")
+
+		// Tags are compared as written, in whole milliseconds
+		previous, last := math.Round(start*1000), math.Round(end*1000)
+		for i, word := range words {
+			text := vttTextEscaper.Replace(word.Text)
+			if i > 0 {
+				text = " " + text
+				if tag := math.Round(word.Start * 1000); tag > previous && tag < last {
+					builder.WriteString("<" + s.formatVTTTime(tag/1000) + ">")
+					previous = tag
+				}
+			}
+			builder.WriteString("<c>" + text + "</c>")
+		}
+		builder.WriteString("\n\n")
+	}
+
+	return strings.TrimSpace(builder.String())
+}
+
+// segmentWordTimings returns the timed words of a segment: the offsets the caption source gave, or
+// times interpolated across the segment by the length of the text before each word
+func segmentWordTimings(segment models.TranscriptSegment) []models.TranscriptWord {
+	if len(segment.Words) > 0 {
+		return segment.Words
+	}
+
+	length := utf8.RuneCountInString(segment.Text)
+	duration := segmentEnd(segment) - segment.Start
+	var words []models.TranscriptWord
+	for _, loc := range spokenWordPattern.FindAllStringIndex(segment.Text, -1) {
+		offset := float64(utf8.RuneCountInString(segment.Text[:loc[0]])) / float64(length)
+		words = append(words, models.TranscriptWord{
+			Text:  segment.Text[loc[0]:loc[1]],
+			Start: segment.Start + duration*offset,
+		})
+	}
+	for i := range words {
+		next := segment.Start + duration
+		if i+1 < len(words) {
+			next = words[i+1].Start
+		}
+		words[i].Duration = next - words[i].Start
+	}
+	return words
+}
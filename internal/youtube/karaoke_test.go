@@ -0,0 +1,72 @@
+package youtube
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestFormatAsVTTKaraoke(t *testing.T) {
+	s := &Service{}
+
+	tests := []struct {
+		name     string
+		segments []models.TranscriptSegment
+		expected string
+	}{
+		{
+			name: "word offsets from the captions",
+			segments: []models.TranscriptSegment{
+				{Text: "welcome back everyone", Start: 2, Duration: 3, End: 5, Words: []models.TranscriptWord{
+					{Text: "welcome", Start: 2, Duration: 0.48}, {Text: "back", Start: 2.48, Duration: 0.48},
+					{Text: "everyone", Start: 2.96, Duration: 2.04}}},
+			},
+			expected: "WEBVTT\n\n00:00:02.000 --> 00:00:05.000\n" +
+				"<c>welcome</c><00:00:02.480><c> back</c><00:00:02.960><c> everyone</c>",
+		},
+		{
+			name: "interpolated by character length",
+			segments: []models.TranscriptSegment{
+				{Text: "ab cd", Start: 1, Duration: 1, End: 2},
+				{Text: ">> R&D <rocks>", Start: 3, Duration: 2},
+			},
+			expected: "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\n<c>ab</c><00:00:01.600><c> cd</c>\n\n" +
+				"00:00:03.000 --> 00:00:05.000\n<c>&gt;&gt;</c><00:00:03.429><c> R&amp;D</c><00:00:04.000><c> &lt;rocks&gt;</c>",
+		},
+		{
+			name: "tags outside the cue or out of order are left out",
+			segments: []models.TranscriptSegment{
+				{Text: "one two three", Start: 10, Duration: 2, End: 12, Words: []models.TranscriptWord{
+					{Text: "one", Start: 9.5}, {Text: "two", Start: 10}, {Text: "three", Start: 12.5}}},
+				{Text: "", Start: 12, Duration: 1},
+			},
+			expected: "WEBVTT\n\n00:00:10.000 --> 00:00:12.000\n<c>one</c><c> two</c><c> three</c>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.formatAsVTTKaraoke(tt.segments); got != tt.expected {
+				t.Errorf("formatAsVTTKaraoke() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSegmentWordTimings(t *testing.T) {
+	segment := models.TranscriptSegment{Text: "aa bb", Start: 0, Duration: 5}
+
+	expected := []models.TranscriptWord{
+		{Text: "aa", Start: 0, Duration: 3},
+		{Text: "bb", Start: 3, Duration: 2},
+	}
+	if got := segmentWordTimings(segment); !reflect.DeepEqual(got, expected) {
+		t.Errorf("segmentWordTimings() = %+v, want %+v", got, expected)
+	}
+
+	segment.Words = []models.TranscriptWord{{Text: "aa", Start: 0, Duration: 1}, {Text: "bb", Start: 1, Duration: 4}}
+	if got := segmentWordTimings(segment); !reflect.DeepEqual(got, segment.Words) {
+		t.Errorf("segmentWordTimings() = %+v, want the caption word timings %+v", got, segment.Words)
+	}
+}
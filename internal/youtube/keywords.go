@@ -0,0 +1,293 @@
+package youtube
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+	"github.com/youtube-transcript-mcp/internal/textseg"
+)
+
+// maxKeyphraseWords is the longest phrase, in tokens, considered as a keyphrase
+const maxKeyphraseWords = 3
+
+// chineseStopCharacters are Chinese function characters; bigrams containing one are not keywords
+var chineseStopCharacters = toSet(strings.Split("的了是在我你他她它们这那就都也和有不吗呢吧啊个说要会很到去没一着过给让把被", ""))
+
+// keywordToken is a token of a transcript with the start of the segment it was said in
+type keywordToken struct {
+	text     string
+	start    float64
+	overlaps bool // shares its first character with the token before it
+}
+
+// documentTerms holds the phrases of one transcript for TF-IDF scoring
+type documentTerms struct {
+	transcript *models.TranscriptResponse
+	counts     map[string]int
+	timestamps map[string][]float64
+	words      map[string]int // number of tokens in each phrase
+	total      int
+}
+
+// ExtractKeywords scores the phrases of a batch of videos with TF-IDF. Each video gets the phrases
+// that set it apart from the rest, and the phrases shared by several videos are returned as themes.
+// Videos without a transcript are reported in the errors and left out.
+func (s *Service) ExtractKeywords(ctx context.Context, videoIdentifiers []string, opts models.KeywordOptions) (*models.KeywordsResponse, error) {
+	batch, err := s.GetMultipleTranscripts(ctx, videoIdentifiers, opts.Languages, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Batch results arrive in completion order
+	fetched := make(map[string]*models.TranscriptResponse, len(batch.Results))
+	for _, result := range batch.Results {
+		if result.Success {
+			fetched[result.VideoID] = result.Transcript
+		}
+	}
+	transcripts := make([]*models.TranscriptResponse, 0, len(fetched))
+	for _, videoIdentifier := range videoIdentifiers {
+		if transcript, ok := fetched[videoIdentifier]; ok {
+			transcripts = append(transcripts, transcript)
+			delete(fetched, videoIdentifier)
+		}
+	}
+
+	if len(transcripts) == 0 {
+		if len(batch.Errors) > 0 {
+			return nil, &batch.Errors[0]
+		}
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeNoTranscriptFound,
+			Message: "No transcripts found for the given videos",
+		}
+	}
+
+	response := extractKeywords(transcripts, opts)
+	response.Errors = batch.Errors
+	return response, nil
+}
+
+// extractKeywords ranks the phrases of each transcript by TF-IDF and collects the shared themes
+func extractKeywords(transcripts []*models.TranscriptResponse, opts models.KeywordOptions) *models.KeywordsResponse {
+	if opts.MaxKeywords <= 0 {
+		opts.MaxKeywords = models.DefaultMaxKeywords
+	}
+	if opts.MaxThemes <= 0 {
+		opts.MaxThemes = models.DefaultMaxThemes
+	}
+
+	documents := make([]*documentTerms, len(transcripts))
+	documentFrequency := make(map[string]int)
+	for i, transcript := range transcripts {
+		documents[i] = collectPhrases(transcript)
+		for phrase := range documents[i].counts {
+			documentFrequency[phrase]++
+		}
+	}
+
+	response := &models.KeywordsResponse{
+		Videos: make([]models.VideoKeywords, 0, len(documents)),
+	}
+
+	videoCount := float64(len(documents))
+	for _, document := range documents {
+		candidates := make([]models.Keyphrase, 0, len(document.counts))
+		for phrase, count := range document.counts {
+			// A phrase said once is more likely a coincidence than a keyphrase
+			if count < 2 && document.words[phrase] > 1 {
+				continue
+			}
+			tf := float64(count) / float64(document.total)
+			idf := math.Log((1+videoCount)/(1+float64(documentFrequency[phrase]))) + 1
+			candidates = append(candidates, models.Keyphrase{
+				Phrase:     phrase,
+				Score:      math.Round(tf*idf*1e6) / 1e6,
+				Count:      count,
+				Timestamps: document.timestamps[phrase],
+			})
+		}
+		// Longer phrases win ties so that "neural networks" is kept over "neural" and "networks"
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].Score != candidates[j].Score {
+				return candidates[i].Score > candidates[j].Score
+			}
+			if words := document.words; words[candidates[i].Phrase] != words[candidates[j].Phrase] {
+				return words[candidates[i].Phrase] > words[candidates[j].Phrase]
+			}
+			return candidates[i].Phrase < candidates[j].Phrase
+		})
+
+		keyphrases := make([]models.Keyphrase, 0, opts.MaxKeywords)
+		for _, candidate := range candidates {
+			if len(keyphrases) == opts.MaxKeywords {
+				break
+			}
+			redundant := false
+			for _, selected := range keyphrases {
+				if selected.Count == candidate.Count && (containsPhrase(selected.Phrase, candidate.Phrase) || containsPhrase(candidate.Phrase, selected.Phrase)) {
+					redundant = true
+					break
+				}
+			}
+			if !redundant {
+				keyphrases = append(keyphrases, candidate)
+			}
+		}
+
+		response.Videos = append(response.Videos, models.VideoKeywords{
+			VideoID:    document.transcript.VideoID,
+			Title:      document.transcript.Title,
+			Language:   document.transcript.Language,
+			Keyphrases: keyphrases,
+		})
+	}
+
+	response.Themes = keywordThemes(documents, documentFrequency, opts.MaxThemes)
+	return response
+}
+
+// keywordThemes returns the phrases found in the most videos. With a single video every phrase
+// qualifies and the most frequent ones are returned.
+func keywordThemes(documents []*documentTerms, documentFrequency map[string]int, maxThemes int) []models.KeywordTheme {
+	minVideos := min(2, len(documents))
+	candidates := make([]models.KeywordTheme, 0)
+	phraseWords := make(map[string]int)
+	for phrase, frequency := range documentFrequency {
+		if frequency < minVideos {
+			continue
+		}
+		theme := models.KeywordTheme{Phrase: phrase, VideoCount: frequency, VideoIDs: make([]string, 0, frequency)}
+		words := 0
+		for _, document := range documents {
+			if count, ok := document.counts[phrase]; ok {
+				theme.Count += count
+				theme.VideoIDs = append(theme.VideoIDs, document.transcript.VideoID)
+				words = document.words[phrase]
+			}
+		}
+		if theme.Count < 2 && words > 1 {
+			continue
+		}
+		phraseWords[phrase] = words
+		candidates = append(candidates, theme)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].VideoCount != candidates[j].VideoCount {
+			return candidates[i].VideoCount > candidates[j].VideoCount
+		}
+		if candidates[i].Count != candidates[j].Count {
+			return candidates[i].Count > candidates[j].Count
+		}
+		if phraseWords[candidates[i].Phrase] != phraseWords[candidates[j].Phrase] {
+			return phraseWords[candidates[i].Phrase] > phraseWords[candidates[j].Phrase]
+		}
+		return candidates[i].Phrase < candidates[j].Phrase
+	})
+
+	themes := make([]models.KeywordTheme, 0, maxThemes)
+	for _, candidate := range candidates {
+		if len(themes) == maxThemes {
+			break
+		}
+		redundant := false
+		for _, selected := range themes {
+			if selected.VideoCount == candidate.VideoCount && selected.Count == candidate.Count &&
+				(containsPhrase(selected.Phrase, candidate.Phrase) || containsPhrase(candidate.Phrase, selected.Phrase)) {
+				redundant = true
+				break
+			}
+		}
+		if !redundant {
+			themes = append(themes, candidate)
+		}
+	}
+	return themes
+}
+
+// collectPhrases counts the phrases of up to maxKeyphraseWords tokens in a transcript and records
+// the segments they occur in. Stopwords break phrases.
+func collectPhrases(transcript *models.TranscriptResponse) *documentTerms {
+	document := &documentTerms{
+		transcript: transcript,
+		counts:     make(map[string]int),
+		timestamps: make(map[string][]float64),
+		words:      make(map[string]int),
+	}
+
+	chinese := baseLanguage(transcript.Language) == "zh"
+	var run []keywordToken
+	for _, token := range keywordTokens(transcript.Transcript, chinese) {
+		document.total++
+		if isStopword(token.text) || chinese && strings.ContainsFunc(token.text, func(r rune) bool { return chineseStopCharacters[string(r)] }) {
+			run = run[:0]
+			continue
+		}
+		run = append(run, token)
+		for n := 1; n <= min(len(run), maxKeyphraseWords); n++ {
+			phrase := joinKeywordTokens(run[len(run)-n:])
+			start := run[len(run)-n].start
+			document.counts[phrase]++
+			document.words[phrase] = n
+			if timestamps := document.timestamps[phrase]; len(timestamps) == 0 || timestamps[len(timestamps)-1] != start {
+				document.timestamps[phrase] = append(timestamps, start)
+			}
+		}
+	}
+
+	return document
+}
+
+// keywordTokens splits segments into tokens. Tags such as [Music] are skipped. Chinese has neither
+// spaces nor kana to mark word boundaries, so its ideograph runs are split into overlapping
+// character bigrams that phrases later stitch back together.
+func keywordTokens(segments []models.TranscriptSegment, chinese bool) []keywordToken {
+	var tokens []keywordToken
+	for _, segment := range segments {
+		for _, token := range textseg.Tokens(stripAnnotations(segment.Text)) {
+			runes := []rune(token.Text)
+			if !chinese || len(runes) <= 2 || token.Class != textseg.ClassHan {
+				tokens = append(tokens, keywordToken{text: token.Text, start: segment.Start})
+				continue
+			}
+			for i := 0; i+2 <= len(runes); i++ {
+				tokens = append(tokens, keywordToken{text: string(runes[i : i+2]), start: segment.Start, overlaps: i > 0})
+			}
+		}
+	}
+	return tokens
+}
+
+// joinKeywordTokens joins consecutive tokens into a phrase, merging the shared character of
+// overlapping bigrams
+func joinKeywordTokens(tokens []keywordToken) string {
+	texts := make([]string, 0, len(tokens))
+	for i, token := range tokens {
+		if i > 0 && token.overlaps {
+			_, size := utf8.DecodeRuneInString(token.text)
+			texts[len(texts)-1] += token.text[size:]
+			continue
+		}
+		texts = append(texts, token.text)
+	}
+	return joinTerms(texts)
+}
+
+// containsPhrase reports whether phrase contains part as whole words, or anywhere for Japanese and
+// Chinese, which have no spaces between words
+func containsPhrase(phrase, part string) bool {
+	if isCJKPhrase(part) {
+		return strings.Contains(phrase, part)
+	}
+	return strings.Contains(" "+phrase+" ", " "+part+" ")
+}
+
+// isCJKPhrase reports whether a phrase starts with a Chinese, Japanese or Korean character
+func isCJKPhrase(phrase string) bool {
+	first, _ := utf8.DecodeRuneInString(phrase)
+	return isCJK(first)
+}
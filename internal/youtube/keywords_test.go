@@ -0,0 +1,82 @@
+package youtube
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func keywordTranscript(videoID, language string, texts ...string) *models.TranscriptResponse {
+	segments := make([]models.TranscriptSegment, len(texts))
+	for i, text := range texts {
+		segments[i] = models.TranscriptSegment{Text: text, Start: float64(i * 10), Duration: 10}
+	}
+	return &models.TranscriptResponse{VideoID: videoID, Language: language, Transcript: segments}
+}
+
+func keyphraseNames(keyphrases []models.Keyphrase) string {
+	names := make([]string, len(keyphrases))
+	for i, keyphrase := range keyphrases {
+		names[i] = keyphrase.Phrase
+	}
+	return strings.Join(names, ",")
+}
+
+func TestExtractKeywords(t *testing.T) {
+	transcripts := []*models.TranscriptResponse{
+		keywordTranscript("first", "en",
+			"Today we train neural networks",
+			"neural networks learn from data",
+			"more data helps neural networks"),
+		keywordTranscript("second", "en",
+			"Sourdough bread needs a starter",
+			"the starter feeds on flour and data",
+			"bake the sourdough bread hot"),
+	}
+
+	response := extractKeywords(transcripts, models.KeywordOptions{MaxKeywords: 2})
+
+	if len(response.Videos) != 2 {
+		t.Fatalf("Expected two videos, got %+v", response.Videos)
+	}
+	if names := keyphraseNames(response.Videos[0].Keyphrases); names != "neural networks,data" {
+		t.Errorf("Unexpected keyphrases for the first video: %s", names)
+	}
+	first := response.Videos[0].Keyphrases[0]
+	if first.Count != 3 || len(first.Timestamps) != 3 || first.Timestamps[2] != 20 {
+		t.Errorf("Expected three occurrences with timestamps, got %+v", first)
+	}
+	if names := keyphraseNames(response.Videos[1].Keyphrases); names != "sourdough bread,starter" {
+		t.Errorf("Unexpected keyphrases for the second video: %s", names)
+	}
+
+	if len(response.Themes) != 1 || response.Themes[0].Phrase != "data" || response.Themes[0].VideoCount != 2 || response.Themes[0].Count != 3 {
+		t.Errorf("Expected data as the only shared theme, got %+v", response.Themes)
+	}
+}
+
+func TestCollectPhrasesChinese(t *testing.T) {
+	document := collectPhrases(keywordTranscript("zh", "zh-Hans", "机器学习很有趣", "我们学习机器学习"))
+
+	if document.counts["机器学习"] != 2 || document.words["机器学习"] != 3 {
+		t.Errorf("Expected the bigrams to be stitched back into 机器学习, got %v", document.counts)
+	}
+	if _, ok := document.counts["我们"]; ok {
+		t.Error("Expected function characters to be left out")
+	}
+	if timestamps := document.timestamps["机器学习"]; len(timestamps) != 2 || timestamps[1] != 10 {
+		t.Errorf("Unexpected timestamps %v", timestamps)
+	}
+}
+
+func TestCollectPhrasesJapanese(t *testing.T) {
+	document := collectPhrases(keywordTranscript("ja", "ja", "機械学習のモデルを作ります", "機械学習のモデルは便利です"))
+
+	if document.counts["機械学習"] != 2 || document.counts["モデル"] != 2 {
+		t.Errorf("Expected script runs as words, got %v", document.counts)
+	}
+	if _, ok := document.counts["機械学習 モデル"]; ok {
+		t.Error("Expected particles to break phrases")
+	}
+}
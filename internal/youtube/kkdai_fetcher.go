@@ -89,8 +89,10 @@ func (k *KkdaiFetcher) FetchTranscript(ctx context.Context, videoID string, lang
 			Duration: float64(seg.Duration) / 1000.0,
 			End:      float64(seg.StartMs+seg.Duration) / 1000.0,
 		}
+		annotateSegment(&segment)
 		segments = append(segments, segment)
 	}
+	assignSpeakerTurns(segments)
 
 	// Build response
 	response := &models.TranscriptResponse{
@@ -120,7 +122,7 @@ func (k *KkdaiFetcher) FetchTranscript(ctx context.Context, videoID string, lang
 	response.FormattedText = text
 
 	// Calculate metadata
-	response.WordCount = k.countWords(text)
+	response.WordCount = k.countWords(stripAnnotations(text))
 	response.CharCount = len(text)
 	response.DurationSeconds = video.Duration.Seconds()
 
@@ -0,0 +1,52 @@
+package youtube
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// SearchLibrary searches the segments of every transcript the service has fetched, including
+// those whose cache entries have expired
+func (s *Service) SearchLibrary(ctx context.Context, opts models.LibrarySearchOptions) (*models.LibrarySearchResponse, error) {
+	if s.library == nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeLibraryUnavailable,
+			Message: "The transcript library is not enabled; set YOUTUBE_LIBRARY_PATH to a directory to index fetched transcripts",
+		}
+	}
+
+	if opts.MaxResults <= 0 {
+		opts.MaxResults = models.DefaultLibraryResults
+	}
+
+	hits, total, err := s.library.Search(opts)
+	if err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeValidationError,
+			Message: "Invalid query: " + err.Error(),
+		}
+	}
+	for i := range hits {
+		hits[i].URL = buildTimestampURL(hits[i].VideoID, hits[i].Start)
+	}
+
+	return &models.LibrarySearchResponse{
+		Query:              opts.Query,
+		Hits:               hits,
+		TotalHits:          total,
+		IndexedTranscripts: s.library.Size(),
+		Truncated:          total > len(hits),
+	}, nil
+}
+
+// addToLibrary indexes a fetched transcript. Indexing failures are logged and never fail a fetch.
+func (s *Service) addToLibrary(transcript *models.TranscriptResponse) {
+	if s.library == nil {
+		return
+	}
+	if err := s.library.Add(transcript); err != nil {
+		s.logger.Warn("Failed to add transcript to library", slog.String("video_id", transcript.VideoID), "error", err)
+	}
+}
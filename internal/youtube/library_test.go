@@ -0,0 +1,67 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/library"
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestSearchLibrary_Disabled(t *testing.T) {
+	service := newTestService(t, http.NewServeMux())
+
+	_, err := service.SearchLibrary(context.Background(), models.LibrarySearchOptions{Query: "hello"})
+	transcriptErr, ok := err.(*models.TranscriptError)
+	if !ok || transcriptErr.Type != models.ErrorTypeLibraryUnavailable {
+		t.Errorf("Expected a library unavailable error, got %v", err)
+	}
+}
+
+func TestSearchLibrary_IndexesFetchedTranscripts(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/watch", func(w http.ResponseWriter, r *http.Request) {
+		captionURL := "http://" + r.Host + "/api/timedtext"
+		fmt.Fprintf(w, `<script>var ytInitialPlayerResponse = {"videoDetails":{"videoId":"dQw4w9WgXcQ","title":"Demo"},"captions":{"playerCaptionsTracklistRenderer":{`+
+			`"captionTracks":[{"baseUrl":"%s?lang=en","languageCode":"en","isDefault":true}]}}};</script>`, captionURL)
+	})
+	mux.HandleFunc("/api/timedtext", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `<transcript><text start="0" dur="2">Good morning</text><text start="62.5" dur="2">see you tomorrow</text></transcript>`)
+	})
+
+	service := newTestService(t, mux)
+	service.config.LibraryPath = t.TempDir()
+	service.library = openTestLibrary(t, service.config.LibraryPath)
+
+	if _, err := service.GetTranscript(context.Background(), "dQw4w9WgXcQ", []string{"en"}, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	response, err := service.SearchLibrary(context.Background(), models.LibrarySearchOptions{Query: `"see you"`})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response.TotalHits != 1 || response.IndexedTranscripts != 1 || response.Truncated {
+		t.Fatalf("Unexpected response: %+v", response)
+	}
+	if hit := response.Hits[0]; hit.Start != 62.5 || hit.URL != "https://youtu.be/dQw4w9WgXcQ?t=62" {
+		t.Errorf("Unexpected hit: %+v", hit)
+	}
+
+	_, err = service.SearchLibrary(context.Background(), models.LibrarySearchOptions{Query: "-morning"})
+	if transcriptErr, ok := err.(*models.TranscriptError); !ok || transcriptErr.Type != models.ErrorTypeValidationError {
+		t.Errorf("Expected a validation error, got %v", err)
+	}
+}
+
+func openTestLibrary(t *testing.T, dir string) *library.Index {
+	t.Helper()
+
+	index, err := library.Open(dir, setupTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to open library: %v", err)
+	}
+	return index
+}
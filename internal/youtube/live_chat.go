@@ -0,0 +1,465 @@
+package youtube
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// maxChatReplayPages caps how many replay pages a single request follows. Every page is a rate-limited
+// request, so a long replay is returned in parts, each with the page token of the next.
+const maxChatReplayPages = 25
+
+// ChatRuns is a chat text field whose runs mix plain text and emoji
+type ChatRuns struct {
+	SimpleText string `json:"simpleText"`
+	Runs       []struct {
+		Text  string `json:"text"`
+		Emoji *struct {
+			EmojiID   string   `json:"emojiId"`
+			Shortcuts []string `json:"shortcuts"`
+		} `json:"emoji,omitempty"`
+	} `json:"runs"`
+}
+
+// String returns the plain text of the field. Custom channel emoji are written as their
+// :shortcut:, standard emoji as the character itself.
+func (r ChatRuns) String() string {
+	if r.SimpleText != "" {
+		return r.SimpleText
+	}
+	var builder strings.Builder
+	for _, run := range r.Runs {
+		switch {
+		case run.Emoji == nil:
+			builder.WriteString(run.Text)
+		case len(run.Emoji.Shortcuts) > 0 && strings.Contains(run.Emoji.EmojiID, "/"):
+			builder.WriteString(run.Emoji.Shortcuts[0])
+		default:
+			builder.WriteString(run.Emoji.EmojiID)
+		}
+	}
+	return builder.String()
+}
+
+// ChatItemRenderer holds the fields shared by the chat message renderers
+type ChatItemRenderer struct {
+	ID                      string   `json:"id"`
+	Message                 ChatRuns `json:"message"`
+	HeaderSubtext           ChatRuns `json:"headerSubtext"`
+	AuthorName              RunsText `json:"authorName"`
+	AuthorExternalChannelID string   `json:"authorExternalChannelId"`
+	TimestampUsec           string   `json:"timestampUsec"`
+	TimestampText           RunsText `json:"timestampText"`
+	PurchaseAmountText      RunsText `json:"purchaseAmountText"`
+	Sticker                 struct {
+		Accessibility struct {
+			AccessibilityData struct {
+				Label string `json:"label"`
+			} `json:"accessibilityData"`
+		} `json:"accessibility"`
+	} `json:"sticker"`
+}
+
+// ChatItem is one chat entry; at most one renderer is set and other entry kinds are ignored
+type ChatItem struct {
+	LiveChatTextMessageRenderer    *ChatItemRenderer `json:"liveChatTextMessageRenderer,omitempty"`
+	LiveChatPaidMessageRenderer    *ChatItemRenderer `json:"liveChatPaidMessageRenderer,omitempty"`
+	LiveChatPaidStickerRenderer    *ChatItemRenderer `json:"liveChatPaidStickerRenderer,omitempty"`
+	LiveChatMembershipItemRenderer *ChatItemRenderer `json:"liveChatMembershipItemRenderer,omitempty"`
+}
+
+// ChatReplayResponse is an innertube response carrying a page of chat replay
+type ChatReplayResponse struct {
+	ContinuationContents struct {
+		LiveChatContinuation struct {
+			Continuations []struct {
+				LiveChatReplayContinuationData *struct {
+					Continuation string `json:"continuation"`
+				} `json:"liveChatReplayContinuationData,omitempty"`
+			} `json:"continuations"`
+			Actions []struct {
+				ReplayChatItemAction struct {
+					Actions []struct {
+						AddChatItemAction struct {
+							Item ChatItem `json:"item"`
+						} `json:"addChatItemAction"`
+					} `json:"actions"`
+					VideoOffsetTimeMsec string `json:"videoOffsetTimeMsec"`
+				} `json:"replayChatItemAction"`
+			} `json:"actions"`
+		} `json:"liveChatContinuation"`
+	} `json:"continuationContents"`
+}
+
+// nextContinuation returns the token of the following replay page
+func (r *ChatReplayResponse) nextContinuation() string {
+	for _, continuation := range r.ContinuationContents.LiveChatContinuation.Continuations {
+		if data := continuation.LiveChatReplayContinuationData; data != nil && data.Continuation != "" {
+			return data.Continuation
+		}
+	}
+	return ""
+}
+
+// messages returns the chat messages of the page in replay order
+func (r *ChatReplayResponse) messages() []models.LiveChatMessage {
+	var messages []models.LiveChatMessage
+	for _, action := range r.ContinuationContents.LiveChatContinuation.Actions {
+		replay := action.ReplayChatItemAction
+		offsetMillis, err := strconv.ParseFloat(replay.VideoOffsetTimeMsec, 64)
+		if err != nil {
+			continue
+		}
+		for _, item := range replay.Actions {
+			if message, ok := chatMessageFromItem(item.AddChatItemAction.Item); ok {
+				message.Offset = offsetMillis / 1000.0
+				messages = append(messages, message)
+			}
+		}
+	}
+	return messages
+}
+
+// GetLiveChatReplay returns the chat replay of a finished live stream. The replay is walked page by
+// page from the stream start, from Start when given, or from where PageToken left off, until End or
+// MaxMessages is reached. A walk stopped early by MaxMessages, the page limit or a failed page is
+// returned with Truncated set and the NextPageToken to continue from.
+func (s *Service) GetLiveChatReplay(ctx context.Context, videoIdentifier string, opts models.LiveChatOptions) (*models.LiveChatReplayResponse, error) {
+	videoID, err := s.extractVideoID(videoIdentifier)
+	if err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeInvalidVideoID,
+			Message: fmt.Sprintf("Invalid video identifier: %s", err.Error()),
+			VideoID: videoIdentifier,
+		}
+	}
+
+	start := 0.0
+	if opts.Start != nil {
+		start = *opts.Start
+	}
+	end := math.Inf(1)
+	if opts.End != nil {
+		end = *opts.End
+	}
+	if end <= start {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeValidationError,
+			Message: fmt.Sprintf("Range end (%.3fs) must be after start (%.3fs)", end, start),
+			VideoID: videoID,
+		}
+	}
+
+	maxMessages := opts.MaxMessages
+	if maxMessages <= 0 {
+		maxMessages = models.DefaultChatMessages
+	}
+
+	if waitErr := s.waitForRateLimit(ctx); waitErr != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeRateLimitExceeded,
+			Message: fmt.Sprintf("Rate limit exceeded: %s", waitErr.Error()),
+			VideoID: videoID,
+		}
+	}
+
+	videoData, err := s.fetchVideoData(ctx, videoID)
+	if err != nil {
+		s.recordRateLimitFailure(err)
+		return nil, err
+	}
+	s.recordRateLimitSuccess()
+
+	token, seekMillis, skip, err := decodeChatPageToken(opts.PageToken)
+	if err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeValidationError,
+			Message: err.Error(),
+			VideoID: videoID,
+		}
+	}
+	if token == "" {
+		if token, err = chatReplayToken(videoData); err != nil {
+			return nil, err
+		}
+		seekMillis = int64(start * 1000)
+	}
+
+	wanted := make(map[string]bool, len(opts.MessageTypes))
+	for _, messageType := range opts.MessageTypes {
+		wanted[messageType] = true
+	}
+
+	response := &models.LiveChatReplayResponse{
+		VideoID:  videoID,
+		Title:    videoData.Title,
+		Messages: make([]models.LiveChatMessage, 0),
+		Start:    start,
+	}
+	if opts.End != nil {
+		response.End = end
+	}
+
+	// The first request seeks to the range start; later pages follow on from the previous one
+	seen := map[string]bool{token: true}
+	for page := 0; ; page++ {
+		payload := map[string]any{"continuation": token}
+		if seekMillis >= 0 {
+			payload["currentPlayerState"] = map[string]any{
+				"playerOffsetMs": strconv.FormatInt(seekMillis, 10),
+			}
+		}
+		replay, err := s.fetchChatReplayPage(ctx, videoData, payload, videoID)
+		if err != nil {
+			if page == 0 {
+				return nil, err
+			}
+			// Return what was collected so far rather than failing the whole replay
+			s.logger.Warn("Failed to fetch chat replay page", "video_id", videoID, "error", err)
+			response.Truncated = true
+			response.NextPageToken = encodeChatPageToken(token, seekMillis, 0)
+			break
+		}
+
+		done := false
+		messages := replay.messages()
+		for i := min(skip, len(messages)); i < len(messages); i++ {
+			message := messages[i]
+			if message.Offset >= end {
+				done = true
+				break
+			}
+			if message.Offset < start || (len(wanted) > 0 && !wanted[message.Type]) {
+				continue
+			}
+			if len(response.Messages) >= maxMessages {
+				// Continue from this message of the same page
+				response.Truncated = true
+				response.NextPageToken = encodeChatPageToken(token, seekMillis, i)
+				done = true
+				break
+			}
+			response.Messages = append(response.Messages, message)
+		}
+
+		next := replay.nextContinuation()
+		if done || next == "" || seen[next] {
+			break
+		}
+		if page+1 >= maxChatReplayPages {
+			response.Truncated = true
+			response.NextPageToken = encodeChatPageToken(next, -1, 0)
+			break
+		}
+		seen[next] = true
+		token, seekMillis, skip = next, -1, 0
+	}
+
+	response.MessageCount = len(response.Messages)
+	for _, message := range response.Messages {
+		if message.Type == models.ChatMessageTypeSuperChat || message.Type == models.ChatMessageTypeSuperSticker {
+			response.SuperChatCount++
+		}
+	}
+
+	if opts.IncludeTranscript {
+		s.mergeChatWithTranscript(ctx, response, opts.Languages, start, end)
+	}
+
+	return response, nil
+}
+
+// fetchChatReplayPage requests and parses one page of chat replay
+func (s *Service) fetchChatReplayPage(ctx context.Context, videoData *VideoData, payload map[string]any, videoID string) (*ChatReplayResponse, error) {
+	body, err := s.postInnertube(ctx, "live_chat/get_live_chat_replay", videoData.Innertube, payload, videoID)
+	if err != nil {
+		return nil, err
+	}
+	var replay ChatReplayResponse
+	if err := json.Unmarshal(body, &replay); err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeParsingError,
+			Message: fmt.Sprintf("Failed to parse chat replay: %s", err.Error()),
+			VideoID: videoID,
+		}
+	}
+	return &replay, nil
+}
+
+// encodeChatPageToken packs where a replay stopped: the continuation of the page, the playback offset
+// it was opened at (-1 when it follows on from the previous page) and how many of its messages were walked
+func encodeChatPageToken(continuation string, seekMillis int64, skip int) string {
+	seek := ""
+	if seekMillis >= 0 {
+		seek = strconv.FormatInt(seekMillis, 10)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(skip) + "|" + seek + "|" + continuation))
+}
+
+// decodeChatPageToken unpacks a token made by encodeChatPageToken
+func decodeChatPageToken(token string) (string, int64, int, error) {
+	if token == "" {
+		return "", -1, 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("invalid page token")
+	}
+	parts := strings.SplitN(string(raw), "|", 3)
+	if len(parts) != 3 || parts[2] == "" {
+		return "", 0, 0, fmt.Errorf("invalid page token")
+	}
+	skip, err := strconv.Atoi(parts[0])
+	if err != nil || skip < 0 {
+		return "", 0, 0, fmt.Errorf("invalid page token")
+	}
+	seekMillis := int64(-1)
+	if parts[1] != "" {
+		if seekMillis, err = strconv.ParseInt(parts[1], 10, 64); err != nil || seekMillis < 0 {
+			return "", 0, 0, fmt.Errorf("invalid page token")
+		}
+	}
+	return parts[2], seekMillis, skip, nil
+}
+
+// chatReplayToken returns the continuation that opens the chat replay of a video, or explains why there is none
+func chatReplayToken(videoData *VideoData) (string, error) {
+	switch {
+	case videoData.IsLiveNow:
+		return "", &models.TranscriptError{
+			Type:    models.ErrorTypeNoChatReplay,
+			Message: "Stream is still live; chat replay becomes available after it ends",
+			VideoID: videoData.VideoID,
+		}
+	case videoData.IsUpcoming:
+		return "", &models.TranscriptError{
+			Type:    models.ErrorTypeNoChatReplay,
+			Message: "Stream has not started yet",
+			VideoID: videoData.VideoID,
+		}
+	}
+
+	token := ""
+	if videoData.InitialData != nil {
+		token = videoData.InitialData.chatReplayContinuation()
+	}
+	if token != "" {
+		return token, nil
+	}
+
+	message := "Video is not a past live stream or premiere"
+	if videoData.IsLive {
+		message = "Chat replay is disabled or unavailable for this stream"
+	}
+	return "", &models.TranscriptError{
+		Type:    models.ErrorTypeNoChatReplay,
+		Message: message,
+		VideoID: videoData.VideoID,
+	}
+}
+
+// chatMessageFromItem converts a chat entry, reporting false for entries that are not messages
+func chatMessageFromItem(item ChatItem) (models.LiveChatMessage, bool) {
+	var renderer *ChatItemRenderer
+	var messageType string
+	switch {
+	case item.LiveChatTextMessageRenderer != nil:
+		renderer, messageType = item.LiveChatTextMessageRenderer, models.ChatMessageTypeText
+	case item.LiveChatPaidMessageRenderer != nil:
+		renderer, messageType = item.LiveChatPaidMessageRenderer, models.ChatMessageTypeSuperChat
+	case item.LiveChatPaidStickerRenderer != nil:
+		renderer, messageType = item.LiveChatPaidStickerRenderer, models.ChatMessageTypeSuperSticker
+	case item.LiveChatMembershipItemRenderer != nil:
+		renderer, messageType = item.LiveChatMembershipItemRenderer, models.ChatMessageTypeMembership
+	default:
+		return models.LiveChatMessage{}, false
+	}
+
+	message := models.LiveChatMessage{
+		ID:              renderer.ID,
+		Type:            messageType,
+		Author:          renderer.AuthorName.String(),
+		AuthorChannelID: renderer.AuthorExternalChannelID,
+		Message:         renderer.Message.String(),
+		Amount:          renderer.PurchaseAmountText.String(),
+		TimeText:        renderer.TimestampText.String(),
+	}
+
+	switch messageType {
+	case models.ChatMessageTypeSuperSticker:
+		if message.Message == "" {
+			message.Message = renderer.Sticker.Accessibility.AccessibilityData.Label
+		}
+	case models.ChatMessageTypeMembership:
+		// "Welcome to ..." or "Member for 6 months" heads the member's own message, if any
+		if header := renderer.HeaderSubtext.String(); header != "" {
+			message.Message = strings.TrimSpace(header + " " + message.Message)
+		}
+	}
+
+	if usec, err := strconv.ParseInt(renderer.TimestampUsec, 10, 64); err == nil {
+		timestamp := time.UnixMicro(usec).UTC()
+		message.Timestamp = &timestamp
+	}
+	return message, true
+}
+
+// mergeChatWithTranscript fills the timeline of response with the caption segments in [start, end)
+// and the chat messages, ordered by offset. A missing transcript is reported rather than failing the replay.
+func (s *Service) mergeChatWithTranscript(ctx context.Context, response *models.LiveChatReplayResponse, languages []string, start, end float64) {
+	var segments []models.TranscriptSegment
+	transcript, err := s.GetTranscript(ctx, response.VideoID, languages, true)
+	if err != nil {
+		transcriptErr, ok := err.(*models.TranscriptError)
+		if !ok {
+			transcriptErr = &models.TranscriptError{
+				Type:    models.ErrorTypeInternalError,
+				Message: err.Error(),
+				VideoID: response.VideoID,
+			}
+		}
+		response.TranscriptError = transcriptErr
+	} else {
+		response.TranscriptLanguage = transcript.Language
+		segments = clipSegments(transcript.Transcript, start, end)
+	}
+
+	response.Timeline = buildChatTimeline(segments, response.Messages)
+}
+
+// buildChatTimeline interleaves caption segments and chat messages by offset. Captions sort before
+// chat messages at the same offset, since chat usually reacts to what was just said.
+func buildChatTimeline(segments []models.TranscriptSegment, messages []models.LiveChatMessage) []models.TimelineEntry {
+	timeline := make([]models.TimelineEntry, 0, len(segments)+len(messages))
+	for _, segment := range segments {
+		timeline = append(timeline, models.TimelineEntry{
+			Source: models.TimelineSourceCaption,
+			Text:   segment.Text,
+			Offset: segment.Start,
+		})
+	}
+	for _, message := range messages {
+		timeline = append(timeline, models.TimelineEntry{
+			Source: models.TimelineSourceChat,
+			Type:   message.Type,
+			Author: message.Author,
+			Text:   message.Message,
+			Amount: message.Amount,
+			Offset: message.Offset,
+		})
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].Offset < timeline[j].Offset
+	})
+	return timeline
+}
@@ -0,0 +1,270 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// liveStreamPage is a watch page of a finished stream whose chat panel offers top and full replay
+const liveStreamPage = `<script>var ytcfg = {"INNERTUBE_API_KEY":"stream-key"};</script>` +
+	`<script>var ytInitialPlayerResponse = {"videoDetails":{"videoId":"dQw4w9WgXcQ","title":"Launch stream","isLiveContent":true,"isLive":false}};</script>` +
+	`<script>var ytInitialData = {"contents":{"twoColumnWatchNextResults":{"conversationBar":{"liveChatRenderer":{"isReplay":true,` +
+	`"continuations":[{"reloadContinuationData":{"continuation":"top-chat"}}],` +
+	`"header":{"liveChatHeaderRenderer":{"viewSelector":{"sortFilterSubMenuRenderer":{"subMenuItems":[` +
+	`{"title":"Top chat replay","selected":true,"continuation":{"reloadContinuationData":{"continuation":"top-chat"}}},` +
+	`{"title":"Live chat replay","selected":false,"continuation":{"reloadContinuationData":{"continuation":"all-chat"}}}]}}}}}}}}};</script>`
+
+func TestChatMessageFromItem(t *testing.T) {
+	var replay ChatReplayResponse
+	if err := json.Unmarshal(readFixture(t, "live_chat_replay.json"), &replay); err != nil {
+		t.Fatalf("Failed to parse fixture: %v", err)
+	}
+
+	messages := replay.messages()
+	if len(messages) != 3 {
+		t.Fatalf("Expected 3 messages (engagement banner skipped), got %d: %+v", len(messages), messages)
+	}
+
+	text := messages[0]
+	if text.Type != models.ChatMessageTypeText || text.Message != "hello from Berlin 👋" || text.Author != "Ada" || text.Offset != 5.2 {
+		t.Errorf("Unexpected text message: %+v", text)
+	}
+	if text.Timestamp == nil || text.Timestamp.Unix() != 1709308805 {
+		t.Errorf("Unexpected timestamp %v", text.Timestamp)
+	}
+
+	paid := messages[1]
+	if paid.Type != models.ChatMessageTypeSuperChat || paid.Amount != "$5.00" || paid.Message != "great stream :gopher:" {
+		t.Errorf("Unexpected super chat: %+v", paid)
+	}
+
+	membership := messages[2]
+	if membership.Type != models.ChatMessageTypeMembership || membership.Message != "Welcome to Gopher Club!" {
+		t.Errorf("Unexpected membership message: %+v", membership)
+	}
+
+	if next := replay.nextContinuation(); next != "replay-page-2" {
+		t.Errorf("Expected next continuation 'replay-page-2', got %q", next)
+	}
+}
+
+func TestGetLiveChatReplay(t *testing.T) {
+	firstPage := readFixture(t, "live_chat_replay.json")
+	lastPage := `{"continuationContents":{"liveChatContinuation":{"actions":[{"replayChatItemAction":{"videoOffsetTimeMsec":"95000","actions":[` +
+		`{"addChatItemAction":{"item":{"liveChatTextMessageRenderer":{"id":"msg-4","message":{"runs":[{"text":"bye"}]},"authorName":{"simpleText":"Ada"}}}}}]}}]}}}`
+
+	var tokens []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/watch", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, liveStreamPage)
+	})
+	mux.HandleFunc("/youtubei/v1/live_chat/get_live_chat_replay", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key") != "stream-key" {
+			t.Errorf("Expected the page's API key, got %q", r.URL.RawQuery)
+		}
+		var body struct {
+			Continuation string `json:"continuation"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		tokens = append(tokens, body.Continuation)
+		if body.Continuation == "all-chat" {
+			_, _ = w.Write(firstPage)
+			return
+		}
+		_, _ = io.WriteString(w, lastPage)
+	})
+
+	service := newTestService(t, mux)
+	ctx := context.Background()
+
+	response, err := service.GetLiveChatReplay(ctx, "dQw4w9WgXcQ", models.LiveChatOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tokens) != 2 || tokens[0] != "all-chat" || tokens[1] != "replay-page-2" {
+		t.Errorf("Expected the full replay to be walked to the end, got requests %v", tokens)
+	}
+	if response.Title != "Launch stream" || response.MessageCount != 4 || response.SuperChatCount != 1 || response.Truncated {
+		t.Errorf("Unexpected response: %+v", response)
+	}
+
+	// A range ends the walk at the first message past it, and types filter what is kept
+	end := 60.0
+	tokens = nil
+	filtered, err := service.GetLiveChatReplay(ctx, "dQw4w9WgXcQ", models.LiveChatOptions{
+		End:          &end,
+		MessageTypes: []string{models.ChatMessageTypeSuperChat},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tokens) != 1 {
+		t.Errorf("Expected one replay request, got %v", tokens)
+	}
+	if filtered.MessageCount != 1 || filtered.Messages[0].ID != "msg-2" {
+		t.Errorf("Unexpected filtered messages: %+v", filtered.Messages)
+	}
+
+	// The message limit truncates the replay, and its page token resumes after the last message returned
+	limited, err := service.GetLiveChatReplay(ctx, "dQw4w9WgXcQ", models.LiveChatOptions{MaxMessages: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if limited.MessageCount != 2 || !limited.Truncated || limited.NextPageToken == "" {
+		t.Fatalf("Expected 2 messages and a truncated replay with a page token, got %d (truncated=%v, token=%q)",
+			limited.MessageCount, limited.Truncated, limited.NextPageToken)
+	}
+	tokens = nil
+	resumed, err := service.GetLiveChatReplay(ctx, "dQw4w9WgXcQ", models.LiveChatOptions{PageToken: limited.NextPageToken})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tokens) != 2 || tokens[0] != "all-chat" || resumed.Truncated {
+		t.Errorf("Expected the resumed replay to walk from the truncated page to the end, got requests %v", tokens)
+	}
+	var ids []string
+	for _, message := range resumed.Messages {
+		ids = append(ids, message.ID)
+	}
+	if fmt.Sprint(ids) != "[msg-3 msg-4]" {
+		t.Errorf("Expected the messages after the first two, got %v", ids)
+	}
+
+	// A page token continues the replay from that page without seeking
+	tokens = nil
+	continued, err := service.GetLiveChatReplay(ctx, "dQw4w9WgXcQ", models.LiveChatOptions{PageToken: encodeChatPageToken("replay-page-2", -1, 0)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tokens) != 1 || tokens[0] != "replay-page-2" || continued.MessageCount != 1 || continued.Messages[0].ID != "msg-4" {
+		t.Errorf("Expected the replay continued from the token, got requests %v and %+v", tokens, continued.Messages)
+	}
+}
+
+func TestGetLiveChatReplayStopsAtPageLimit(t *testing.T) {
+	pages := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/watch", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, liveStreamPage)
+	})
+	mux.HandleFunc("/youtubei/v1/live_chat/get_live_chat_replay", func(w http.ResponseWriter, r *http.Request) {
+		// An endless replay of empty pages
+		pages++
+		_, _ = fmt.Fprintf(w, `{"continuationContents":{"liveChatContinuation":{"continuations":[`+
+			`{"liveChatReplayContinuationData":{"continuation":"page-%d"}}]}}}`, pages)
+	})
+
+	service := newTestService(t, mux)
+
+	response, err := service.GetLiveChatReplay(context.Background(), "dQw4w9WgXcQ", models.LiveChatOptions{
+		MessageTypes: []string{models.ChatMessageTypeSuperChat},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if pages != maxChatReplayPages {
+		t.Errorf("Expected %d replay requests, got %d", maxChatReplayPages, pages)
+	}
+	if !response.Truncated || response.NextPageToken != encodeChatPageToken(fmt.Sprintf("page-%d", maxChatReplayPages), -1, 0) {
+		t.Errorf("Expected a truncated replay continuing from the next page, got truncated=%v token=%q", response.Truncated, response.NextPageToken)
+	}
+}
+
+func TestGetLiveChatReplayKeepsMessagesOnBadPage(t *testing.T) {
+	firstPage := readFixture(t, "live_chat_replay.json")
+	mux := http.NewServeMux()
+	mux.HandleFunc("/watch", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, liveStreamPage)
+	})
+	mux.HandleFunc("/youtubei/v1/live_chat/get_live_chat_replay", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Continuation string `json:"continuation"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Continuation == "all-chat" {
+			_, _ = w.Write(firstPage)
+			return
+		}
+		_, _ = io.WriteString(w, `{"continuationContents":`)
+	})
+
+	service := newTestService(t, mux)
+
+	response, err := service.GetLiveChatReplay(context.Background(), "dQw4w9WgXcQ", models.LiveChatOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response.MessageCount != 3 || !response.Truncated || response.NextPageToken != encodeChatPageToken("replay-page-2", -1, 0) {
+		t.Errorf("Expected the first page kept and a token for the failed one, got %d messages (truncated=%v, token=%q)",
+			response.MessageCount, response.Truncated, response.NextPageToken)
+	}
+}
+
+func TestChatPageToken(t *testing.T) {
+	continuation, seekMillis, skip, err := decodeChatPageToken(encodeChatPageToken("op2w|abc", 60000, 3))
+	if err != nil || continuation != "op2w|abc" || seekMillis != 60000 || skip != 3 {
+		t.Errorf("Round trip gave %q, %d, %d, %v", continuation, seekMillis, skip, err)
+	}
+	if _, seekMillis, _, _ := decodeChatPageToken(encodeChatPageToken("next", -1, 0)); seekMillis != -1 {
+		t.Errorf("Expected no seek for a following page, got %d", seekMillis)
+	}
+	for _, token := range []string{"not base64!", "cmVwbGF5", "LTF8fG5leHQ"} {
+		if _, _, _, err := decodeChatPageToken(token); err == nil {
+			t.Errorf("Expected %q to be rejected", token)
+		}
+	}
+}
+
+func TestChatReplayTokenErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		videoData *VideoData
+		message   string
+	}{
+		{"still live", &VideoData{IsLive: true, IsLiveNow: true}, "Stream is still live; chat replay becomes available after it ends"},
+		{"upcoming", &VideoData{IsLive: true, IsUpcoming: true}, "Stream has not started yet"},
+		{"chat disabled", &VideoData{IsLive: true, InitialData: &InitialData{}}, "Chat replay is disabled or unavailable for this stream"},
+		{"regular upload", &VideoData{}, "Video is not a past live stream or premiere"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := chatReplayToken(tt.videoData)
+			transcriptErr, ok := err.(*models.TranscriptError)
+			if !ok || transcriptErr.Type != models.ErrorTypeNoChatReplay || transcriptErr.Message != tt.message {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestBuildChatTimeline(t *testing.T) {
+	segments := []models.TranscriptSegment{
+		{Text: "welcome everyone", Start: 0, Duration: 4},
+		{Text: "today we ship", Start: 42, Duration: 3},
+	}
+	messages := []models.LiveChatMessage{
+		{Type: models.ChatMessageTypeText, Author: "Ada", Message: "hi", Offset: 5.2},
+		{Type: models.ChatMessageTypeSuperChat, Author: "Grace", Message: "ship it", Amount: "$5.00", Offset: 42},
+	}
+
+	timeline := buildChatTimeline(segments, messages)
+	expected := []string{"welcome everyone", "hi", "today we ship", "ship it"}
+	if len(timeline) != len(expected) {
+		t.Fatalf("Expected %d entries, got %d", len(expected), len(timeline))
+	}
+	for i, text := range expected {
+		if timeline[i].Text != text {
+			t.Errorf("Entry %d: expected %q, got %q", i, text, timeline[i].Text)
+		}
+	}
+	if timeline[3].Source != models.TimelineSourceChat || timeline[3].Amount != "$5.00" {
+		t.Errorf("Unexpected chat entry: %+v", timeline[3])
+	}
+}
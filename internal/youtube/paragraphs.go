@@ -0,0 +1,154 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// paragraphUnit is a stretch of speech that paragraphs are built from: a caption segment, or a
+// restored sentence of automatic captions
+type paragraphUnit struct {
+	text          string
+	start         float64
+	end           float64
+	sentenceEnd   bool
+	speakerChange bool
+}
+
+// paragraph is a group of units rendered together, under a chapter heading when it opens a chapter
+type paragraph struct {
+	heading string
+	start   float64
+	texts   []string
+}
+
+// formatAsParagraphs groups segments into paragraphs at silences, speaker changes and chapter
+// starts, and at sentence ends once a paragraph reaches its target length. Automatic captions
+// without punctuation are grouped by their restored sentences.
+func (s *Service) formatAsParagraphs(segments []models.TranscriptSegment, opts renderOptions) string {
+	var units []paragraphUnit
+	if needsPunctuation(opts.transcriptType, segments) {
+		for _, sentence := range restoreSentences(segments) {
+			units = append(units, paragraphUnit{
+				text:          sentence.text,
+				start:         sentence.start,
+				end:           sentence.end,
+				sentenceEnd:   true,
+				speakerChange: sentence.speakerChange,
+			})
+		}
+	} else {
+		for _, segment := range segments {
+			units = append(units, paragraphUnit{
+				text:          segment.Text,
+				start:         segment.Start,
+				end:           segmentEnd(segment),
+				sentenceEnd:   endsSentence(segment.Text),
+				speakerChange: segment.SpeakerChange,
+			})
+		}
+	}
+
+	var builder strings.Builder
+	for _, p := range buildParagraphs(units, opts.paragraphs, opts.chapters) {
+		if p.heading != "" {
+			builder.WriteString(p.heading)
+			builder.WriteString("\n\n")
+		}
+		if opts.includeTimestamps {
+			builder.WriteString(fmt.Sprintf("[%.1fs] ", p.start))
+		}
+		builder.WriteString(strings.Join(p.texts, " "))
+		builder.WriteString("\n\n")
+	}
+	return strings.TrimSpace(builder.String())
+}
+
+// buildParagraphs groups units into paragraphs. A paragraph ends before a speaker change, a
+// silence of the pause length or the start of a chapter, and at the first sentence end after
+// reaching the target length in seconds or words. Speech without sentence ends is broken at
+// twice the target length.
+func buildParagraphs(units []paragraphUnit, opts models.ParagraphOptions, chapters []models.Chapter) []paragraph {
+	opts = paragraphDefaults(opts)
+
+	var paragraphs []paragraph
+	var current *paragraph
+	words := 0
+	chapterIndex := -1
+	for i, unit := range units {
+		heading := ""
+		if len(chapters) > 0 {
+			next := max(chapterIndex, 0)
+			for next+1 < len(chapters) && unit.start >= chapters[next+1].Start {
+				next++
+			}
+			if next != chapterIndex {
+				chapterIndex = next
+				chapter := chapters[chapterIndex]
+				heading = fmt.Sprintf("## %s [%s]", chapter.Title, formatClock(chapter.Start))
+			}
+		}
+
+		if current == nil || heading != "" || paragraphBreaksBefore(*current, words, units[i-1], unit, opts) {
+			paragraphs = append(paragraphs, paragraph{heading: heading, start: unit.start})
+			current = &paragraphs[len(paragraphs)-1]
+			words = 0
+		}
+		current.texts = append(current.texts, unit.text)
+		words += len(strings.Fields(stripAnnotations(unit.text)))
+	}
+	return paragraphs
+}
+
+// paragraphBreaksBefore reports whether the current paragraph of words words, ending with
+// previous, ends before next
+func paragraphBreaksBefore(current paragraph, words int, previous, next paragraphUnit, opts models.ParagraphOptions) bool {
+	if next.speakerChange || next.start-previous.end >= opts.PauseSeconds {
+		return true
+	}
+
+	length := previous.end - current.start
+	reached := (opts.TargetSeconds > 0 && length >= opts.TargetSeconds) ||
+		(opts.TargetWords > 0 && words >= opts.TargetWords)
+	overdue := (opts.TargetSeconds > 0 && length >= 2*opts.TargetSeconds) ||
+		(opts.TargetWords > 0 && words >= 2*opts.TargetWords)
+	return (reached && previous.sentenceEnd) || overdue
+}
+
+// paragraphDefaults fills in the default pause and target length of paragraph options
+func paragraphDefaults(opts models.ParagraphOptions) models.ParagraphOptions {
+	if opts.PauseSeconds <= 0 {
+		opts.PauseSeconds = models.DefaultParagraphPause
+	}
+	if opts.TargetSeconds <= 0 && opts.TargetWords <= 0 {
+		opts.TargetSeconds = models.DefaultParagraphLength
+	}
+	return opts
+}
+
+// endsSentence reports whether caption text ends a sentence
+func endsSentence(text string) bool {
+	text = strings.TrimRight(strings.TrimSpace(text), `"')”’`)
+	return strings.HasSuffix(text, ".") || strings.HasSuffix(text, "?") || strings.HasSuffix(text, "!") ||
+		strings.HasSuffix(text, "…") || strings.HasSuffix(text, "。") || strings.HasSuffix(text, "？") ||
+		strings.HasSuffix(text, "！")
+}
+
+// chaptersForFormat returns the chapters of a video when the format type shows them as headings.
+// Chapters are optional, so failing to fetch them leaves the output without headings.
+func (s *Service) chaptersForFormat(ctx context.Context, formatType, videoID string) []models.Chapter {
+	if formatType != models.FormatTypeParagraphs {
+		return nil
+	}
+	chapters, err := s.GetChapters(ctx, videoID)
+	if err != nil {
+		s.logger.Debug("Failed to fetch chapters for paragraph headings",
+			slog.String("video_id", videoID), slog.String("error", err.Error()))
+		return nil
+	}
+	return chapters.Chapters
+}
@@ -0,0 +1,114 @@
+package youtube
+
+import (
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestFormatAsParagraphs(t *testing.T) {
+	s := &Service{}
+
+	tests := []struct {
+		name     string
+		segments []models.TranscriptSegment
+		opts     renderOptions
+		expected string
+	}{
+		{
+			name: "silence starts a paragraph",
+			segments: []models.TranscriptSegment{
+				{Text: "First point", Start: 0, Duration: 2},
+				{Text: "continues here.", Start: 2, Duration: 2},
+				{Text: "After the break.", Start: 7, Duration: 2},
+			},
+			opts:     renderOptions{includeTimestamps: true},
+			expected: "[0.0s] First point continues here.\n\n[7.0s] After the break.",
+		},
+		{
+			name: "target length waits for a sentence end",
+			segments: []models.TranscriptSegment{
+				{Text: "One.", Start: 0, Duration: 5},
+				{Text: "Two and", Start: 5, Duration: 5},
+				{Text: "three.", Start: 10, Duration: 5},
+				{Text: "Four.", Start: 15, Duration: 5},
+			},
+			opts:     renderOptions{paragraphs: models.ParagraphOptions{TargetSeconds: 8}},
+			expected: "One. Two and three.\n\nFour.",
+		},
+		{
+			name: "target words",
+			segments: []models.TranscriptSegment{
+				{Text: "[Music] one two three.", Start: 0, Duration: 2},
+				{Text: "four five.", Start: 2, Duration: 2},
+				{Text: "six.", Start: 4, Duration: 2},
+			},
+			opts:     renderOptions{paragraphs: models.ParagraphOptions{TargetWords: 3}},
+			expected: "[Music] one two three.\n\nfour five. six.",
+		},
+		{
+			name: "overdue paragraph without sentence ends",
+			segments: []models.TranscriptSegment{
+				{Text: "a b", Start: 0, Duration: 1},
+				{Text: "c d", Start: 1, Duration: 1},
+				{Text: "e f", Start: 2, Duration: 1},
+			},
+			opts:     renderOptions{paragraphs: models.ParagraphOptions{TargetWords: 2}},
+			expected: "a b c d\n\ne f",
+		},
+		{
+			name: "chapter headings",
+			segments: []models.TranscriptSegment{
+				{Text: "Welcome.", Start: 0, Duration: 2},
+				{Text: "Let's cook.", Start: 60, Duration: 2},
+				{Text: "Stir well.", Start: 62, Duration: 2},
+			},
+			opts: renderOptions{
+				paragraphs: models.ParagraphOptions{PauseSeconds: 100},
+				chapters: []models.Chapter{
+					{Title: "Intro", Start: 0, End: 60},
+					{Title: "Cooking", Start: 60, End: 120},
+				},
+			},
+			expected: "## Intro [0:00]\n\nWelcome.\n\n## Cooking [1:00]\n\nLet's cook. Stir well.",
+		},
+		{
+			name: "restored sentences of automatic captions",
+			segments: []models.TranscriptSegment{
+				{Text: "hello everyone welcome back", Start: 0, Duration: 2},
+				{Text: "today we bake bread", Start: 3, Duration: 2},
+				{Text: "okay let's start", Start: 8, Duration: 2},
+			},
+			opts:     renderOptions{transcriptType: models.TranscriptTypeAuto},
+			expected: "Hello everyone welcome back. Today we bake bread.\n\nOkay, let's start.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.formatAsParagraphs(tt.segments, tt.opts); got != tt.expected {
+				t.Errorf("formatAsParagraphs() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEndsSentence(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected bool
+	}{
+		{"It works.", true},
+		{"Really?", true},
+		{`He said "stop!"`, true},
+		{"終わりです。", true},
+		{"and then", false},
+		{"[Music]", false},
+	}
+
+	for _, tt := range tests {
+		if got := endsSentence(tt.text); got != tt.expected {
+			t.Errorf("endsSentence(%q) = %v, want %v", tt.text, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// playlistIDPattern matches the playlist ID prefixes YouTube uses for user, upload, liked and album lists
+var playlistIDPattern = regexp.MustCompile(`^(?:PL|UU|LL|FL|OL|UL|OLAK5uy_)[a-zA-Z0-9_-]{10,}$`)
+
+// GetPlaylistTranscripts fetches the transcripts of a playlist's videos. The playlist is read from the
+// page data and its continuation pages, then the transcripts go through the bounded-concurrency batch path.
+func (s *Service) GetPlaylistTranscripts(ctx context.Context, playlistIdentifier string, opts models.PlaylistOptions) (*models.PlaylistTranscriptsResponse, error) {
+	playlistID, err := extractPlaylistID(playlistIdentifier)
+	if err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeValidationError,
+			Message: fmt.Sprintf("Invalid playlist identifier: %s", err.Error()),
+			VideoID: playlistIdentifier,
+		}
+	}
+
+	maxVideos := opts.MaxVideos
+	if maxVideos <= 0 {
+		maxVideos = models.DefaultPlaylistVideos
+	}
+
+	// Only the playlist order lets the listing stop early; other orders need every entry
+	limit := 0
+	if opts.Order == "" || opts.Order == models.PlaylistOrderPlaylist {
+		limit = maxVideos
+	}
+
+	title, entries, err := s.listPlaylistVideos(ctx, playlistID, limit, opts.SkipUnavailable)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, skipped := selectPlaylistVideos(entries, opts.Order, maxVideos, opts.SkipUnavailable)
+
+	videoIDs := make([]string, 0, len(selected))
+	unavailable := make([]models.TranscriptError, 0)
+	for _, video := range selected {
+		if video.Available {
+			videoIDs = append(videoIDs, video.VideoID)
+			continue
+		}
+		unavailable = append(unavailable, models.TranscriptError{
+			Type:    models.ErrorTypeVideoUnavailable,
+			Message: "Video is private, deleted or otherwise unavailable",
+			VideoID: video.VideoID,
+		})
+	}
+
+	batch, err := s.GetMultipleTranscriptsWithOptions(ctx, videoIDs, opts.Languages, true, opts.Transcript)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range unavailable {
+		batch.Results = append(batch.Results, models.TranscriptResult{
+			VideoID: unavailable[i].VideoID,
+			Error:   &unavailable[i],
+		})
+		batch.Errors = append(batch.Errors, unavailable[i])
+		batch.ErrorCount++
+	}
+	batch.TotalCount = len(selected)
+
+	order := make([]string, len(selected))
+	for i, video := range selected {
+		order[i] = video.VideoID
+	}
+	orderBatchResults(batch, order)
+
+	return &models.PlaylistTranscriptsResponse{
+		MultipleTranscriptResponse: batch,
+		PlaylistID:                 playlistID,
+		PlaylistTitle:              title,
+		Videos:                     selected,
+		SkippedCount:               skipped,
+	}, nil
+}
+
+// listPlaylistVideos pages through a playlist. With a positive limit it stops once that many usable
+// entries were seen; usable means playable when skipUnavailable is set.
+func (s *Service) listPlaylistVideos(ctx context.Context, playlistID string, limit int, skipUnavailable bool) (string, []models.PlaylistVideo, error) {
+	if waitErr := s.waitForRateLimit(ctx); waitErr != nil {
+		return "", nil, &models.TranscriptError{
+			Type:    models.ErrorTypeRateLimitExceeded,
+			Message: fmt.Sprintf("Rate limit exceeded: %s", waitErr.Error()),
+			VideoID: playlistID,
+		}
+	}
+
+	html, err := s.fetchPage(ctx, s.youtubeURL("/playlist?list="+url.QueryEscape(playlistID)), playlistID)
+	if err != nil {
+		s.recordRateLimitFailure(err)
+		return "", nil, err
+	}
+	s.recordRateLimitSuccess()
+
+	var browseData BrowseData
+	raw, ok := extractJSONVariable(html, "ytInitialData")
+	if ok {
+		ok = json.Unmarshal(raw, &browseData) == nil
+	}
+	if !ok {
+		return "", nil, &models.TranscriptError{
+			Type:    models.ErrorTypeParsingError,
+			Message: "Failed to extract playlist data from page",
+			VideoID: playlistID,
+		}
+	}
+
+	items, found := browseData.playlistItems()
+	if !found {
+		message := browseData.alertText()
+		if message == "" {
+			message = "Playlist not found, private or empty"
+		}
+		return "", nil, &models.TranscriptError{
+			Type:    models.ErrorTypeVideoUnavailable,
+			Message: message,
+			VideoID: playlistID,
+		}
+	}
+
+	cfg := extractInnertubeConfig(html)
+	videos := make([]models.PlaylistVideo, 0, len(items))
+	usable := 0
+	for page := 0; ; page++ {
+		for _, item := range items {
+			renderer := item.PlaylistVideoRenderer
+			if renderer == nil || renderer.VideoID == "" {
+				continue
+			}
+			video := playlistVideoFromRenderer(renderer, len(videos)+1)
+			videos = append(videos, video)
+			if video.Available || !skipUnavailable {
+				usable++
+			}
+		}
+
+		token := continuationToken(items)
+		if token == "" || (limit > 0 && usable >= limit) || page >= maxContinuationPages {
+			break
+		}
+
+		response, err := s.fetchContinuation(ctx, cfg, token, playlistID)
+		if err != nil {
+			// Return what was listed so far rather than failing the whole playlist
+			s.logger.Warn("Failed to fetch playlist continuation", "playlist_id", playlistID, "error", err)
+			break
+		}
+		items = response.items()
+	}
+
+	return browseData.Metadata.PlaylistMetadataRenderer.Title, videos, nil
+}
+
+// playlistVideoFromRenderer converts a playlist entry; position is used when the entry has no index
+func playlistVideoFromRenderer(renderer *PlaylistVideoRenderer, position int) models.PlaylistVideo {
+	video := models.PlaylistVideo{
+		VideoID:   renderer.VideoID,
+		Title:     renderer.Title.String(),
+		Position:  position,
+		Available: renderer.IsPlayable,
+	}
+	if index, err := strconv.Atoi(renderer.Index.String()); err == nil {
+		video.Position = index
+	}
+	if length, err := strconv.ParseFloat(renderer.LengthSeconds, 64); err == nil {
+		video.DurationSeconds = length
+	}
+	return video
+}
+
+// selectPlaylistVideos orders the entries and keeps up to maxVideos of them, returning how many
+// unavailable entries were skipped
+func selectPlaylistVideos(videos []models.PlaylistVideo, order string, maxVideos int, skipUnavailable bool) ([]models.PlaylistVideo, int) {
+	ordered := make([]models.PlaylistVideo, 0, len(videos))
+	skipped := 0
+	for _, video := range videos {
+		if skipUnavailable && !video.Available {
+			skipped++
+			continue
+		}
+		ordered = append(ordered, video)
+	}
+
+	switch order {
+	case models.PlaylistOrderReverse:
+		for i, j := 0, len(ordered)-1; i < j; i, j = i+1, j-1 {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		}
+	case models.PlaylistOrderShortest:
+		sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].DurationSeconds < ordered[j].DurationSeconds })
+	case models.PlaylistOrderLongest:
+		sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].DurationSeconds > ordered[j].DurationSeconds })
+	}
+
+	if maxVideos > 0 && len(ordered) > maxVideos {
+		ordered = ordered[:maxVideos]
+	}
+	return ordered, skipped
+}
+
+// orderBatchResults sorts batch results, which arrive in completion order, into the order of videoIDs
+func orderBatchResults(batch *models.MultipleTranscriptResponse, videoIDs []string) {
+	rank := make(map[string]int, len(videoIDs))
+	for i, videoID := range videoIDs {
+		if _, seen := rank[videoID]; !seen {
+			rank[videoID] = i
+		}
+	}
+	sort.SliceStable(batch.Results, func(i, j int) bool {
+		return rank[batch.Results[i].VideoID] < rank[batch.Results[j].VideoID]
+	})
+	sort.SliceStable(batch.Errors, func(i, j int) bool {
+		return rank[batch.Errors[i].VideoID] < rank[batch.Errors[j].VideoID]
+	})
+}
+
+// extractPlaylistID extracts a playlist ID from a playlist URL, a watch URL with list=, or a bare ID
+func extractPlaylistID(identifier string) (string, error) {
+	identifier = strings.TrimSpace(identifier)
+	if playlistIDPattern.MatchString(identifier) {
+		return identifier, nil
+	}
+
+	if strings.Contains(identifier, "list=") {
+		raw := identifier
+		if !strings.Contains(raw, "://") {
+			raw = "https://" + raw
+		}
+		if parsed, err := url.Parse(raw); err == nil {
+			if list := parsed.Query().Get("list"); list != "" {
+				return list, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("could not extract playlist ID from: %s", identifier)
+}
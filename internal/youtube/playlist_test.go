@@ -0,0 +1,161 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/youtube-transcript-mcp/internal/cache"
+	"github.com/youtube-transcript-mcp/internal/config"
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// newTestService returns a service whose YouTube requests go to handler
+func newTestService(t *testing.T, handler http.Handler) *Service {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	memoryCache := cache.NewMemoryCache(100, 10, time.Hour)
+	t.Cleanup(func() { _ = memoryCache.Close() })
+
+	service := NewService(config.YouTubeConfig{
+		RequestTimeout: 5 * time.Second,
+		MaxConcurrent:  2,
+		RetryAttempts:  1,
+		RetryDelay:     time.Millisecond,
+		UserAgent:      "test-agent",
+	}, memoryCache, setupTestLogger())
+	service.baseURL = server.URL
+	return service
+}
+
+func TestExtractPlaylistID(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"PLrAXtmErZgOeiKm4sgNOknGvNjby9efdf", "PLrAXtmErZgOeiKm4sgNOknGvNjby9efdf", false},
+		{"https://www.youtube.com/playlist?list=PLrAXtmErZgOeiKm4sgNOknGvNjby9efdf", "PLrAXtmErZgOeiKm4sgNOknGvNjby9efdf", false},
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ&list=UUuAXFkgsw1L7xaCfnd5JJOw&index=2", "UUuAXFkgsw1L7xaCfnd5JJOw", false},
+		{"youtube.com/playlist?list=OLAK5uy_abcdefghijklmn", "OLAK5uy_abcdefghijklmn", false},
+		{"dQw4w9WgXcQ", "", true},
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := extractPlaylistID(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extractPlaylistID(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("extractPlaylistID(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSelectPlaylistVideos(t *testing.T) {
+	videos := []models.PlaylistVideo{
+		{VideoID: "a", Position: 1, DurationSeconds: 300, Available: true},
+		{VideoID: "b", Position: 2, Available: false},
+		{VideoID: "c", Position: 3, DurationSeconds: 60, Available: true},
+		{VideoID: "d", Position: 4, DurationSeconds: 900, Available: true},
+	}
+
+	tests := []struct {
+		name            string
+		order           string
+		maxVideos       int
+		skipUnavailable bool
+		expected        []string
+		expectedSkipped int
+	}{
+		{"playlist order", models.PlaylistOrderPlaylist, 10, false, []string{"a", "b", "c", "d"}, 0},
+		{"skip unavailable", models.PlaylistOrderPlaylist, 10, true, []string{"a", "c", "d"}, 1},
+		{"reverse with limit", models.PlaylistOrderReverse, 2, true, []string{"d", "c"}, 1},
+		{"shortest", models.PlaylistOrderShortest, 2, true, []string{"c", "a"}, 1},
+		{"longest", models.PlaylistOrderLongest, 1, true, []string{"d"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, skipped := selectPlaylistVideos(videos, tt.order, tt.maxVideos, tt.skipUnavailable)
+			ids := make([]string, len(selected))
+			for i, video := range selected {
+				ids[i] = video.VideoID
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected %v, got %v", tt.expected, ids)
+			}
+			if skipped != tt.expectedSkipped {
+				t.Errorf("Expected %d skipped, got %d", tt.expectedSkipped, skipped)
+			}
+		})
+	}
+}
+
+func TestListPlaylistVideosFollowsContinuations(t *testing.T) {
+	playlistPage := `<html><script>ytcfg.set({"INNERTUBE_API_KEY":"test-key","INNERTUBE_CLIENT_VERSION":"2.20250101.00.00"});</script>` +
+		`<script>var ytInitialData = {"metadata":{"playlistMetadataRenderer":{"title":"My List"}},"contents":{"twoColumnBrowseResultsRenderer":{"tabs":[{"tabRenderer":{"selected":true,"content":{"sectionListRenderer":{"contents":[{"itemSectionRenderer":{"contents":[{"playlistVideoListRenderer":{"contents":[` +
+		`{"playlistVideoRenderer":{"videoId":"aaaaaaaaaaa","title":{"runs":[{"text":"First"}]},"index":{"simpleText":"1"},"lengthSeconds":"61","isPlayable":true}},` +
+		`{"playlistVideoRenderer":{"videoId":"bbbbbbbbbbb","title":{"runs":[{"text":"[Private video]"}]},"index":{"simpleText":"2"},"isPlayable":false}},` +
+		`{"continuationItemRenderer":{"continuationEndpoint":{"continuationCommand":{"token":"page-2"}}}}` +
+		`]}}]}}]}}}}]}}};</script></html>`
+	continuation := `{"onResponseReceivedActions":[{"appendContinuationItemsAction":{"continuationItems":[` +
+		`{"playlistVideoRenderer":{"videoId":"ccccccccccc","title":{"simpleText":"Third"},"index":{"simpleText":"3"},"lengthSeconds":"30","isPlayable":true}}]}}]}`
+
+	var continuationRequests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/playlist", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("list") != "PLtest1234567890" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, playlistPage)
+	})
+	mux.HandleFunc("/youtubei/v1/browse", func(w http.ResponseWriter, r *http.Request) {
+		continuationRequests++
+		if r.URL.Query().Get("key") != "test-key" {
+			t.Errorf("Expected API key in request, got %q", r.URL.RawQuery)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["continuation"] != "page-2" {
+			t.Errorf("Unexpected continuation body: %v (%v)", body, err)
+		}
+		_, _ = io.WriteString(w, continuation)
+	})
+
+	service := newTestService(t, mux)
+
+	title, videos, err := service.listPlaylistVideos(context.Background(), "PLtest1234567890", 0, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if title != "My List" {
+		t.Errorf("Expected title 'My List', got %q", title)
+	}
+	if len(videos) != 3 || continuationRequests != 1 {
+		t.Fatalf("Expected 3 videos from 1 continuation, got %d from %d: %+v", len(videos), continuationRequests, videos)
+	}
+	if videos[1].Available || videos[2].Position != 3 || videos[2].DurationSeconds != 30 {
+		t.Errorf("Unexpected videos: %+v", videos)
+	}
+
+	// A limit reached on the first page stops paging
+	_, videos, err = service.listPlaylistVideos(context.Background(), "PLtest1234567890", 2, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(videos) != 2 || continuationRequests != 1 {
+		t.Errorf("Expected paging to stop at the limit, got %d videos and %d continuations", len(videos), continuationRequests)
+	}
+}
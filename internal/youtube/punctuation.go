@@ -0,0 +1,290 @@
+package youtube
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// spokenWordPattern matches a word of caption text
+var spokenWordPattern = regexp.MustCompile(`\S+`)
+
+// Pauses between caption segments, in seconds, that suggest a sentence boundary
+const (
+	sentencePauseStrong = 1.0
+	sentencePauseWeak   = 0.5
+)
+
+// Sentence lengths, in words, that restoring punctuation aims for
+const (
+	minSentenceWords  = 3
+	longSentenceWords = 20
+	maxSentenceWords  = 40
+)
+
+// discourseMarkers are words that often open a new sentence in speech, weighted by how strongly
+// they suggest one
+var discourseMarkers = map[string]float64{
+	"okay":      1,
+	"ok":        1,
+	"alright":   1,
+	"anyway":    1,
+	"anyways":   1,
+	"so":        0.6,
+	"now":       0.6,
+	"well":      0.6,
+	"actually":  0.6,
+	"basically": 0.6,
+	"however":   0.6,
+	"finally":   0.6,
+	"meanwhile": 0.6,
+}
+
+// interjections are sentence openers followed by a comma
+var interjections = map[string]bool{
+	"okay":    true,
+	"ok":      true,
+	"alright": true,
+	"anyway":  true,
+	"yeah":    true,
+	"well":    true,
+}
+
+// danglingWords are words a sentence does not end on
+var danglingWords = map[string]bool{
+	"a": true, "an": true, "the": true, "and": true, "or": true, "but": true, "of": true,
+	"to": true, "in": true, "on": true, "at": true, "for": true, "with": true, "from": true,
+	"my": true, "your": true, "our": true, "their": true, "his": true, "her": true, "its": true,
+	"is": true, "are": true, "was": true, "were": true, "that": true, "because": true, "if": true,
+}
+
+// questionOpeners are auxiliary verbs that open a question when a pronoun follows them
+var questionOpeners = map[string]bool{
+	"do": true, "does": true, "did": true, "is": true, "are": true, "was": true, "were": true,
+	"can": true, "could": true, "would": true, "should": true, "will": true, "have": true, "has": true,
+}
+
+// questionSubjects are the pronouns that follow a question opener
+var questionSubjects = map[string]bool{
+	"you": true, "i": true, "we": true, "they": true, "he": true, "she": true, "it": true,
+	"that": true, "this": true, "there": true, "anyone": true, "anybody": true,
+}
+
+// restoredWord is a word of a transcript with its estimated start and end times
+type restoredWord struct {
+	text  string
+	start float64
+	end   float64
+	// gap is the silence before the word, known at segment boundaries
+	gap float64
+	// speaker marks the first word after a speaker change
+	speaker bool
+	// annotation marks a sound event such as [Music] rather than a spoken word
+	annotation bool
+}
+
+// restoredSentence is a sentence rebuilt from words that may span caption segments
+type restoredSentence struct {
+	text          string
+	start         float64
+	end           float64
+	speakerChange bool
+}
+
+// needsPunctuation reports whether segments are automatic captions without sentence punctuation
+func needsPunctuation(transcriptType string, segments []models.TranscriptSegment) bool {
+	if transcriptType != models.TranscriptTypeAuto {
+		return false
+	}
+	words, marks := 0, 0
+	for _, segment := range segments {
+		for _, word := range strings.Fields(segment.Text) {
+			words++
+			if strings.ContainsAny(word[len(word)-1:], ".?!") {
+				marks++
+			}
+		}
+	}
+	// Punctuated speech ends a sentence every few dozen words at most
+	return words > 0 && marks*maxSentenceWords < words
+}
+
+// restoreSentences rebuilds the sentences of unpunctuated captions. Boundaries are placed where
+// pauses between segments, capitalized words and discourse markers such as "so" or "okay"
+// suggest them, never on words like "the" that leave a sentence unfinished. Each sentence is
+// capitalized, ends in a period or question mark and starts at the estimated time of its first
+// word.
+func restoreSentences(segments []models.TranscriptSegment) []restoredSentence {
+	var sentences []restoredSentence
+	var current []restoredWord
+	speakerChange := false
+
+	flush := func() {
+		if len(current) > 0 {
+			sentences = append(sentences, restoredSentence{
+				text:          punctuateSentence(current),
+				start:         current[0].start,
+				end:           current[len(current)-1].end,
+				speakerChange: speakerChange,
+			})
+		}
+		current, speakerChange = nil, false
+	}
+
+	for _, word := range restorableWords(segments) {
+		switch {
+		case word.annotation && word.text == speakerChangeMarker:
+			flush()
+			speakerChange = true
+			continue
+		case word.annotation:
+			flush()
+			sentences = append(sentences, restoredSentence{text: word.text, start: word.start, end: word.end})
+			continue
+		case word.speaker:
+			flush()
+			speakerChange = true
+		case len(current) > 0 && sentenceBreaksBefore(current, word):
+			flush()
+		}
+		current = append(current, word)
+	}
+	flush()
+
+	return sentences
+}
+
+// restorableWords splits segments into words and annotations, spreading each segment's duration
+// over its words by their length
+func restorableWords(segments []models.TranscriptSegment) []restoredWord {
+	var words []restoredWord
+	previousEnd := -1.0
+	for _, segment := range segments {
+		length := utf8.RuneCountInString(segment.Text)
+		first := len(words)
+
+		offset := 0
+		addWord := func(text string, position int, annotation bool) {
+			start := segment.Start
+			if length > 0 {
+				start += segment.Duration * float64(utf8.RuneCountInString(segment.Text[:position])) / float64(length)
+			}
+			words = append(words, restoredWord{text: text, start: start, annotation: annotation})
+		}
+		addSpeech := func(text string, base int) {
+			for _, loc := range spokenWordPattern.FindAllStringIndex(text, -1) {
+				addWord(text[loc[0]:loc[1]], base+loc[0], false)
+			}
+		}
+		for _, loc := range annotationPattern.FindAllStringIndex(segment.Text, -1) {
+			addSpeech(segment.Text[offset:loc[0]], offset)
+			addWord(segment.Text[loc[0]:loc[1]], loc[0], true)
+			offset = loc[1]
+		}
+		addSpeech(segment.Text[offset:], offset)
+
+		// Each word lasts until the next one of its segment starts
+		for k := first; k < len(words); k++ {
+			words[k].end = segmentEnd(segment)
+			if k+1 < len(words) {
+				words[k].end = words[k+1].start
+			}
+		}
+		if first < len(words) {
+			if previousEnd >= 0 {
+				words[first].gap = max(0, segment.Start-previousEnd)
+			}
+			words[first].speaker = segment.SpeakerChange && !strings.Contains(segment.Text, speakerChangeMarker)
+		}
+		previousEnd = segmentEnd(segment)
+	}
+	return words
+}
+
+// sentenceBreaksBefore reports whether a sentence of the current words ends before next
+func sentenceBreaksBefore(current []restoredWord, next restoredWord) bool {
+	last := current[len(current)-1].text
+	if strings.ContainsAny(last[len(last)-1:], ".?!") {
+		return true
+	}
+	if len(current) >= maxSentenceWords {
+		return true
+	}
+	if len(current) < minSentenceWords {
+		return next.gap >= sentencePauseStrong
+	}
+
+	score := 0.0
+	switch {
+	case next.gap >= sentencePauseStrong:
+		score += 1
+	case next.gap >= sentencePauseWeak:
+		score += 0.6
+	}
+	score += discourseMarkers[normalizeWord(next.text)]
+	if isCapitalizedWord(next.text) {
+		score += 0.4
+	}
+	if len(current) >= longSentenceWords {
+		score += 0.5
+	}
+	if danglingWords[normalizeWord(last)] {
+		score -= 1
+	}
+	return score >= 1
+}
+
+// isCapitalizedWord reports whether a word starts with a capital letter, other than the pronoun I
+func isCapitalizedWord(word string) bool {
+	first, _ := utf8.DecodeRuneInString(word)
+	if !unicode.IsUpper(first) {
+		return false
+	}
+	key := normalizeWord(word)
+	return key != "i" && !strings.HasPrefix(key, "i'")
+}
+
+// punctuateSentence joins words into a sentence with a capital letter, an end mark and a comma
+// after an opening interjection
+func punctuateSentence(words []restoredWord) string {
+	texts := make([]string, len(words))
+	for i, word := range words {
+		texts[i] = word.text
+		if key := normalizeWord(word.text); key == "i" || strings.HasPrefix(key, "i'") {
+			texts[i] = "I" + word.text[1:]
+		}
+	}
+
+	first := normalizeWord(texts[0])
+	if len(texts) > 1 && interjections[first] && !strings.ContainsAny(texts[0], ",.?!") {
+		texts[0] += ","
+	}
+	r, size := utf8.DecodeRuneInString(texts[0])
+	texts[0] = string(unicode.ToUpper(r)) + texts[0][size:]
+
+	text := strings.Join(texts, " ")
+	if strings.ContainsAny(text[len(text)-1:], ".?!") {
+		return text
+	}
+	if first == "why" || (len(texts) > 1 && questionOpeners[first] && questionSubjects[normalizeWord(texts[1])]) {
+		return text + "?"
+	}
+	return text + "."
+}
+
+// formatRestoredSentences renders the restored sentences of unpunctuated captions, one per line
+func (s *Service) formatRestoredSentences(segments []models.TranscriptSegment, includeTimestamps bool) string {
+	var builder strings.Builder
+	for _, sentence := range restoreSentences(segments) {
+		if includeTimestamps {
+			builder.WriteString(fmt.Sprintf("[%.1fs] ", sentence.start))
+		}
+		builder.WriteString(sentence.text)
+		builder.WriteString("\n")
+	}
+	return strings.TrimSpace(builder.String())
+}
@@ -0,0 +1,138 @@
+package youtube
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestRestoreSentences(t *testing.T) {
+	tests := []struct {
+		name     string
+		segments []models.TranscriptSegment
+		texts    []string
+		starts   []float64
+	}{
+		{
+			name: "pause between segments",
+			segments: []models.TranscriptSegment{
+				{Text: "welcome back to the channel", Start: 0, Duration: 2},
+				{Text: "today we are making bread", Start: 3.5, Duration: 2},
+			},
+			texts:  []string{"Welcome back to the channel.", "Today we are making bread."},
+			starts: []float64{0, 3.5},
+		},
+		{
+			name: "sentence spans segments and starts mid-segment",
+			segments: []models.TranscriptSegment{
+				{Text: "first you mix the flour and", Start: 0, Duration: 2.7},
+				{Text: "the water okay now let it", Start: 2.7, Duration: 2.5},
+				{Text: "rest for an hour", Start: 5.2, Duration: 2},
+			},
+			texts:  []string{"First you mix the flour and the water.", "Okay, now let it rest for an hour."},
+			starts: []float64{0, 3.7},
+		},
+		{
+			name: "no break on a dangling word despite a pause",
+			segments: []models.TranscriptSegment{
+				{Text: "i went to the", Start: 0, Duration: 2},
+				{Text: "store yesterday", Start: 2.8, Duration: 1},
+			},
+			texts:  []string{"I went to the store yesterday."},
+			starts: []float64{0},
+		},
+		{
+			name: "question and capitalized word",
+			segments: []models.TranscriptSegment{
+				{Text: "do you know what this is I think", Start: 0, Duration: 3},
+				{Text: "it's a sourdough starter from Paris", Start: 3, Duration: 2},
+				{Text: "Paris has the best ones", Start: 5.6, Duration: 2},
+			},
+			texts:  []string{"Do you know what this is I think it's a sourdough starter from Paris?", "Paris has the best ones."},
+			starts: []float64{0, 5.6},
+		},
+		{
+			name: "annotations and speaker changes",
+			segments: []models.TranscriptSegment{
+				{Text: "[Music]", Start: 0, Duration: 2},
+				{Text: "so good to see you", Start: 2, Duration: 2},
+				{Text: ">> thanks for having me", Start: 4, Duration: 2, SpeakerChange: true},
+			},
+			texts:  []string{"[Music]", "So good to see you.", "Thanks for having me."},
+			starts: []float64{0, 2, 4.26},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sentences := restoreSentences(tt.segments)
+			var texts []string
+			var starts []float64
+			for _, sentence := range sentences {
+				texts = append(texts, sentence.text)
+				starts = append(starts, roundHundredths(sentence.start))
+			}
+			if !reflect.DeepEqual(texts, tt.texts) {
+				t.Errorf("texts = %q, want %q", texts, tt.texts)
+			}
+			if !reflect.DeepEqual(starts, tt.starts) {
+				t.Errorf("starts = %v, want %v", starts, tt.starts)
+			}
+		})
+	}
+}
+
+func TestNeedsPunctuation(t *testing.T) {
+	unpunctuated := []models.TranscriptSegment{{Text: "so this is how it works"}, {Text: "you take the thing and"}}
+	punctuated := []models.TranscriptSegment{{Text: "So this is how it works."}, {Text: "You take the thing."}}
+
+	tests := []struct {
+		transcriptType string
+		segments       []models.TranscriptSegment
+		expected       bool
+	}{
+		{models.TranscriptTypeAuto, unpunctuated, true},
+		{models.TranscriptTypeAuto, punctuated, false},
+		{models.TranscriptTypeManual, unpunctuated, false},
+		{models.TranscriptTypeAuto, nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := needsPunctuation(tt.transcriptType, tt.segments); got != tt.expected {
+			t.Errorf("needsPunctuation(%q, %v) = %v, want %v", tt.transcriptType, tt.segments, got, tt.expected)
+		}
+	}
+}
+
+func TestRenderSegmentsRestoresPunctuation(t *testing.T) {
+	s := &Service{}
+	segments := []models.TranscriptSegment{
+		{Text: "welcome back everyone", Start: 0, Duration: 2},
+		{Text: "today we bake bread", Start: 3.5, Duration: 2},
+	}
+
+	tests := []struct {
+		formatType     string
+		transcriptType string
+		expected       string
+	}{
+		{models.FormatTypeSentences, models.TranscriptTypeAuto, "[0.0s] Welcome back everyone.\n[3.5s] Today we bake bread."},
+		{models.FormatTypeParagraphs, models.TranscriptTypeAuto, "[0.0s] Welcome back everyone. Today we bake bread."},
+		{models.FormatTypeSentences, models.TranscriptTypeManual, "[0.0s] welcome back everyone.\n[3.5s] today we bake bread."},
+	}
+
+	for _, tt := range tests {
+		got, err := s.renderSegments(segments, renderOptions{
+			formatType:        tt.formatType,
+			includeTimestamps: true,
+			transcriptType:    tt.transcriptType,
+		})
+		if err != nil {
+			t.Fatalf("renderSegments(%q) error = %v", tt.formatType, err)
+		}
+		if got != tt.expected {
+			t.Errorf("renderSegments(%q, %q) = %q, want %q", tt.formatType, tt.transcriptType, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package youtube
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// Costs of the edits allowed when aligning a quote with a transcript. Quotes tend to leave out
+// words that were said rather than add new ones, filler words most of all, and speech recognition
+// splits and merges words ("every one" for "everyone"), so those edits cost less than a wrong or
+// missing word.
+const (
+	quoteEditCost   = 1.0
+	quoteExtraCost  = 0.75
+	quoteFillerCost = 0.25
+	quoteSplitCost  = 0.1
+)
+
+// quoteToken is a normalized word of a quote or transcript. Transcript tokens also keep their
+// original text and the index of their segment.
+type quoteToken struct {
+	key     string
+	text    string
+	segment int
+}
+
+// quoteSpan is an aligned stretch of transcript tokens, from start up to but excluding end
+type quoteSpan struct {
+	start int
+	end   int
+	cost  float64
+}
+
+// LocateQuote finds where a possibly misremembered quote was said in a video. Words are compared
+// after removing case, punctuation and diacritics, and each word may differ by a few characters,
+// so quotes still match automatic captions with recognition errors and quotes that run across
+// caption segments.
+func (s *Service) LocateQuote(ctx context.Context, videoIdentifier string, opts models.QuoteOptions) (*models.LocateQuoteResponse, error) {
+	if opts.MaxResults <= 0 {
+		opts.MaxResults = models.DefaultQuoteMatches
+	}
+
+	quote := quoteTokens(opts.Quote, -1)
+	if len(quote) == 0 {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeValidationError,
+			Message: "The quote has no words to look for",
+			VideoID: videoIdentifier,
+		}
+	}
+
+	transcript, err := s.GetTranscript(ctx, videoIdentifier, opts.Languages, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.LocateQuoteResponse{
+		VideoID:     transcript.VideoID,
+		Title:       transcript.Title,
+		ChannelName: transcript.Metadata.ChannelName,
+		Language:    transcript.Language,
+		Quote:       opts.Quote,
+		Matches:     locateQuote(transcript, quote, opts),
+	}, nil
+}
+
+// locateQuote returns the best non-overlapping matches of a quote in a transcript, most confident
+// first
+func locateQuote(transcript *models.TranscriptResponse, quote []quoteToken, opts models.QuoteOptions) []models.QuoteMatch {
+	var words []quoteToken
+	for i, segment := range transcript.Transcript {
+		words = append(words, quoteTokens(stripAnnotations(segment.Text), i)...)
+	}
+
+	spans := alignQuote(quote, words)
+	sort.Slice(spans, func(i, j int) bool {
+		if spans[i].cost != spans[j].cost {
+			return spans[i].cost < spans[j].cost
+		}
+		if spans[i].end-spans[i].start != spans[j].end-spans[j].start {
+			return spans[i].end-spans[i].start < spans[j].end-spans[j].start
+		}
+		return spans[i].start < spans[j].start
+	})
+
+	matches := make([]models.QuoteMatch, 0, opts.MaxResults)
+	var selected []quoteSpan
+	for _, span := range spans {
+		if len(matches) == opts.MaxResults {
+			break
+		}
+		confidence := roundRatio(max(0, 1-span.cost/float64(len(quote))))
+		if confidence < opts.MinConfidence {
+			break
+		}
+		overlaps := false
+		for _, other := range selected {
+			if span.start < other.end && other.start < span.end {
+				overlaps = true
+				break
+			}
+		}
+		if overlaps {
+			continue
+		}
+		selected = append(selected, span)
+
+		first, last := words[span.start].segment, words[span.end-1].segment
+		texts := make([]string, 0, span.end-span.start)
+		for _, word := range words[span.start:span.end] {
+			texts = append(texts, word.text)
+		}
+		match := models.QuoteMatch{
+			Text:         joinTerms(texts),
+			Confidence:   confidence,
+			Start:        transcript.Transcript[first].Start,
+			End:          segmentEnd(transcript.Transcript[last]),
+			SegmentIndex: first,
+			SegmentCount: last - first + 1,
+			URL:          buildTimestampURL(transcript.VideoID, transcript.Transcript[first].Start),
+		}
+		match.Citation = quoteCitation(transcript, match)
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// alignQuote aligns a quote with every stretch of the transcript using a word-level edit distance
+// that may start and end anywhere in the transcript. It returns the cheapest span ending at each
+// transcript token.
+func alignQuote(quote, words []quoteToken) []quoteSpan {
+	m := len(quote)
+	costs := make([][]float64, 3)
+	starts := make([][]int, 3)
+	for k := range costs {
+		costs[k] = make([]float64, m+1)
+		starts[k] = make([]int, m+1)
+	}
+	// Before the first transcript token every quote word is missing
+	for i := range costs[0] {
+		costs[0][i] = float64(i) * quoteEditCost
+	}
+
+	distances := make([]map[string]float64, m)
+	for i := range distances {
+		distances[i] = make(map[string]float64)
+	}
+	wordCost := func(i int, key string) float64 {
+		cost, ok := distances[i][key]
+		if !ok {
+			cost = wordDistance(quote[i].key, key)
+			distances[i][key] = cost
+		}
+		return cost
+	}
+
+	spans := make([]quoteSpan, 0, len(words))
+	for j := 1; j <= len(words); j++ {
+		before, previous, current := (j+1)%3, (j+2)%3, j%3
+		word := words[j-1]
+		extraCost := quoteExtraCost
+		if fillerWords[word.key] {
+			extraCost = quoteFillerCost
+		}
+
+		costs[current][0], starts[current][0] = 0, j
+		for i := 1; i <= m; i++ {
+			best, start := costs[previous][i-1]+wordCost(i-1, word.key), starts[previous][i-1]
+			if cost := costs[current][i-1] + quoteEditCost; cost < best {
+				best, start = cost, starts[current][i-1]
+			}
+			if cost := costs[previous][i] + extraCost; cost < best {
+				best, start = cost, starts[previous][i]
+			}
+			// One quote word said as two transcript words, or two quote words as one
+			if j >= 2 && quote[i-1].key == words[j-2].key+word.key {
+				if cost := costs[before][i-1] + quoteSplitCost; cost < best {
+					best, start = cost, starts[before][i-1]
+				}
+			}
+			if i >= 2 && word.key == quote[i-2].key+quote[i-1].key {
+				if cost := costs[previous][i-2] + quoteSplitCost; cost < best {
+					best, start = cost, starts[previous][i-2]
+				}
+			}
+			costs[current][i], starts[current][i] = best, start
+		}
+
+		if starts[current][m] < j {
+			spans = append(spans, quoteSpan{start: starts[current][m], end: j, cost: costs[current][m]})
+		}
+	}
+	return spans
+}
+
+// wordDistance is the cost of hearing word b where the quote says a: the share of characters that
+// differ, or a full edit when most of them do
+func wordDistance(a, b string) float64 {
+	if a == b {
+		return 0
+	}
+	first, second := []rune(a), []rune(b)
+	ratio := float64(levenshtein(first, second)) / float64(max(len(first), len(second)))
+	if ratio >= 0.5 {
+		return quoteEditCost
+	}
+	return ratio * quoteEditCost
+}
+
+// levenshtein returns the number of single character edits between two words
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitution := previous[j-1]
+			if a[i-1] != b[j-1] {
+				substitution++
+			}
+			current[j] = min(substitution, previous[j]+1, current[j-1]+1)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// quoteTokens splits text into normalized words. Chinese and Japanese are written without spaces,
+// so each of their characters is a token of its own.
+func quoteTokens(text string, segment int) []quoteToken {
+	var tokens []quoteToken
+	for _, field := range strings.Fields(strings.ReplaceAll(text, "’", "'")) {
+		if !strings.ContainsFunc(field, isSpacelessScript) {
+			if key := quoteKey(field); key != "" {
+				tokens = append(tokens, quoteToken{key: key, text: field, segment: segment})
+			}
+			continue
+		}
+
+		var run []rune
+		flush := func() {
+			if key := quoteKey(string(run)); key != "" {
+				tokens = append(tokens, quoteToken{key: key, text: string(run), segment: segment})
+			}
+			run = run[:0]
+		}
+		for _, r := range field {
+			if !isSpacelessScript(r) {
+				run = append(run, r)
+				continue
+			}
+			flush()
+			tokens = append(tokens, quoteToken{key: string(r), text: string(r), segment: segment})
+		}
+		flush()
+	}
+	return tokens
+}
+
+// quoteKey normalizes a word for matching: lower case, without surrounding punctuation,
+// apostrophes or diacritics
+func quoteKey(word string) string {
+	return foldDiacritics(strings.ReplaceAll(normalizeWord(word), "'", ""))
+}
+
+// isSpacelessScript reports whether a character belongs to a script written without spaces
+// between words
+func isSpacelessScript(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// quoteCitation formats a match as a citation: the quote, the channel, title, site and
+// publication date of the video, the time it was said and its link
+func quoteCitation(transcript *models.TranscriptResponse, match models.QuoteMatch) string {
+	var source []string
+	if transcript.Metadata.ChannelName != "" {
+		source = append(source, transcript.Metadata.ChannelName)
+	}
+	if transcript.Title != "" {
+		source = append(source, `"`+transcript.Title+`"`)
+	}
+	source = append(source, "YouTube")
+	if published, err := models.ParseDate(transcript.Metadata.PublishedAt); err == nil {
+		source = append(source, published.Format(time.DateOnly))
+	}
+	source = append(source, "at "+formatClock(match.Start))
+
+	return `"` + match.Text + `" - ` + strings.Join(source, ", ") + ". " + match.URL
+}
@@ -0,0 +1,179 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestLocateQuote(t *testing.T) {
+	transcript := &models.TranscriptResponse{
+		VideoID: "dQw4w9WgXcQ",
+		Title:   "Talk",
+		Transcript: []models.TranscriptSegment{
+			{Text: "[Applause]", Start: 0, Duration: 2},
+			{Text: "so the thing about", Start: 2, Duration: 3},
+			{Text: "um the future is that it's", Start: 5, Duration: 3},
+			{Text: "already here it's just not evenly", Start: 8, Duration: 3},
+			{Text: "distributed you know", Start: 11, Duration: 2},
+			{Text: "every one of us can help", Start: 20, Duration: 3},
+			{Text: "the future is already here", Start: 40, Duration: 3},
+			{Text: "café au lait", Start: 50, Duration: 2},
+			{Text: "今日は良い天気です", Start: 60, Duration: 2},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		quote      string
+		maxResults int
+		expected   []string // text of each match, best first
+		start      float64
+		count      int
+		confidence float64
+	}{
+		{
+			name:       "exact quote across segments with a filler",
+			quote:      "The future is already here — it's just not very evenly distributed.",
+			maxResults: 1,
+			expected:   []string{"the future is that it's already here it's just not evenly distributed"},
+			start:      5,
+			count:      3,
+		},
+		{
+			name:       "repeated phrase returns both places",
+			quote:      "the future is already here",
+			maxResults: 3,
+			expected:   []string{"the future is already here", "the future is that it's already here"},
+			start:      40,
+			count:      1,
+			confidence: 1,
+		},
+		{
+			name:       "recognition error and split word",
+			quote:      "Everyone of us can halp",
+			maxResults: 1,
+			expected:   []string{"every one of us can help"},
+			start:      20,
+			count:      1,
+		},
+		{
+			name:       "diacritics",
+			quote:      "cafe au lait",
+			maxResults: 1,
+			expected:   []string{"café au lait"},
+			start:      50,
+			count:      1,
+			confidence: 1,
+		},
+		{
+			name:       "japanese without spaces",
+			quote:      "良い天気",
+			maxResults: 1,
+			expected:   []string{"良い天気"},
+			start:      60,
+			count:      1,
+			confidence: 1,
+		},
+		{
+			name:       "unrelated quote",
+			quote:      "ask not what your country can do for you",
+			maxResults: 3,
+			expected:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := locateQuote(transcript, quoteTokens(tt.quote, -1), models.QuoteOptions{
+				MaxResults:    tt.maxResults,
+				MinConfidence: models.DefaultQuoteConfidence,
+			})
+			if len(matches) != len(tt.expected) {
+				t.Fatalf("Expected %d matches, got %+v", len(tt.expected), matches)
+			}
+			for i, match := range matches {
+				if match.Text != tt.expected[i] {
+					t.Errorf("Match %d: expected %q, got %q", i, tt.expected[i], match.Text)
+				}
+			}
+			if len(matches) == 0 {
+				return
+			}
+			if matches[0].Start != tt.start || matches[0].SegmentCount != tt.count {
+				t.Errorf("Expected start %v over %d segments, got %+v", tt.start, tt.count, matches[0])
+			}
+			if tt.confidence != 0 && matches[0].Confidence != tt.confidence {
+				t.Errorf("Expected confidence %v, got %v", tt.confidence, matches[0].Confidence)
+			}
+		})
+	}
+}
+
+func TestAlignQuote_Costs(t *testing.T) {
+	words := quoteTokens("we choose to go to the moon", 0)
+	tests := []struct {
+		quote string
+		cost  float64
+	}{
+		{quote: "to go to the moon", cost: 0},
+		{quote: "to go to the mon", cost: 0.25},       // one character of four
+		{quote: "to go to moon", cost: 0.75},          // the transcript has an extra word
+		{quote: "to go to the big moon", cost: 1},     // the quote has an extra word
+		{quote: "choose togo to the moon", cost: 0.1}, // two transcript words as one quote word
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.quote, func(t *testing.T) {
+			spans := alignQuote(quoteTokens(tt.quote, -1), words)
+			best := spans[0]
+			for _, span := range spans {
+				if span.cost < best.cost {
+					best = span
+				}
+			}
+			if best.cost != tt.cost || best.end != len(words) {
+				t.Errorf("Expected cost %v ending at the last word, got %+v", tt.cost, best)
+			}
+		})
+	}
+}
+
+func TestLocateQuote_Citation(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/watch", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<script>var ytInitialPlayerResponse = {"videoDetails":{"videoId":"dQw4w9WgXcQ","title":"Keynote","author":"Tech Talks"},`+
+			`"microformat":{"playerMicroformatRenderer":{"publishDate":"2024-03-05"}},"captions":{"playerCaptionsTracklistRenderer":{`+
+			`"captionTracks":[{"baseUrl":"http://%s/api/timedtext?lang=en","languageCode":"en","isDefault":true}]}}};</script>`, r.Host)
+	})
+	mux.HandleFunc("/api/timedtext", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `<transcript><text start="1" dur="4">hello there</text><text start="754.2" dur="3">stay hungry</text>`+
+			`<text start="757.2" dur="2">stay foolish</text></transcript>`)
+	})
+
+	service := newTestService(t, mux)
+	response, err := service.LocateQuote(context.Background(), "dQw4w9WgXcQ", models.QuoteOptions{Quote: "Stay hungry, stay foolish!", MinConfidence: 0.5})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(response.Matches) != 1 {
+		t.Fatalf("Expected one match, got %+v", response.Matches)
+	}
+
+	match := response.Matches[0]
+	if match.Start != 754.2 || match.End != 759.2 || match.URL != "https://youtu.be/dQw4w9WgXcQ?t=754" {
+		t.Errorf("Unexpected timing: %+v", match)
+	}
+	expected := `"stay hungry stay foolish" - Tech Talks, "Keynote", YouTube, 2024-03-05, at 12:34. https://youtu.be/dQw4w9WgXcQ?t=754`
+	if match.Citation != expected {
+		t.Errorf("Expected citation %q, got %q", expected, match.Citation)
+	}
+
+	_, err = service.LocateQuote(context.Background(), "dQw4w9WgXcQ", models.QuoteOptions{Quote: "!?"})
+	if transcriptErr, ok := err.(*models.TranscriptError); !ok || transcriptErr.Type != models.ErrorTypeValidationError {
+		t.Errorf("Expected a validation error, got %v", err)
+	}
+}
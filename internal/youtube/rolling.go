@@ -0,0 +1,96 @@
+package youtube
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// mergeRollingSegments removes the words automatic captions repeat from one rolling caption window
+// to the next, so "the quick" followed by "the quick brown fox" reads "the quick" and "brown fox".
+// A segment that starts with the words ending the window before has them removed and starts later
+// by the share of its text they took; a segment that only repeats earlier words is dropped and the
+// earlier segment extended to its end. A single repeated word only counts when the windows overlap
+// in time, since "no" followed by "no way" may well have been said twice. Only automatic captions roll, so other tracks are returned
+// as they are: their repeats were spoken.
+func mergeRollingSegments(transcriptType string, segments []models.TranscriptSegment) []models.TranscriptSegment {
+	if transcriptType != models.TranscriptTypeAuto {
+		return segments
+	}
+
+	merged := make([]models.TranscriptSegment, 0, len(segments))
+	// The words and end of the last window as shown, before its repeated words were removed
+	var window []string
+	var windowEnd float64
+	for _, segment := range segments {
+		words := strings.Fields(segment.Text)
+		if len(merged) == 0 {
+			merged = append(merged, segment)
+			window, windowEnd = words, segmentEnd(segment)
+			continue
+		}
+		previous := &merged[len(merged)-1]
+
+		overlap := rollingOverlap(window, words, segment.Start < windowEnd)
+		window, windowEnd = words, segmentEnd(segment)
+		if overlap == 0 {
+			merged = append(merged, segment)
+			continue
+		}
+
+		end := segmentEnd(segment)
+		if overlap == len(words) {
+			if end > segmentEnd(*previous) {
+				previous.End = end
+				previous.Duration = end - previous.Start
+			}
+			continue
+		}
+
+		if len(segment.Words) == len(words) {
+			// Timed words say exactly when the new words start
+			segment.Words = segment.Words[overlap:]
+			segment.Start = segment.Words[0].Start
+		} else {
+			// The new words start after the repeated ones and the space that follows them
+			repeated := utf8.RuneCountInString(strings.Join(words[:overlap], " ")) + 1
+			segment.Start += segment.Duration * float64(repeated) / float64(utf8.RuneCountInString(segment.Text))
+			segment.Words = nil
+		}
+		segment.Text = strings.Join(words[overlap:], " ")
+		segment.Duration = end - segment.Start
+		segment.End = end
+		annotateSegment(&segment)
+
+		// Rolling windows overlap in time as well; the earlier one ends where the new words start
+		if previous.Start < segment.Start && segmentEnd(*previous) > segment.Start {
+			previous.End = segment.Start
+			previous.Duration = segment.Start - previous.Start
+		}
+		merged = append(merged, segment)
+	}
+	return merged
+}
+
+// rollingOverlap returns the length of the longest run of words that ends previous and starts next.
+// A run of one word is only returned when the windows overlap in time.
+func rollingOverlap(previous, next []string, overlapsInTime bool) int {
+	shortest := 2
+	if overlapsInTime {
+		shortest = 1
+	}
+	for n := min(len(previous), len(next)); n >= shortest; n-- {
+		matches := true
+		for i := 0; i < n; i++ {
+			if normalizeWord(previous[len(previous)-n+i]) != normalizeWord(next[i]) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return n
+		}
+	}
+	return 0
+}
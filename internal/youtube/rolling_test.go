@@ -0,0 +1,136 @@
+package youtube
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestParseTranscriptXMLMergesRollingCaptions(t *testing.T) {
+	s := &Service{logger: setupTestLogger()}
+
+	segments, err := s.parseTranscriptXML(readFixture(t, "rolling_captions.xml"), models.TranscriptTypeAuto)
+	if err != nil {
+		t.Fatalf("parseTranscriptXML() error = %v", err)
+	}
+
+	// srv3 times every word, so the new words of a window start exactly where their first word does
+	expected := []models.TranscriptSegment{
+		{Text: "the quick", Start: 0, Duration: 2, End: 2, Words: []models.TranscriptWord{
+			{Text: "the", Start: 0, Duration: 0.4}, {Text: "quick", Start: 0.4, Duration: 1.6}}},
+		{Text: "brown fox", Start: 2.4, Duration: 2.1, End: 4.5, Words: []models.TranscriptWord{
+			{Text: "brown", Start: 2.4, Duration: 0.6}, {Text: "fox", Start: 3, Duration: 1.5}}},
+		{Text: "jumps over", Start: 4.8, Duration: 2.2, End: 7, Words: []models.TranscriptWord{
+			{Text: "jumps", Start: 4.8, Duration: 0.6}, {Text: "over", Start: 5.4, Duration: 1.1}}},
+		{Text: "no", Start: 7, Duration: 2, End: 9, Words: []models.TranscriptWord{
+			{Text: "no", Start: 7, Duration: 2}}},
+		{Text: "no way", Start: 9, Duration: 2, End: 11, Words: []models.TranscriptWord{
+			{Text: "no", Start: 9, Duration: 0.4}, {Text: "way", Start: 9.4, Duration: 1.6}}},
+		{Text: "out", Start: 11.1, Duration: 1.4, End: 12.5, Words: []models.TranscriptWord{
+			{Text: "out", Start: 11.1, Duration: 1.4}}},
+		// A window that follows on in time repeats a word that was said twice
+		{Text: "that", Start: 12.5, Duration: 1, End: 13.5, Words: []models.TranscriptWord{
+			{Text: "that", Start: 12.5, Duration: 1}}},
+		{Text: "that was it", Start: 13.5, Duration: 2, End: 15.5, Words: []models.TranscriptWord{
+			{Text: "that", Start: 13.5, Duration: 0.4}, {Text: "was", Start: 13.9, Duration: 0.4}, {Text: "it", Start: 14.3, Duration: 1.2}}},
+	}
+
+	if len(segments) != len(expected) {
+		t.Fatalf("parseTranscriptXML() returned %d segments, want %d: %+v", len(segments), len(expected), segments)
+	}
+	for i, segment := range segments {
+		if !reflect.DeepEqual(roundSegment(segment), expected[i]) {
+			t.Errorf("segment %d = %+v, want %+v", i, segment, expected[i])
+		}
+	}
+
+	var text []string
+	for _, segment := range segments {
+		text = append(text, segment.Text)
+	}
+	if got, want := strings.Join(text, " "), "the quick brown fox jumps over no no way out that that was it"; got != want {
+		t.Errorf("merged text = %q, want %q", got, want)
+	}
+}
+
+func TestMergeRollingSegmentsKeepsManualRepeats(t *testing.T) {
+	segments := []models.TranscriptSegment{
+		{Text: "No.", Start: 0, Duration: 1, End: 1},
+		{Text: "No, I don't.", Start: 1, Duration: 2, End: 3},
+		{Text: "Thank you.", Start: 3, Duration: 1, End: 4},
+		{Text: "Thank you very much.", Start: 4, Duration: 2, End: 6},
+	}
+
+	for _, transcriptType := range []string{models.TranscriptTypeManual, models.TranscriptTypeGenerated, models.TranscriptTypeTranslated} {
+		merged := mergeRollingSegments(transcriptType, segments)
+		if !reflect.DeepEqual(merged, segments) {
+			t.Errorf("mergeRollingSegments(%q) = %+v, want the segments unchanged", transcriptType, merged)
+		}
+	}
+}
+
+func TestMergeRollingSegmentsWithoutWordTimings(t *testing.T) {
+	segments := mergeRollingSegments(models.TranscriptTypeAuto, []models.TranscriptSegment{
+		{Text: "the quick", Start: 0, Duration: 2, End: 2},
+		{Text: "the quick brown fox", Start: 1.5, Duration: 3, End: 4.5},
+		{Text: "brown fox", Start: 4, Duration: 1, End: 5},
+	})
+
+	// Without word timings the new words start after the share of the text the repeated ones took
+	expected := []models.TranscriptSegment{
+		{Text: "the quick", Start: 0, Duration: 2, End: 2},
+		{Text: "brown fox", Start: 3.08, Duration: 1.92, End: 5},
+	}
+	if len(segments) != len(expected) {
+		t.Fatalf("mergeRollingSegments() returned %d segments, want %d: %+v", len(segments), len(expected), segments)
+	}
+	for i, segment := range segments {
+		if !reflect.DeepEqual(roundSegment(segment), expected[i]) {
+			t.Errorf("segment %d = %+v, want %+v", i, segment, expected[i])
+		}
+	}
+}
+
+// roundSegment rounds the times of a segment and its words to hundredths
+func roundSegment(segment models.TranscriptSegment) models.TranscriptSegment {
+	segment.Start = roundHundredths(segment.Start)
+	segment.Duration = roundHundredths(segment.Duration)
+	segment.End = roundHundredths(segment.End)
+	if segment.Words != nil {
+		words := make([]models.TranscriptWord, len(segment.Words))
+		for i, word := range segment.Words {
+			words[i] = models.TranscriptWord{Text: word.Text, Start: roundHundredths(word.Start), Duration: roundHundredths(word.Duration)}
+		}
+		segment.Words = words
+	}
+	return segment
+}
+
+func TestRollingOverlap(t *testing.T) {
+	tests := []struct {
+		previous       string
+		next           string
+		overlapsInTime bool
+		expected       int
+	}{
+		{"the quick", "the quick brown fox", true, 2},
+		{"the quick brown fox", "brown fox jumps", false, 2},
+		{"Brown fox.", "brown fox jumps", true, 2},
+		{"so", "so we went", true, 1},
+		{"no no way", "way out", true, 1},
+		{"that", "that was", false, 0},
+		{"it was very", "very good", false, 0},
+		{"one two three", "four five", true, 0},
+		{"a b c", "a b c", false, 3},
+		{"", "anything", true, 0},
+	}
+
+	for _, tt := range tests {
+		got := rollingOverlap(strings.Fields(tt.previous), strings.Fields(tt.next), tt.overlapsInTime)
+		if got != tt.expected {
+			t.Errorf("rollingOverlap(%q, %q, %v) = %d, want %d", tt.previous, tt.next, tt.overlapsInTime, got, tt.expected)
+		}
+	}
+}
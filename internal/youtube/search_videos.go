@@ -0,0 +1,247 @@
+package youtube
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// maxSearchPages caps how many result pages a single search follows
+const maxSearchPages = 5
+
+// Search filter values as encoded in the results page's sp parameter
+var (
+	searchUploadDateFilters = map[string]int{
+		models.SearchUploadHour:  1,
+		models.SearchUploadToday: 2,
+		models.SearchUploadWeek:  3,
+		models.SearchUploadMonth: 4,
+		models.SearchUploadYear:  5,
+	}
+	searchDurationFilters = map[string]int{
+		models.SearchDurationShort:  1,
+		models.SearchDurationLong:   2,
+		models.SearchDurationMedium: 3,
+	}
+)
+
+// captionBadgeLabels are the result badges that mark a video with captions
+var captionBadgeLabels = map[string]bool{"CC": true, "Subtitles": true}
+
+// SearchData holds the parts of a results page's ytInitialData that the service reads
+type SearchData struct {
+	EstimatedResults string `json:"estimatedResults"`
+	Contents         struct {
+		TwoColumnSearchResultsRenderer struct {
+			PrimaryContents struct {
+				SectionListRenderer struct {
+					Contents []SearchSection `json:"contents"`
+				} `json:"sectionListRenderer"`
+			} `json:"primaryContents"`
+		} `json:"twoColumnSearchResultsRenderer"`
+	} `json:"contents"`
+}
+
+// SearchSection is a block of search results or the pointer to the next page
+type SearchSection struct {
+	ItemSectionRenderer *struct {
+		Contents []struct {
+			VideoRenderer *VideoRenderer `json:"videoRenderer,omitempty"`
+		} `json:"contents"`
+	} `json:"itemSectionRenderer,omitempty"`
+	ContinuationItemRenderer *ContinuationItemRenderer `json:"continuationItemRenderer,omitempty"`
+}
+
+// SearchContinuationResponse is an innertube search response carrying the next page of results
+type SearchContinuationResponse struct {
+	OnResponseReceivedCommands []struct {
+		AppendContinuationItemsAction struct {
+			ContinuationItems []SearchSection `json:"continuationItems"`
+		} `json:"appendContinuationItemsAction"`
+	} `json:"onResponseReceivedCommands"`
+}
+
+// SearchVideos runs a YouTube search and returns the matching videos. Channels, playlists and
+// shelves in the results are left out.
+func (s *Service) SearchVideos(ctx context.Context, opts models.VideoSearchOptions) (*models.SearchVideosResponse, error) {
+	query := strings.TrimSpace(opts.Query)
+	if query == "" {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeValidationError,
+			Message: "Search query must not be empty",
+		}
+	}
+
+	maxResults := opts.MaxResults
+	if maxResults <= 0 {
+		maxResults = models.DefaultSearchVideos
+	}
+
+	if waitErr := s.waitForRateLimit(ctx); waitErr != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeRateLimitExceeded,
+			Message: fmt.Sprintf("Rate limit exceeded: %s", waitErr.Error()),
+		}
+	}
+
+	params := url.Values{}
+	params.Set("search_query", query)
+	params.Set("sp", encodeSearchFilters(opts))
+	html, err := s.fetchPage(ctx, s.youtubeURL("/results?"+params.Encode()), "")
+	if err != nil {
+		s.recordRateLimitFailure(err)
+		return nil, err
+	}
+	s.recordRateLimitSuccess()
+
+	searchData, err := parseSearchPage(html)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.SearchVideosResponse{
+		Query:   query,
+		Results: make([]models.VideoSearchResult, 0, maxResults),
+	}
+	if estimated, err := strconv.ParseInt(searchData.EstimatedResults, 10, 64); err == nil {
+		response.EstimatedResults = estimated
+	}
+
+	cfg := extractInnertubeConfig(html)
+	sections := searchData.Contents.TwoColumnSearchResultsRenderer.PrimaryContents.SectionListRenderer.Contents
+	seen := make(map[string]bool)
+	for page := 1; ; page++ {
+		results, token := parseSearchSections(sections, opts.HasCaptions)
+		for _, result := range results {
+			if seen[result.VideoID] || len(response.Results) >= maxResults {
+				continue
+			}
+			seen[result.VideoID] = true
+			response.Results = append(response.Results, result)
+		}
+
+		if token == "" || len(response.Results) >= maxResults || page >= maxSearchPages {
+			break
+		}
+
+		body, err := s.postInnertube(ctx, "search", cfg, map[string]any{"continuation": token}, "")
+		if err != nil {
+			s.logger.Warn("Failed to fetch more search results", "query", query, "error", err)
+			break
+		}
+		var continuation SearchContinuationResponse
+		if err := json.Unmarshal(body, &continuation); err != nil {
+			s.logger.Warn("Failed to parse search continuation", "query", query, "error", err)
+			break
+		}
+		sections = nil
+		for _, command := range continuation.OnResponseReceivedCommands {
+			sections = append(sections, command.AppendContinuationItemsAction.ContinuationItems...)
+		}
+	}
+
+	return response, nil
+}
+
+// parseSearchPage extracts the search results data from a results page
+func parseSearchPage(html string) (*SearchData, error) {
+	raw, ok := extractJSONVariable(html, "ytInitialData")
+	if !ok {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeParsingError,
+			Message: "Failed to extract search results from page",
+		}
+	}
+
+	var searchData SearchData
+	if err := json.Unmarshal(raw, &searchData); err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeParsingError,
+			Message: fmt.Sprintf("Failed to parse search results: %s", err.Error()),
+		}
+	}
+	return &searchData, nil
+}
+
+// parseSearchSections returns the videos in the sections and the continuation token of the next page.
+// captionsFiltered marks every result as captioned because the search itself was restricted to them.
+func parseSearchSections(sections []SearchSection, captionsFiltered bool) ([]models.VideoSearchResult, string) {
+	var results []models.VideoSearchResult
+	token := ""
+	for _, section := range sections {
+		if section.ContinuationItemRenderer != nil {
+			token = section.ContinuationItemRenderer.ContinuationEndpoint.ContinuationCommand.Token
+			continue
+		}
+		if section.ItemSectionRenderer == nil {
+			continue
+		}
+		for _, item := range section.ItemSectionRenderer.Contents {
+			if item.VideoRenderer == nil || item.VideoRenderer.VideoID == "" {
+				continue
+			}
+			result := searchResultFromRenderer(item.VideoRenderer)
+			result.HasCaptions = result.HasCaptions || captionsFiltered
+			results = append(results, result)
+		}
+	}
+	return results, token
+}
+
+// searchResultFromRenderer converts a search result tile
+func searchResultFromRenderer(renderer *VideoRenderer) models.VideoSearchResult {
+	result := models.VideoSearchResult{
+		VideoID:       renderer.VideoID,
+		Title:         renderer.Title.String(),
+		Duration:      renderer.LengthText.String(),
+		PublishedText: renderer.PublishedTimeText.String(),
+		URL:           buildTimestampURL(renderer.VideoID, 0),
+	}
+	if runs := renderer.OwnerText.Runs; len(runs) > 0 {
+		result.ChannelName = runs[0].Text
+		result.ChannelID = runs[0].NavigationEndpoint.BrowseEndpoint.BrowseID
+	}
+	if seconds, err := models.ParseTimeOffset(result.Duration); err == nil {
+		result.DurationSeconds = seconds
+	}
+	if fields := strings.Fields(renderer.ViewCountText.String()); len(fields) > 0 {
+		if views, ok := parseCompactCount(fields[0]); ok {
+			result.ViewCount = views
+		}
+	}
+	for _, badge := range renderer.Badges {
+		if captionBadgeLabels[badge.MetadataBadgeRenderer.Label] {
+			result.HasCaptions = true
+		}
+	}
+	if len(renderer.DetailedMetadataSnippets) > 0 {
+		result.Snippet = renderer.DetailedMetadataSnippets[0].SnippetText.String()
+	}
+	return result
+}
+
+// encodeSearchFilters builds the sp parameter of a results page. It is a base64 protobuf whose
+// field 2 holds the filters: upload date (1), result type (2, always video), duration (3) and
+// subtitles (5).
+func encodeSearchFilters(opts models.VideoSearchOptions) string {
+	filters := []byte{}
+	if value, ok := searchUploadDateFilters[opts.UploadDate]; ok {
+		filters = append(filters, 1<<3, byte(value))
+	}
+	filters = append(filters, 2<<3, 1)
+	if value, ok := searchDurationFilters[opts.Duration]; ok {
+		filters = append(filters, 3<<3, byte(value))
+	}
+	if opts.HasCaptions {
+		filters = append(filters, 5<<3, 1)
+	}
+
+	message := append([]byte{2<<3 | 2, byte(len(filters))}, filters...)
+	return base64.StdEncoding.EncodeToString(message)
+}
@@ -0,0 +1,127 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// readFixture returns the contents of a file in testdata
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("Failed to read fixture %s: %v", name, err)
+	}
+	return data
+}
+
+func TestParseSearchPageFixture(t *testing.T) {
+	searchData, err := parseSearchPage(string(readFixture(t, "search_results.html")))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	results, token := parseSearchSections(searchData.Contents.TwoColumnSearchResultsRenderer.PrimaryContents.SectionListRenderer.Contents, false)
+	if token != "search-page-2" {
+		t.Errorf("Expected continuation token 'search-page-2', got %q", token)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 videos (channels and playlists skipped), got %d", len(results))
+	}
+
+	first := results[0]
+	if first.VideoID != "Pa_e9EeCdy8" || first.Title != "Generics in Go: an introduction {part 1};" {
+		t.Errorf("Unexpected first result: %+v", first)
+	}
+	if first.ChannelName != "The Go Programming Language" || first.ChannelID != "UC_fNa2Rv4XABOgK1VhRJo0g" {
+		t.Errorf("Unexpected channel: %q %q", first.ChannelName, first.ChannelID)
+	}
+	if first.DurationSeconds != 3723 || first.ViewCount != 184233 || !first.HasCaptions {
+		t.Errorf("Unexpected details: %+v", first)
+	}
+	if first.Snippet != "Type parameters explained" {
+		t.Errorf("Unexpected snippet %q", first.Snippet)
+	}
+	if results[1].HasCaptions {
+		t.Error("Expected second result without a caption badge to be uncaptioned")
+	}
+}
+
+func TestEncodeSearchFilters(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     models.VideoSearchOptions
+		expected string
+	}{
+		{"videos only", models.VideoSearchOptions{}, "EgIQAQ=="},
+		{"upload date", models.VideoSearchOptions{UploadDate: models.SearchUploadWeek}, "EgQIAxAB"},
+		{"duration", models.VideoSearchOptions{Duration: models.SearchDurationLong}, "EgQQARgC"},
+		{"captions", models.VideoSearchOptions{HasCaptions: true}, "EgQQASgB"},
+		{"all filters", models.VideoSearchOptions{UploadDate: models.SearchUploadHour, Duration: models.SearchDurationShort, HasCaptions: true}, "EggIARABGAEoAQ=="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := encodeSearchFilters(tt.opts); result != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSearchVideosThroughService(t *testing.T) {
+	resultsPage := readFixture(t, "search_results.html")
+	continuation := readFixture(t, "search_continuation.json")
+
+	var receivedFilter string
+	var continuationBody map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("/results", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("search_query") != "golang generics" {
+			t.Errorf("Unexpected query %q", r.URL.Query().Get("search_query"))
+		}
+		receivedFilter = r.URL.Query().Get("sp")
+		_, _ = w.Write(resultsPage)
+	})
+	mux.HandleFunc("/youtubei/v1/search", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key") != "fixture-key" {
+			t.Errorf("Expected the page's API key, got %q", r.URL.RawQuery)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&continuationBody)
+		_, _ = w.Write(continuation)
+	})
+
+	service := newTestService(t, mux)
+
+	response, err := service.SearchVideos(context.Background(), models.VideoSearchOptions{
+		Query:       "golang generics",
+		HasCaptions: true,
+		MaxResults:  3,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if receivedFilter != "EgQQASgB" {
+		t.Errorf("Expected captions filter, got %q", receivedFilter)
+	}
+	if continuationBody["continuation"] != "search-page-2" {
+		t.Errorf("Unexpected continuation request: %v", continuationBody)
+	}
+	if response.EstimatedResults != 48213 {
+		t.Errorf("Expected estimated results 48213, got %d", response.EstimatedResults)
+	}
+	if len(response.Results) != 3 {
+		t.Fatalf("Expected 3 results with duplicates removed, got %d: %+v", len(response.Results), response.Results)
+	}
+	third := response.Results[2]
+	if third.VideoID != "WjM5eBrbG-A" || third.ViewCount != 12000 || !third.HasCaptions {
+		t.Errorf("Unexpected third result: %+v", third)
+	}
+}
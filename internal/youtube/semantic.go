@@ -0,0 +1,149 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/youtube-transcript-mcp/internal/library"
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// defaultEmbeddingChunkSeconds is the length of the transcript windows embedded for semantic search
+const defaultEmbeddingChunkSeconds = 30
+
+// embeddingBatchSize is the number of chunks sent to the embedder per request
+const embeddingBatchSize = 64
+
+// SemanticSearch returns the transcript chunks of the library closest in meaning to a query.
+// Library transcripts that have not been embedded with the current model yet are embedded first,
+// so the first search after many fetches takes longer.
+func (s *Service) SemanticSearch(ctx context.Context, opts models.SemanticSearchOptions) (*models.SemanticSearchResponse, error) {
+	if s.library == nil || s.embedder == nil || s.vectors == nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeLibraryUnavailable,
+			Message: "Semantic search is not enabled; set YOUTUBE_LIBRARY_PATH and YOUTUBE_EMBEDDING_URL or YOUTUBE_EMBEDDING_API_KEY",
+		}
+	}
+
+	if opts.MaxResults <= 0 {
+		opts.MaxResults = models.DefaultSemanticResults
+	}
+
+	embedded, err := s.embedLibrary(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	queryVectors, err := s.embedder.Embed(ctx, []string{opts.Query})
+	if err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeEmbeddingFailed,
+			Message: fmt.Sprintf("Failed to embed query: %v", err),
+		}
+	}
+
+	hits, compared := s.vectors.Search(queryVectors[0], s.embedder.Model(), opts)
+	for i := range hits {
+		hits[i].URL = buildTimestampURL(hits[i].VideoID, hits[i].Start)
+	}
+
+	return &models.SemanticSearchResponse{
+		Query:          opts.Query,
+		Model:          s.embedder.Model(),
+		Hits:           hits,
+		SearchedChunks: compared,
+		NewlyEmbedded:  embedded,
+	}, nil
+}
+
+// embedLibrary embeds the library transcripts passing the search filters whose vectors are
+// missing or stale, and returns how many it embedded
+func (s *Service) embedLibrary(ctx context.Context, opts models.SemanticSearchOptions) (int, error) {
+	s.embedMu.Lock()
+	defer s.embedMu.Unlock()
+
+	model := s.embedder.Model()
+	chunkSeconds := s.config.EmbeddingChunkSeconds
+	if chunkSeconds <= 0 {
+		chunkSeconds = defaultEmbeddingChunkSeconds
+	}
+
+	embedded := 0
+	for _, document := range s.library.Documents() {
+		if !document.Matches(opts.Channel, opts.Language) || s.vectors.Current(document, model, chunkSeconds) {
+			continue
+		}
+
+		chunks := chunkSegments(document.Segments, float64(chunkSeconds))
+		for start := 0; start < len(chunks); start += embeddingBatchSize {
+			batch := chunks[start:min(start+embeddingBatchSize, len(chunks))]
+			texts := make([]string, len(batch))
+			for i, chunk := range batch {
+				texts[i] = chunk.Text
+			}
+			vectors, err := s.embedder.Embed(ctx, texts)
+			if err != nil {
+				return embedded, &models.TranscriptError{
+					Type:    models.ErrorTypeEmbeddingFailed,
+					Message: fmt.Sprintf("Failed to embed transcript: %v", err),
+					VideoID: document.VideoID,
+				}
+			}
+			for i := range batch {
+				batch[i].Vector = vectors[i]
+			}
+		}
+
+		err := s.vectors.Add(library.DocumentVectors{
+			Model:        model,
+			ChunkSeconds: chunkSeconds,
+			Document:     document,
+			Chunks:       chunks,
+		})
+		if err != nil {
+			return embedded, &models.TranscriptError{
+				Type:    models.ErrorTypeInternalError,
+				Message: fmt.Sprintf("Failed to store transcript vectors: %v", err),
+				VideoID: document.VideoID,
+			}
+		}
+		embedded++
+		s.logger.Debug("Embedded library transcript", "video_id", document.VideoID, "language", document.Language, "chunks", len(chunks))
+	}
+	return embedded, nil
+}
+
+// chunkSegments groups consecutive segments into windows of about window seconds. Caption tags
+// such as [Music] carry no meaning to embed, so they are removed and tag-only segments skipped.
+func chunkSegments(segments []library.Segment, window float64) []library.Chunk {
+	var chunks []library.Chunk
+	var texts []string
+	var current library.Chunk
+
+	flush := func() {
+		if len(texts) > 0 {
+			current.Text = strings.Join(texts, " ")
+			chunks = append(chunks, current)
+		}
+		texts = texts[:0]
+	}
+
+	for _, segment := range segments {
+		text := stripAnnotations(segment.Text)
+		if text == "" {
+			continue
+		}
+		if len(texts) > 0 && segment.Start >= current.Start+window {
+			flush()
+		}
+		if len(texts) == 0 {
+			current = library.Chunk{Start: segment.Start}
+		}
+		texts = append(texts, text)
+		current.End = max(current.End, segment.End)
+	}
+	flush()
+
+	return chunks
+}
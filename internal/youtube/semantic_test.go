@@ -0,0 +1,101 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/library"
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestChunkSegments(t *testing.T) {
+	segments := []library.Segment{
+		{Text: "one", Start: 0, End: 4},
+		{Text: "[Music]", Start: 4, End: 10},
+		{Text: "two  [laughs]", Start: 10, End: 14},
+		{Text: "three", Start: 30, End: 33},
+		{Text: "four", Start: 45, End: 62},
+		{Text: "five", Start: 70, End: 72},
+	}
+	expected := []library.Chunk{
+		{Text: "one two", Start: 0, End: 14},
+		{Text: "three four", Start: 30, End: 62},
+		{Text: "five", Start: 70, End: 72},
+	}
+
+	chunks := chunkSegments(segments, 30)
+	if len(chunks) != len(expected) {
+		t.Fatalf("Expected %d chunks, got %+v", len(expected), chunks)
+	}
+	for i, chunk := range chunks {
+		if chunk.Text != expected[i].Text || chunk.Start != expected[i].Start || chunk.End != expected[i].End {
+			t.Errorf("Chunk %d: expected %+v, got %+v", i, expected[i], chunk)
+		}
+	}
+}
+
+func TestSemanticSearch_Disabled(t *testing.T) {
+	service := newTestService(t, http.NewServeMux())
+
+	_, err := service.SemanticSearch(context.Background(), models.SemanticSearchOptions{Query: "cars"})
+	if transcriptErr, ok := err.(*models.TranscriptError); !ok || transcriptErr.Type != models.ErrorTypeLibraryUnavailable {
+		t.Errorf("Expected a library unavailable error, got %v", err)
+	}
+}
+
+func TestSemanticSearch_FindsParaphrases(t *testing.T) {
+	var calls [][]string
+	embeddingServer := embeddingStandIn(t, &calls)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/watch", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<script>var ytInitialPlayerResponse = {"videoDetails":{"videoId":"dQw4w9WgXcQ","title":"Demo"},"captions":{"playerCaptionsTracklistRenderer":{`+
+			`"captionTracks":[{"baseUrl":"http://%s/api/timedtext?lang=en","languageCode":"en","isDefault":true}]}}};</script>`, r.Host)
+	})
+	mux.HandleFunc("/api/timedtext", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `<transcript><text start="0" dur="5">today we bake bread</text><text start="5" dur="5">in my kitchen</text>`+
+			`<text start="40" dur="5">then I drove the automobile</text><text start="80" dur="5">and checked my budget</text></transcript>`)
+	})
+
+	service := newTestService(t, mux)
+	service.config.LibraryPath = t.TempDir()
+	service.config.EmbeddingChunkSeconds = 30
+	service.library = openTestLibrary(t, service.config.LibraryPath)
+	service.embedder = &OpenAIEmbedder{baseURL: embeddingServer.URL, model: "concepts", client: http.DefaultClient}
+	vectors, err := library.OpenVectors(filepath.Join(service.config.LibraryPath, "vectors"), setupTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to open vectors: %v", err)
+	}
+	service.vectors = vectors
+
+	if _, err := service.GetTranscript(context.Background(), "dQw4w9WgXcQ", []string{"en"}, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	response, err := service.SemanticSearch(context.Background(), models.SemanticSearchOptions{Query: "vehicles and driving", MaxResults: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response.NewlyEmbedded != 1 || response.SearchedChunks != 3 || response.Model != "concepts" || len(response.Hits) != 2 {
+		t.Fatalf("Unexpected response: %+v", response)
+	}
+	if hit := response.Hits[0]; hit.Text != "then I drove the automobile" || hit.Start != 40 || hit.URL != "https://youtu.be/dQw4w9WgXcQ?t=40" {
+		t.Errorf("Unexpected best hit: %+v", hit)
+	}
+
+	// Stored vectors are reused; only the query is embedded again
+	calls = nil
+	response, err = service.SemanticSearch(context.Background(), models.SemanticSearchOptions{Query: "a recipe for cooking"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response.NewlyEmbedded != 0 || len(calls) != 1 {
+		t.Errorf("Expected only the query to be embedded, got %d transcripts and calls %v", response.NewlyEmbedded, calls)
+	}
+	if response.Hits[0].Text != "today we bake bread in my kitchen" {
+		t.Errorf("Unexpected best hit: %+v", response.Hits[0])
+	}
+}
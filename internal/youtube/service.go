@@ -10,6 +10,7 @@ import (
 	"math"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/youtube-transcript-mcp/internal/cache"
 	"github.com/youtube-transcript-mcp/internal/config"
+	"github.com/youtube-transcript-mcp/internal/library"
 	"github.com/youtube-transcript-mcp/internal/models"
 )
 
@@ -32,9 +34,22 @@ type Service struct {
 	proxyManager   *ProxyManager
 	logger         *slog.Logger
 	rateLimitState *RateLimitState
+	translator     Translator
+	library        *library.Index
+	embedder       Embedder
+	vectors        *library.VectorIndex
+	embedMu        sync.Mutex // serializes embedding of library transcripts
+	baseURL        string
+	metaCacheTTL   time.Duration
 	config         config.YouTubeConfig
 }
 
+// defaultBaseURL is the origin of YouTube pages and innertube API calls
+const defaultBaseURL = "https://www.youtube.com"
+
+// defaultMetadataTTL is how long video metadata and chapters stay cached when unconfigured
+const defaultMetadataTTL = time.Hour
+
 // RateLimitState tracks rate limiting state for adaptive behavior
 type RateLimitState struct {
 	lastFailureTime     time.Time
@@ -104,6 +119,31 @@ func NewService(cfg config.YouTubeConfig, cache cache.Cache, logger *slog.Logger
 	rateLimiter := rate.NewLimiter(rate.Every(time.Minute/time.Duration(rateLimitPerMinute)), rateLimitPerMinute)
 	hourlyLimiter := rate.NewLimiter(rate.Every(time.Hour/time.Duration(rateLimitPerHour)), rateLimitPerHour)
 
+	// External translation is optional; without it only YouTube's own caption translation is used
+	translator, err := NewTranslator(cfg)
+	if err != nil {
+		logger.Error("Failed to configure translation provider", "error", err, "provider", cfg.TranslationProvider)
+	}
+
+	// The library keeps every fetched transcript searchable after its cache entry expires
+	var transcriptLibrary *library.Index
+	if cfg.LibraryPath != "" {
+		transcriptLibrary, err = library.Open(cfg.LibraryPath, logger)
+		if err != nil {
+			logger.Error("Failed to open transcript library", "error", err, "path", cfg.LibraryPath)
+		}
+	}
+
+	// Semantic search embeds library transcripts into vectors stored next to the library
+	embedder := NewEmbedder(cfg)
+	var vectors *library.VectorIndex
+	if transcriptLibrary != nil && embedder != nil {
+		vectors, err = library.OpenVectors(filepath.Join(cfg.LibraryPath, "vectors"), logger)
+		if err != nil {
+			logger.Error("Failed to open vector index", "error", err, "path", cfg.LibraryPath)
+		}
+	}
+
 	return &Service{
 		config:        cfg,
 		httpClient:    httpClient,
@@ -112,6 +152,11 @@ func NewService(cfg config.YouTubeConfig, cache cache.Cache, logger *slog.Logger
 		hourlyLimiter: hourlyLimiter,
 		proxyManager:  proxyManager,
 		logger:        logger,
+		translator:    translator,
+		library:       transcriptLibrary,
+		embedder:      embedder,
+		vectors:       vectors,
+		baseURL:       defaultBaseURL,
 		rateLimitState: &RateLimitState{
 			adaptiveMultiplier: 1.0,
 		},
@@ -120,6 +165,12 @@ func NewService(cfg config.YouTubeConfig, cache cache.Cache, logger *slog.Logger
 
 // GetTranscript retrieves transcript for a single video
 func (s *Service) GetTranscript(ctx context.Context, videoIdentifier string, languages []string, preserveFormatting bool) (*models.TranscriptResponse, error) {
+	return s.getTranscript(ctx, videoIdentifier, languages, preserveFormatting, false)
+}
+
+// getTranscript retrieves transcript for a single video, with the timing of every word where the
+// captions have it when wordTimings is set
+func (s *Service) getTranscript(ctx context.Context, videoIdentifier string, languages []string, preserveFormatting, wordTimings bool) (*models.TranscriptResponse, error) {
 	videoID, err := s.extractVideoID(videoIdentifier)
 	if err != nil {
 		return nil, &models.TranscriptError{
@@ -131,6 +182,9 @@ func (s *Service) GetTranscript(ctx context.Context, videoIdentifier string, lan
 
 	// Check cache first
 	cacheKey := fmt.Sprintf("%s%s:%s", models.CacheKeyPrefixTranscript, videoID, strings.Join(languages, ","))
+	if wordTimings {
+		cacheKey += ":words"
+	}
 	if cached, found := s.cache.Get(ctx, cacheKey); found {
 		if transcript, ok := cached.(*models.TranscriptResponse); ok {
 			s.logger.Debug("Returning cached transcript", slog.String("video_id", videoID))
@@ -181,23 +235,39 @@ func (s *Service) GetTranscript(ctx context.Context, videoIdentifier string, lan
 	}
 
 	// Fetch the transcript
-	transcript, err := s.fetchTranscriptFromTrack(ctx, selectedTrack)
+	transcript, err := s.fetchTranscriptFromTrack(ctx, selectedTrack, wordTimings)
 	if err != nil {
 		s.recordRateLimitFailure(err)
 		return nil, err
 	}
 
 	// Build response
+	response := s.buildTranscriptResponse(videoData, selectedTrack, transcript, preserveFormatting)
+	s.addToLibrary(response)
+
+	// Cache the result
+	if err := s.cache.Set(ctx, cacheKey, response, s.config.RequestTimeout); err != nil {
+		s.logger.Warn("Failed to cache transcript response", "error", err)
+	}
+
+	// Record success for adaptive rate limiting
+	s.recordRateLimitSuccess()
+
+	return response, nil
+}
+
+// buildTranscriptResponse assembles the response for a transcript fetched from track
+func (s *Service) buildTranscriptResponse(videoData *VideoData, track *CaptionTrack, transcript []models.TranscriptSegment, preserveFormatting bool) *models.TranscriptResponse {
 	response := &models.TranscriptResponse{
-		VideoID:        videoID,
+		VideoID:        videoData.VideoID,
 		Title:          videoData.Title,
 		Description:    videoData.Description,
-		Language:       selectedTrack.LanguageCode,
-		TranscriptType: s.getTranscriptType(selectedTrack),
+		Language:       track.LanguageCode,
+		TranscriptType: s.getTranscriptType(track),
 		Transcript:     transcript,
 		Metadata: models.TranscriptMetadata{
 			ExtractionTimestamp: time.Now().UTC(),
-			LanguageDetected:    selectedTrack.LanguageCode,
+			LanguageDetected:    track.LanguageCode,
 			Source:              "youtube",
 			ChannelID:           videoData.ChannelID,
 			ChannelName:         videoData.ChannelName,
@@ -218,15 +288,7 @@ func (s *Service) GetTranscript(ctx context.Context, videoIdentifier string, lan
 	response.CharCount = len(response.FormattedText)
 	response.DurationSeconds = s.calculateDuration(transcript)
 
-	// Cache the result
-	if err := s.cache.Set(ctx, cacheKey, response, s.config.RequestTimeout); err != nil {
-		s.logger.Warn("Failed to cache transcript response", "error", err)
-	}
-
-	// Record success for adaptive rate limiting
-	s.recordRateLimitSuccess()
-
-	return response, nil
+	return response
 }
 
 // GetMultipleTranscripts retrieves transcripts for multiple videos
@@ -373,75 +435,346 @@ func (s *Service) ListAvailableLanguages(ctx context.Context, videoIdentifier st
 	return response, nil
 }
 
-// TranslateTranscript translates a transcript to target language
+// TranslateTranscript returns a transcript in targetLanguage. A caption track already in that language
+// is used as is; otherwise YouTube machine-translates the best source track through its tlang parameter.
 func (s *Service) TranslateTranscript(ctx context.Context, videoIdentifier, targetLanguage, sourceLanguage string) (*models.TranscriptResponse, error) {
-	// First get available languages
-	availableLanguages, err := s.ListAvailableLanguages(ctx, videoIdentifier)
+	videoID, err := s.extractVideoID(videoIdentifier)
+	if err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeInvalidVideoID,
+			Message: fmt.Sprintf("Invalid video identifier: %s", err.Error()),
+			VideoID: videoIdentifier,
+		}
+	}
+
+	// Check cache
+	cacheKey := fmt.Sprintf("%s%s:tlang=%s:%s", models.CacheKeyPrefixTranscript, videoID, targetLanguage, sourceLanguage)
+	if cached, found := s.cache.Get(ctx, cacheKey); found {
+		if transcript, ok := cached.(*models.TranscriptResponse); ok {
+			return transcript, nil
+		}
+	}
+
+	if waitErr := s.waitForRateLimit(ctx); waitErr != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeRateLimitExceeded,
+			Message: fmt.Sprintf("Rate limit exceeded: %s", waitErr.Error()),
+			VideoID: videoID,
+		}
+	}
+
+	videoData, err := s.fetchVideoData(ctx, videoID)
 	if err != nil {
+		s.recordRateLimitFailure(err)
 		return nil, err
 	}
 
-	// Check if target language is available
-	var targetFound bool
-	for _, lang := range availableLanguages.Languages {
-		if lang.Code == targetLanguage {
-			targetFound = true
-			break
+	captionTracks, err := s.extractCaptionTracks(videoData)
+	if err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeNoTranscriptFound,
+			Message: fmt.Sprintf("No captions found: %s", err.Error()),
+			VideoID: videoID,
 		}
 	}
 
-	if !targetFound {
-		// Try to get auto-translated version
-		return s.GetTranscript(ctx, videoIdentifier, []string{targetLanguage}, false)
+	// A track already in the target language needs no translation, unless another source was asked for
+	if sourceLanguage == "" || matchesLanguage(sourceLanguage, targetLanguage) {
+		if track := findTrackByLanguage(captionTracks, targetLanguage); track != nil {
+			transcript, err := s.fetchTranscriptFromTrack(ctx, track, false)
+			if err != nil {
+				s.recordRateLimitFailure(err)
+				return nil, err
+			}
+			response := s.buildTranscriptResponse(videoData, track, transcript, false)
+			s.cacheTranslation(ctx, cacheKey, response)
+			return response, nil
+		}
 	}
 
-	// Get transcript in target language
-	return s.GetTranscript(ctx, videoIdentifier, []string{targetLanguage}, false)
+	sourceTrack, err := selectTranslationSource(captionTracks, sourceLanguage, videoID)
+	tlang := ""
+	if err == nil {
+		var ok bool
+		if tlang, ok = findTranslationLanguage(videoData.TranslationLanguages, targetLanguage); !ok {
+			err = &models.TranscriptError{
+				Type:        models.ErrorTypeTranslationNotAvailable,
+				Message:     fmt.Sprintf("YouTube cannot translate this video's captions to %q", targetLanguage),
+				VideoID:     videoID,
+				Suggestions: translationLanguageCodes(videoData.TranslationLanguages),
+			}
+		}
+	}
+	if err != nil {
+		// Fall back to the external translator when YouTube cannot translate
+		transcriptErr, ok := err.(*models.TranscriptError)
+		if !ok || transcriptErr.Type != models.ErrorTypeTranslationNotAvailable || s.translator == nil {
+			return nil, err
+		}
+		response, err := s.translateWithProvider(ctx, videoData, captionTracks, targetLanguage, sourceLanguage)
+		if err != nil {
+			return nil, err
+		}
+		s.cacheTranslation(ctx, cacheKey, response)
+		return response, nil
+	}
+
+	translatedTrack := *sourceTrack
+	translatedTrack.BaseURL, err = addURLParam(sourceTrack.BaseURL, "tlang", tlang)
+	if err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeParsingError,
+			Message: fmt.Sprintf("Invalid caption track URL: %s", err.Error()),
+			VideoID: videoID,
+		}
+	}
+
+	transcript, err := s.fetchTranscriptFromTrack(ctx, &translatedTrack, false)
+	if err != nil {
+		s.recordRateLimitFailure(err)
+		return nil, err
+	}
+
+	response := s.buildTranscriptResponse(videoData, sourceTrack, transcript, false)
+	response.Language = tlang
+	response.TranscriptType = models.TranscriptTypeTranslated
+	response.Metadata.IsTranslated = true
+	response.Metadata.SourceLanguage = sourceTrack.LanguageCode
+	response.Metadata.TargetLanguage = tlang
+	response.Metadata.TranslationProvider = "youtube"
+
+	s.cacheTranslation(ctx, cacheKey, response)
+	return response, nil
+}
+
+// SetMetadataTTL sets how long video metadata and chapters stay cached, normally the cache's metadata TTL
+func (s *Service) SetMetadataTTL(ttl time.Duration) {
+	s.metaCacheTTL = ttl
+}
+
+// metadataTTL returns how long video metadata and chapters stay cached
+func (s *Service) metadataTTL() time.Duration {
+	if s.metaCacheTTL > 0 {
+		return s.metaCacheTTL
+	}
+	return defaultMetadataTTL
+}
+
+// cacheTranslation caches a translate_transcript result and records the successful fetch
+func (s *Service) cacheTranslation(ctx context.Context, cacheKey string, response *models.TranscriptResponse) {
+	if err := s.cache.Set(ctx, cacheKey, response, s.config.RequestTimeout); err != nil {
+		s.logger.Warn("Failed to cache translated transcript", "error", err)
+	}
+	s.addToLibrary(response)
+	s.recordRateLimitSuccess()
 }
 
 // FormatTranscript formats a transcript according to specified format
 func (s *Service) FormatTranscript(ctx context.Context, videoIdentifier, formatType string, includeTimestamps bool) (*models.TranscriptResponse, error) {
-	transcript, err := s.GetTranscript(ctx, videoIdentifier, nil, true)
+	return s.FormatTranscriptWithOptions(ctx, videoIdentifier, models.FormatOptions{
+		FormatType:        formatType,
+		IncludeTimestamps: includeTimestamps,
+	})
+}
+
+// FormatTranscriptWithOptions formats a transcript and fits the rendered output to an optional token budget
+func (s *Service) FormatTranscriptWithOptions(ctx context.Context, videoIdentifier string, opts models.FormatOptions) (*models.TranscriptResponse, error) {
+	// Only karaoke captions highlight words, so only they fetch word timings
+	cached, err := s.getTranscript(ctx, videoIdentifier, nil, true, opts.FormatType == models.FormatTypeVTTKaraoke)
 	if err != nil {
 		return nil, err
 	}
 
-	switch formatType {
+	// Work on a copy so the cached response is left untouched
+	transcript := *cached
+
+	annotated := applyAnnotationMode(cached.Transcript, opts.Transcript.Annotations)
+	render := renderOptions{
+		formatType:        opts.FormatType,
+		includeTimestamps: opts.IncludeTimestamps,
+		transcriptType:    transcript.TranscriptType,
+		paragraphs:        opts.Paragraphs,
+		chapters:          s.chaptersForFormat(ctx, opts.FormatType, transcript.VideoID),
+	}
+
+	maxTokens := opts.Transcript.MaxTokens
+	budget := maxTokens
+	for pass := 0; ; pass++ {
+		segments, truncated := fitSegmentsToTokenBudget(annotated, budget, opts.Transcript.TokenStrategy)
+
+		formatted, err := s.renderSegments(segments, render)
+		if err != nil {
+			return nil, err
+		}
+
+		transcript.Transcript = withoutWordTimings(segments)
+		transcript.FormattedText = formatted
+		transcript.TokenCount = estimateTokens(formatted)
+		transcript.Truncated = truncated
+
+		if maxTokens <= 0 || transcript.TokenCount <= maxTokens || pass >= maxBudgetPasses || budget <= 1 {
+			break
+		}
+
+		// Shrink the text budget by the markup overhead of the chosen format and try again
+		next := budget * maxTokens / transcript.TokenCount
+		if next >= budget {
+			next = budget - 1
+		}
+		budget = next
+	}
+
+	transcript.WordCount = s.countWords(transcript.FormattedText)
+	transcript.CharCount = len(transcript.FormattedText)
+
+	return &transcript, nil
+}
+
+// GetTranscriptWithOptions retrieves a transcript and applies the optional post-processing in opts
+func (s *Service) GetTranscriptWithOptions(ctx context.Context, videoIdentifier string, languages []string, preserveFormatting bool, opts models.TranscriptOptions) (*models.TranscriptResponse, error) {
+	transcript, err := s.getTranscript(ctx, videoIdentifier, languages, preserveFormatting, opts.IncludeWordTimings)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.applyTranscriptOptions(transcript, opts, opts.MaxTokens)
+	if opts.SplitByChapter {
+		result = s.splitTranscriptByChapter(ctx, result)
+	}
+	return result, nil
+}
+
+// GetMultipleTranscriptsWithOptions retrieves transcripts for multiple videos and shares the token
+// budget in opts across all successful results
+func (s *Service) GetMultipleTranscriptsWithOptions(ctx context.Context, videoIdentifiers []string, languages []string, continueOnError bool, opts models.TranscriptOptions) (*models.MultipleTranscriptResponse, error) {
+	response, err := s.GetMultipleTranscripts(ctx, videoIdentifiers, languages, continueOnError)
+	if err != nil {
+		return response, err
+	}
+
+	s.applyBatchTranscriptOptions(response, opts)
+	return response, nil
+}
+
+// applyBatchTranscriptOptions applies opts to each successful result of a batch response
+func (s *Service) applyBatchTranscriptOptions(response *models.MultipleTranscriptResponse, opts models.TranscriptOptions) {
+	needs := make([]int, 0, len(response.Results))
+	indices := make([]int, 0, len(response.Results))
+	for i, result := range response.Results {
+		if result.Transcript == nil {
+			continue
+		}
+		_, total := segmentTokenCosts(result.Transcript.Transcript)
+		needs = append(needs, total)
+		indices = append(indices, i)
+	}
+
+	budgets := make([]int, len(needs))
+	if opts.MaxTokens > 0 {
+		budgets = distributeTokenBudget(needs, opts.MaxTokens)
+	}
+
+	for j, i := range indices {
+		budget := budgets[j]
+		if opts.MaxTokens > 0 {
+			// A zero share still has to truncate, not disable the budget
+			budget = max(budget, 1)
+		}
+		response.Results[i].Transcript = s.applyTranscriptOptions(response.Results[i].Transcript, opts, budget)
+	}
+}
+
+// applyTranscriptOptions returns a copy of transcript with its annotations handled as requested,
+// fitted to maxTokens, with refreshed counts
+func (s *Service) applyTranscriptOptions(transcript *models.TranscriptResponse, opts models.TranscriptOptions, maxTokens int) *models.TranscriptResponse {
+	result := *transcript
+
+	annotated := applyAnnotationMode(transcript.Transcript, opts.Annotations)
+	segments, truncated := fitSegmentsToTokenBudget(annotated, maxTokens, opts.TokenStrategy)
+	result.Transcript = append(make([]models.TranscriptSegment, 0, len(segments)), segments...)
+	result.Truncated = truncated
+	if !opts.IncludeWordTimings {
+		result.Transcript = withoutWordTimings(result.Transcript)
+	}
+
+	rewritten := opts.Annotations != "" && opts.Annotations != models.AnnotationModeKeep
+	if (truncated || rewritten) && result.FormattedText != "" {
+		result.FormattedText = s.formatTranscriptText(segments)
+		result.WordCount = s.countWords(result.FormattedText)
+		result.CharCount = len(result.FormattedText)
+	}
+
+	if result.FormattedText != "" {
+		result.TokenCount = estimateTokens(result.FormattedText)
+	} else {
+		_, result.TokenCount = segmentTokenCosts(segments)
+	}
+
+	return &result
+}
+
+// renderOptions controls how renderSegments renders segments
+type renderOptions struct {
+	formatType        string
+	includeTimestamps bool
+	// transcriptType tells automatic captions, whose punctuation may need restoring, apart
+	transcriptType string
+	paragraphs     models.ParagraphOptions
+	// chapters become headings of the paragraphs format
+	chapters []models.Chapter
+}
+
+// renderSegments renders segments in the requested format type. Sentences and paragraphs of
+// automatic captions without punctuation are rebuilt with restored punctuation.
+func (s *Service) renderSegments(segments []models.TranscriptSegment, opts renderOptions) (string, error) {
+	includeTimestamps := opts.includeTimestamps
+	switch opts.formatType {
 	case models.FormatTypePlainText:
-		transcript.FormattedText = s.formatAsPlainText(transcript.Transcript, includeTimestamps)
+		return s.formatAsPlainText(segments, includeTimestamps), nil
 	case models.FormatTypeParagraphs:
-		transcript.FormattedText = s.formatAsParagraphs(transcript.Transcript, includeTimestamps)
+		return s.formatAsParagraphs(segments, opts), nil
 	case models.FormatTypeSentences:
-		transcript.FormattedText = s.formatAsSentences(transcript.Transcript, includeTimestamps)
+		if needsPunctuation(opts.transcriptType, segments) {
+			return s.formatRestoredSentences(segments, includeTimestamps), nil
+		}
+		return s.formatAsSentences(segments, includeTimestamps), nil
 	case models.FormatTypeSRT:
-		transcript.FormattedText = s.formatAsSRT(transcript.Transcript)
+		return s.formatAsSRT(segments), nil
 	case models.FormatTypeVTT:
-		transcript.FormattedText = s.formatAsVTT(transcript.Transcript)
+		return s.formatAsVTT(segments), nil
+	case models.FormatTypeVTTKaraoke:
+		return s.formatAsVTTKaraoke(segments), nil
+	case models.FormatTypeSpeakerTurns:
+		return s.formatAsSpeakerTurns(segments, includeTimestamps), nil
 	case models.FormatTypeJSON:
-		jsonBytes, err := json.MarshalIndent(transcript.Transcript, "", "  ")
+		jsonBytes, err := json.MarshalIndent(withoutWordTimings(segments), "", "  ")
 		if err != nil {
-			return nil, err
+			return "", err
 		}
-		transcript.FormattedText = string(jsonBytes)
+		return string(jsonBytes), nil
 	default:
-		transcript.FormattedText = s.formatTranscriptText(transcript.Transcript)
+		return s.formatTranscriptText(segments), nil
 	}
-
-	transcript.WordCount = s.countWords(transcript.FormattedText)
-	transcript.CharCount = len(transcript.FormattedText)
-
-	return transcript, nil
 }
 
 // fetchVideoData fetches initial video data from YouTube
 func (s *Service) fetchVideoData(ctx context.Context, videoID string) (*VideoData, error) {
-	url := fmt.Sprintf("https://www.youtube.com/watch?v=%s", videoID)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	html, err := s.fetchPage(ctx, s.youtubeURL("/watch?v="+url.QueryEscape(videoID)), videoID)
 	if err != nil {
 		return nil, err
 	}
 
+	// Extract video data from the page
+	return s.parseVideoData(html, videoID)
+}
+
+// fetchPage fetches a YouTube HTML page; id identifies the video, playlist or channel in errors
+func (s *Service) fetchPage(ctx context.Context, pageURL string, id string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return "", err
+	}
+
 	// Set headers similar to youtube-transcript-api
 	req.Header.Set("User-Agent", s.config.UserAgent)
 	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
@@ -453,10 +786,10 @@ func (s *Service) fetchVideoData(ctx context.Context, videoID string) (*VideoDat
 
 	resp, err := s.doHTTPRequestWithRetry(ctx, req)
 	if err != nil {
-		return nil, &models.TranscriptError{
+		return "", &models.TranscriptError{
 			Type:    models.ErrorTypeNetworkError,
-			Message: fmt.Sprintf("Failed to fetch video page: %s", err.Error()),
-			VideoID: videoID,
+			Message: fmt.Sprintf("Failed to fetch page: %s", err.Error()),
+			VideoID: id,
 		}
 	}
 	defer func() {
@@ -466,24 +799,24 @@ func (s *Service) fetchVideoData(ctx context.Context, videoID string) (*VideoDat
 	}()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, &models.TranscriptError{
+		return "", &models.TranscriptError{
 			Type:    models.ErrorTypeVideoUnavailable,
 			Message: "Video not found or unavailable",
-			VideoID: videoID,
+			VideoID: id,
 		}
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &models.TranscriptError{
+		return "", &models.TranscriptError{
 			Type:    models.ErrorTypeNetworkError,
 			Message: fmt.Sprintf("HTTP error: %d", resp.StatusCode),
-			VideoID: videoID,
+			VideoID: id,
 		}
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	html := string(body)
@@ -495,25 +828,38 @@ func (s *Service) fetchVideoData(ctx context.Context, videoID string) (*VideoDat
 		// For now, we'll just proceed - in production, you'd want to handle this properly
 	}
 
-	// Extract video data from the page
-	return s.parseVideoData(html, videoID)
+	return html, nil
+}
+
+// youtubeURL resolves a path against the YouTube base URL
+func (s *Service) youtubeURL(path string) string {
+	base := s.baseURL
+	if base == "" {
+		base = defaultBaseURL
+	}
+	return base + path
 }
 
 // parseVideoData extracts video metadata and caption information from the HTML
 func (s *Service) parseVideoData(html string, videoID string) (*VideoData, error) {
 	videoData := &VideoData{
-		VideoID: videoID,
+		VideoID:   videoID,
+		Innertube: extractInnertubeConfig(html),
 	}
 
 	// Extract initial player response
-	playerResponseRegex := regexp.MustCompile(`var ytInitialPlayerResponse = ({.+?});`)
-	matches := playerResponseRegex.FindStringSubmatch(html)
-	if len(matches) < 2 {
-		return nil, fmt.Errorf("failed to extract player response")
+	rawPlayerResponse, ok := extractJSONVariable(html, "ytInitialPlayerResponse")
+	if !ok {
+		playerResponseRegex := regexp.MustCompile(`var ytInitialPlayerResponse = ({.+?});`)
+		matches := playerResponseRegex.FindStringSubmatch(html)
+		if len(matches) < 2 {
+			return nil, fmt.Errorf("failed to extract player response")
+		}
+		rawPlayerResponse = []byte(matches[1])
 	}
 
 	var playerResponse PlayerResponse
-	if err := json.Unmarshal([]byte(matches[1]), &playerResponse); err != nil {
+	if err := json.Unmarshal(rawPlayerResponse, &playerResponse); err != nil {
 		return nil, fmt.Errorf("failed to parse player response: %w", err)
 	}
 
@@ -529,16 +875,58 @@ func (s *Service) parseVideoData(html string, videoID string) (*VideoData, error
 			slog.Warn("Failed to parse view count", "error", err, "viewCount", details.ViewCount)
 		}
 		videoData.IsLive = details.IsLiveContent
+		if length, err := strconv.ParseFloat(details.LengthSeconds, 64); err == nil {
+			videoData.LengthSeconds = length
+		}
+		videoData.Keywords = details.Keywords
+		videoData.IsPrivate = details.IsPrivate
+		videoData.IsLiveNow = details.IsLive
+		videoData.IsUpcoming = details.IsUpcoming
+		videoData.Thumbnails = details.Thumbnail.toModels()
+	}
+
+	// Extract publishing details
+	if microformat := playerResponse.Microformat; microformat != nil {
+		renderer := microformat.PlayerMicroformatRenderer
+		videoData.PublishedAt = renderer.PublishDate
+		videoData.UploadDate = renderer.UploadDate
+		videoData.Category = renderer.Category
+		videoData.IsUnlisted = renderer.IsUnlisted
+		videoData.IsFamilySafe = renderer.IsFamilySafe
+		if renderer.LiveBroadcastDetails != nil {
+			videoData.IsLiveNow = videoData.IsLiveNow || renderer.LiveBroadcastDetails.IsLiveNow
+		}
+		if len(videoData.Thumbnails) == 0 {
+			videoData.Thumbnails = renderer.Thumbnail.toModels()
+		}
+		if videoData.LengthSeconds == 0 {
+			if length, err := strconv.ParseFloat(renderer.LengthSeconds, 64); err == nil {
+				videoData.LengthSeconds = length
+			}
+		}
 	}
 
 	// Extract caption tracks
 	if captions := playerResponse.Captions; captions != nil {
 		videoData.CaptionTracks = captions.PlayerCaptionsTracklistRenderer.CaptionTracks
+		videoData.TranslationLanguages = captions.PlayerCaptionsTracklistRenderer.TranslationLanguages
 	}
 
-	// Extract additional metadata from initial data if needed in future
-	// Currently not extracting additional metadata but keeping regex for future use
-	_ = regexp.MustCompile(`var ytInitialData = ({.+?});`)
+	// Extract additional metadata from initial data
+	if raw, ok := extractJSONVariable(html, "ytInitialData"); ok {
+		var initialData InitialData
+		if err := json.Unmarshal(raw, &initialData); err != nil {
+			slog.Warn("Failed to parse initial data", "error", err, "video_id", videoID)
+		} else {
+			videoData.InitialData = &initialData
+			if likes, ok := initialData.likeCount(); ok {
+				videoData.LikeCount = likes
+			}
+			if comments, ok := initialData.commentCount(); ok {
+				videoData.CommentCount = comments
+			}
+		}
+	}
 
 	return videoData, nil
 }
@@ -617,9 +1005,14 @@ func (s *Service) selectBestTrack(tracks []CaptionTrack, languages []string) *Ca
 	return nil
 }
 
-// fetchTranscriptFromTrack fetches the actual transcript data from a caption track
-func (s *Service) fetchTranscriptFromTrack(ctx context.Context, track *CaptionTrack) ([]models.TranscriptSegment, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", track.BaseURL, nil)
+// fetchTranscriptFromTrack fetches the actual transcript data from a caption track. With wordTimings
+// the track is requested in the json3 format, which times every word of automatic captions.
+func (s *Service) fetchTranscriptFromTrack(ctx context.Context, track *CaptionTrack, wordTimings bool) ([]models.TranscriptSegment, error) {
+	trackURL := track.BaseURL
+	if wordTimings {
+		trackURL = captionFormatURL(trackURL)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", trackURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -683,8 +1076,13 @@ func (s *Service) fetchTranscriptFromTrack(ctx context.Context, track *CaptionTr
 		"url", track.BaseURL,
 		"preview", preview)
 
-	// Parse the transcript XML
-	return s.parseTranscriptXML(body)
+	// Parse the transcript, served as XML or, when word timings were asked for, json3
+	segments, err := s.parseTranscriptData(body, s.getTranscriptType(track))
+	if err != nil || wordTimings {
+		return segments, err
+	}
+	// srv3 XML times words too; they are only kept when asked for
+	return withoutWordTimings(segments), nil
 }
 
 // Text represents a text element in XML transcripts
@@ -694,8 +1092,9 @@ type Text struct {
 	Dur   float64 `xml:"dur,attr"`
 }
 
-// parseTranscriptXML parses YouTube's transcript XML format with improved error handling
-func (s *Service) parseTranscriptXML(data []byte) ([]models.TranscriptSegment, error) {
+// parseTranscriptXML parses YouTube's transcript XML format with improved error handling.
+// transcriptType tells automatic captions, whose rolling windows repeat words, apart.
+func (s *Service) parseTranscriptXML(data []byte, transcriptType string) ([]models.TranscriptSegment, error) {
 	// First, check if the data is empty or has minimal content
 	if len(data) == 0 {
 		return nil, fmt.Errorf("empty XML response")
@@ -721,6 +1120,7 @@ func (s *Service) parseTranscriptXML(data []byte) ([]models.TranscriptSegment, e
 
 	type TimedText struct {
 		XMLName xml.Name `xml:"timedtext"`
+		Format  string   `xml:"format,attr"`
 		Head    struct {
 			Ws struct {
 				WinStyles []any `xml:"ws"`
@@ -731,10 +1131,13 @@ func (s *Service) parseTranscriptXML(data []byte) ([]models.TranscriptSegment, e
 		} `xml:"head"`
 		Body struct {
 			Paragraphs []struct {
-				Text      string  `xml:",chardata"`
-				Sentences []Text  `xml:"s"`
-				Start     float64 `xml:"t,attr"`
-				Dur       float64 `xml:"d,attr"`
+				Text      string `xml:",chardata"`
+				Sentences []struct {
+					Text   string  `xml:",chardata"`
+					Offset float64 `xml:"t,attr"`
+				} `xml:"s"`
+				Start float64 `xml:"t,attr"`
+				Dur   float64 `xml:"d,attr"`
 			} `xml:"p"`
 			Texts []Text `xml:"text"`
 		} `xml:"body"`
@@ -757,29 +1160,46 @@ func (s *Service) parseTranscriptXML(data []byte) ([]models.TranscriptSegment, e
 			if len(timedtext.Body.Texts) > 0 {
 				segments = s.convertTextsToSegments(timedtext.Body.Texts)
 			} else {
-				// Extract from paragraphs
+				// Extract from paragraphs. The srv3 format (format="3") times them in milliseconds.
+				scale := 1.0
+				if timedtext.Format == "3" {
+					scale = 0.001
+				}
 				for _, p := range timedtext.Body.Paragraphs {
+					text := p.Text
+					var words []timedWord
 					if len(p.Sentences) > 0 {
-						// Use sentences if available
-						segments = append(segments, s.convertTextsToSegments(p.Sentences)...)
-					} else if strings.TrimSpace(p.Text) != "" {
-						// Use paragraph text directly
-						cleanedText := s.cleanTranscriptText(p.Text)
-						if cleanedText != "" {
-							duration := p.Dur
-							if duration <= 0 {
-								duration = 2.0 // Default duration
-							}
-							segment := models.TranscriptSegment{
-								Text:     cleanedText,
-								Start:    p.Start,
-								Duration: duration,
-								End:      p.Start + duration,
-							}
-							segments = append(segments, segment)
+						// Automatic captions put each word in an <s> of its own, with the
+						// space before it and its offset into the paragraph; the paragraph is
+						// the caption line
+						var builder strings.Builder
+						for _, sentence := range p.Sentences {
+							builder.WriteString(sentence.Text)
+							words = append(words, timedWord{text: sentence.Text, start: (p.Start + sentence.Offset) * scale})
 						}
+						text = builder.String()
+					}
+					cleanedText := s.cleanTranscriptText(text)
+					if cleanedText == "" {
+						continue
+					}
+					duration := p.Dur * scale
+					if duration <= 0 {
+						duration = 2.0 // Default duration
 					}
+					segment := models.TranscriptSegment{
+						Text:     cleanedText,
+						Start:    p.Start * scale,
+						Duration: duration,
+						End:      p.Start*scale + duration,
+					}
+					if timedtext.Format == "3" && len(words) > 0 {
+						segment.Words = wordTimings(words, segment.End)
+					}
+					annotateSegment(&segment)
+					segments = append(segments, segment)
 				}
+				segments = mergeRollingSegments(transcriptType, segments)
 			}
 		} else {
 			// Log the XML content for debugging
@@ -797,6 +1217,7 @@ func (s *Service) parseTranscriptXML(data []byte) ([]models.TranscriptSegment, e
 	if len(segments) == 0 {
 		return nil, fmt.Errorf("no transcript segments found in XML")
 	}
+	assignSpeakerTurns(segments)
 
 	s.logger.Debug("Successfully parsed transcript", "segments", len(segments))
 	return segments, nil
@@ -823,6 +1244,7 @@ func (s *Service) convertTextsToSegments(texts []Text) []models.TranscriptSegmen
 			Duration: duration,
 			End:      text.Start + duration,
 		}
+		annotateSegment(&segment)
 		segments = append(segments, segment)
 	}
 	return segments
@@ -898,33 +1320,6 @@ func (s *Service) formatAsPlainText(segments []models.TranscriptSegment, include
 	return strings.TrimSpace(builder.String())
 }
 
-func (s *Service) formatAsParagraphs(segments []models.TranscriptSegment, includeTimestamps bool) string {
-	var builder strings.Builder
-	var currentParagraph strings.Builder
-
-	for i, segment := range segments {
-		if includeTimestamps && currentParagraph.Len() == 0 {
-			currentParagraph.WriteString(fmt.Sprintf("[%.1fs] ", segment.Start))
-		}
-		currentParagraph.WriteString(segment.Text)
-		currentParagraph.WriteString(" ")
-
-		// Start new paragraph every 5 segments or at natural breaks
-		if (i+1)%5 == 0 || strings.HasSuffix(strings.TrimSpace(segment.Text), ".") {
-			builder.WriteString(strings.TrimSpace(currentParagraph.String()))
-			builder.WriteString("\n\n")
-			currentParagraph.Reset()
-		}
-	}
-
-	// Add remaining text
-	if currentParagraph.Len() > 0 {
-		builder.WriteString(strings.TrimSpace(currentParagraph.String()))
-	}
-
-	return strings.TrimSpace(builder.String())
-}
-
 func (s *Service) formatAsSentences(segments []models.TranscriptSegment, includeTimestamps bool) string {
 	var builder strings.Builder
 
@@ -934,10 +1329,12 @@ func (s *Service) formatAsSentences(segments []models.TranscriptSegment, include
 		}
 		builder.WriteString(segment.Text)
 
-		// Add period if not present
+		// Add period if not present; a closing sound event tag such as [Music] is not a sentence
 		if !strings.HasSuffix(strings.TrimSpace(segment.Text), ".") &&
 			!strings.HasSuffix(strings.TrimSpace(segment.Text), "!") &&
-			!strings.HasSuffix(strings.TrimSpace(segment.Text), "?") {
+			!strings.HasSuffix(strings.TrimSpace(segment.Text), "?") &&
+			!strings.HasSuffix(strings.TrimSpace(segment.Text), "]") &&
+			!strings.HasSuffix(strings.TrimSpace(segment.Text), "］") {
 			builder.WriteString(".")
 		}
 		builder.WriteString("\n")
@@ -996,16 +1393,19 @@ func (s *Service) formatSRTTime(seconds float64) string {
 }
 
 func (s *Service) formatVTTTime(seconds float64) string {
-	hours := int(seconds) / 3600
-	minutes := (int(seconds) % 3600) / 60
-	secs := int(seconds) % 60
-	millis := int((seconds-float64(int(seconds)))*1000 + 0.5) // Round to nearest millisecond
+	// Round to the nearest millisecond first, so 1.9996 becomes 00:00:02.000 rather than 01.1000
+	total := int(math.Round(seconds * 1000))
+	hours := total / 3600000
+	minutes := (total % 3600000) / 60000
+	secs := (total % 60000) / 1000
+	millis := total % 1000
 
 	return fmt.Sprintf("%02d:%02d:%02d.%03d", hours, minutes, secs, millis)
 }
 
+// countWords counts the spoken words of text, leaving out sound events and speaker-change markers
 func (s *Service) countWords(text string) int {
-	words := strings.Fields(text)
+	words := strings.Fields(stripAnnotations(text))
 	return len(words)
 }
 
@@ -1237,8 +1637,15 @@ func (s *Service) doHTTPRequestWithRetry(ctx context.Context, req *http.Request)
 	var err error
 
 	retryErr := s.retryWithBackoff(ctx, fmt.Sprintf("HTTP %s %s", req.Method, req.URL), func() error {
-		// Clone request for retry safety
+		// Clone request for retry safety, rewinding the body for requests that have one
 		reqClone := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return err
+			}
+			reqClone.Body = body
+		}
 
 		resp, err = s.httpClient.Do(reqClone)
 		if err != nil {
@@ -1289,25 +1696,87 @@ type VideoData struct {
 	ChannelName   string
 	PublishedAt   string
 	CaptionTracks []CaptionTrack
-	ViewCount     int64
-	LikeCount     int64
-	CommentCount  int64
-	IsLive        bool
+	// TranslationLanguages are the targets YouTube offers for translatable tracks
+	TranslationLanguages []TranslationLanguage
+	ViewCount            int64
+	LikeCount            int64
+	CommentCount         int64
+	IsLive               bool
+	IsLiveNow            bool
+	IsUpcoming           bool
+	IsPrivate            bool
+	IsUnlisted           bool
+	IsFamilySafe         bool
+	UploadDate           string
+	Category             string
+	Keywords             []string
+	Thumbnails           []models.Thumbnail
+	LengthSeconds        float64
+	InitialData          *InitialData
+	Innertube            innertubeConfig
 }
 
 type PlayerResponse struct {
 	VideoDetails *VideoDetails `json:"videoDetails"`
 	Captions     *Captions     `json:"captions"`
+	Microformat  *Microformat  `json:"microformat"`
+}
+
+type Microformat struct {
+	PlayerMicroformatRenderer PlayerMicroformatRenderer `json:"playerMicroformatRenderer"`
+}
+
+type PlayerMicroformatRenderer struct {
+	Thumbnail            ThumbnailList         `json:"thumbnail"`
+	LiveBroadcastDetails *LiveBroadcastDetails `json:"liveBroadcastDetails,omitempty"`
+	PublishDate          string                `json:"publishDate"`
+	UploadDate           string                `json:"uploadDate"`
+	Category             string                `json:"category"`
+	LengthSeconds        string                `json:"lengthSeconds"`
+	IsUnlisted           bool                  `json:"isUnlisted"`
+	IsFamilySafe         bool                  `json:"isFamilySafe"`
+}
+
+type LiveBroadcastDetails struct {
+	StartTimestamp string `json:"startTimestamp,omitempty"`
+	EndTimestamp   string `json:"endTimestamp,omitempty"`
+	IsLiveNow      bool   `json:"isLiveNow"`
+}
+
+type ThumbnailList struct {
+	Thumbnails []struct {
+		URL    string `json:"url"`
+		Width  int    `json:"width"`
+		Height int    `json:"height"`
+	} `json:"thumbnails"`
+}
+
+// toModels converts the thumbnail list to model thumbnails
+func (l ThumbnailList) toModels() []models.Thumbnail {
+	if len(l.Thumbnails) == 0 {
+		return nil
+	}
+	thumbnails := make([]models.Thumbnail, 0, len(l.Thumbnails))
+	for _, thumbnail := range l.Thumbnails {
+		thumbnails = append(thumbnails, models.Thumbnail{URL: thumbnail.URL, Width: thumbnail.Width, Height: thumbnail.Height})
+	}
+	return thumbnails
 }
 
 type VideoDetails struct {
-	VideoID          string `json:"videoId"`
-	Title            string `json:"title"`
-	ShortDescription string `json:"shortDescription"`
-	ChannelID        string `json:"channelId"`
-	Author           string `json:"author"`
-	ViewCount        string `json:"viewCount"`
-	IsLiveContent    bool   `json:"isLiveContent"`
+	VideoID          string        `json:"videoId"`
+	Title            string        `json:"title"`
+	ShortDescription string        `json:"shortDescription"`
+	ChannelID        string        `json:"channelId"`
+	Author           string        `json:"author"`
+	ViewCount        string        `json:"viewCount"`
+	IsLiveContent    bool          `json:"isLiveContent"`
+	LengthSeconds    string        `json:"lengthSeconds"`
+	Keywords         []string      `json:"keywords"`
+	Thumbnail        ThumbnailList `json:"thumbnail"`
+	IsPrivate        bool          `json:"isPrivate"`
+	IsLive           bool          `json:"isLive"`
+	IsUpcoming       bool          `json:"isUpcoming"`
 }
 
 type Captions struct {
@@ -1315,7 +1784,14 @@ type Captions struct {
 }
 
 type PlayerCaptionsTracklistRenderer struct {
-	CaptionTracks []CaptionTrack `json:"captionTracks"`
+	CaptionTracks        []CaptionTrack        `json:"captionTracks"`
+	TranslationLanguages []TranslationLanguage `json:"translationLanguages"`
+}
+
+// TranslationLanguage is a language YouTube can machine-translate translatable tracks into
+type TranslationLanguage struct {
+	LanguageCode string   `json:"languageCode"`
+	LanguageName NameText `json:"languageName"`
 }
 
 type CaptionTrack struct {
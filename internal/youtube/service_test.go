@@ -224,6 +224,7 @@ func TestFormatVTTTime(t *testing.T) {
 		{"00:01:05.123", 65.123},
 		{"01:01:05.999", 3665.999},
 		{"02:00:00.000", 7200.0},
+		{"00:00:02.000", 1.9996},
 	}
 
 	for _, tt := range tests {
@@ -247,6 +248,7 @@ func TestCountWords(t *testing.T) {
 		{"", 0},
 		{"   ", 0},
 		{"Hello, world! How are you?", 5},
+		{"[Music] >> Hello ♪ world [Applause]", 2},
 	}
 
 	for _, tt := range tests {
@@ -604,7 +606,7 @@ func TestParseTranscriptXML(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			segments, err := s.parseTranscriptXML([]byte(tt.xmlData))
+			segments, err := s.parseTranscriptXML([]byte(tt.xmlData), models.TranscriptTypeManual)
 
 			if tt.expectError {
 				if err == nil {
@@ -0,0 +1,204 @@
+package youtube
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// speakerMarkerPattern matches the caption markers of a new speaker: >> anywhere in the text, or a
+// dash opening the text or following the end of a sentence, as in "- Hi. - Hello."
+var speakerMarkerPattern = regexp.MustCompile(`>>|(?:^|[.?!…])(\s*[-–—]\s+)`)
+
+// speakerNamePattern matches a NAME: prefix of up to three capitalized words
+var speakerNamePattern = regexp.MustCompile(`^(\p{Lu}[\p{L}'.\-]*(?:\s\p{Lu}[\p{L}'.\-]*){0,2}):(?:\s+|$)`)
+
+// Labels of the speakers captions mark without a name
+const (
+	firstSpeakerLabel  = "Speaker 1"
+	secondSpeakerLabel = "Speaker 2"
+)
+
+// assignSpeakerTurns groups segments into speaker turns from the conventions captions use to mark
+// speakers: >> markers, leading dashes and NAME: prefixes. A turn is labelled with the speaker's
+// name when the captions give one and as Speaker 1 or Speaker 2 otherwise, alternating between
+// the two. Segments are left without turns when the captions mark no speakers.
+func assignSpeakerTurns(segments []models.TranscriptSegment) {
+	names := recurringSpeakerNames(segments)
+
+	turn, speaker, detected := 0, "", false
+	for i := range segments {
+		name, found := findSpeakerCue(segments[i].Text, names)
+		switch {
+		case found && (name == "" || name != speaker):
+			turn++
+			speaker = nextSpeakerLabel(name, speaker)
+			detected = true
+		case found:
+			// The same named speaker carries on
+			detected = true
+		case i == 0:
+			turn, speaker = 1, firstSpeakerLabel
+		}
+		segments[i].Turn, segments[i].Speaker = turn, speaker
+	}
+
+	if !detected {
+		for i := range segments {
+			segments[i].Turn, segments[i].Speaker = 0, ""
+		}
+	}
+}
+
+// hasSpeakerTurns reports whether any segment was assigned a speaker turn
+func hasSpeakerTurns(segments []models.TranscriptSegment) bool {
+	for _, segment := range segments {
+		if segment.Turn > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// nextSpeakerLabel returns the label of a new turn: the speaker's name, or the unnamed speaker
+// other than the previous one
+func nextSpeakerLabel(name, previous string) string {
+	if name != "" {
+		return name
+	}
+	if previous == firstSpeakerLabel {
+		return secondSpeakerLabel
+	}
+	return firstSpeakerLabel
+}
+
+// findSpeakerCue reports whether caption text marks a new speaker and returns the speaker's name
+// if it gives one. All-caps names are always accepted; other capitalized names only when they are
+// among names, so that a prefix like "Note:" is not taken for a speaker.
+func findSpeakerCue(text string, names map[string]bool) (string, bool) {
+	_, rest, marked := splitSpeakerMarker(text)
+	if name, _, ok := speakerNamePrefix(rest); ok && (isUpperName(name) || names[name]) {
+		return name, true
+	}
+	return "", marked
+}
+
+// recurringSpeakerNames returns the capitalized NAME: prefixes used for at least two segments
+func recurringSpeakerNames(segments []models.TranscriptSegment) map[string]bool {
+	counts := make(map[string]int)
+	for _, segment := range segments {
+		_, rest, _ := splitSpeakerMarker(segment.Text)
+		if name, _, ok := speakerNamePrefix(rest); ok {
+			counts[name]++
+		}
+	}
+
+	names := make(map[string]bool)
+	for name, count := range counts {
+		if count >= 2 {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// splitSpeakerMarker splits caption text at its first speaker marker into the text said before it
+// and the text of the new speaker. Text without a marker is returned whole as rest.
+func splitSpeakerMarker(text string) (before, rest string, marked bool) {
+	loc := speakerMarkerPattern.FindStringSubmatchIndex(text)
+	if loc == nil {
+		return "", text, false
+	}
+	start := loc[0]
+	if loc[2] >= 0 {
+		// Keep the punctuation that ended the previous speaker's sentence
+		start = loc[2]
+	}
+	return strings.TrimSpace(text[:start]), strings.TrimSpace(text[loc[1]:]), true
+}
+
+// speakerNamePrefix splits a NAME: prefix from the start of text
+func speakerNamePrefix(text string) (name, rest string, ok bool) {
+	match := speakerNamePattern.FindStringSubmatchIndex(text)
+	if match == nil {
+		return "", text, false
+	}
+	return text[match[2]:match[3]], strings.TrimSpace(text[match[1]:]), true
+}
+
+// isUpperName reports whether a name is written in capitals, with at least two letters
+func isUpperName(name string) bool {
+	letters := 0
+	for _, r := range name {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters >= 2
+}
+
+// formatAsSpeakerTurns renders segments as one labelled paragraph per speaker turn, with the
+// speaker markers removed from the text
+func (s *Service) formatAsSpeakerTurns(segments []models.TranscriptSegment, includeTimestamps bool) string {
+	if !hasSpeakerTurns(segments) {
+		// Transcripts cached before turns were assigned
+		segments = append([]models.TranscriptSegment(nil), segments...)
+		assignSpeakerTurns(segments)
+	}
+
+	type speakerTurn struct {
+		speaker string
+		start   float64
+		parts   []string
+	}
+	var turns []*speakerTurn
+	for i, segment := range segments {
+		before, text, _ := splitSpeakerMarker(segment.Text)
+		if name, rest, ok := speakerNamePrefix(text); ok && name == segment.Speaker {
+			text = rest
+		}
+
+		if i == 0 || segment.Turn != segments[i-1].Turn {
+			if before != "" && len(turns) > 0 {
+				// The end of the previous speaker's turn shares the caption
+				last := turns[len(turns)-1]
+				last.parts = append(last.parts, before)
+				before = ""
+			}
+			speaker := segment.Speaker
+			if speaker == "" {
+				speaker = firstSpeakerLabel
+			}
+			turns = append(turns, &speakerTurn{speaker: speaker, start: segment.Start})
+		}
+
+		current := turns[len(turns)-1]
+		for _, part := range []string{before, text} {
+			if part != "" {
+				current.parts = append(current.parts, part)
+			}
+		}
+	}
+
+	var builder strings.Builder
+	for _, turn := range turns {
+		if len(turn.parts) == 0 {
+			continue
+		}
+		if includeTimestamps {
+			builder.WriteString(fmt.Sprintf("[%.1fs] ", turn.start))
+		}
+		builder.WriteString(turn.speaker)
+		builder.WriteString(": ")
+		builder.WriteString(strings.Join(turn.parts, " "))
+		builder.WriteString("\n\n")
+	}
+
+	return strings.TrimSpace(builder.String())
+}
@@ -0,0 +1,128 @@
+package youtube
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestAssignSpeakerTurns(t *testing.T) {
+	tests := []struct {
+		name     string
+		texts    []string
+		turns    []int
+		speakers []string
+	}{
+		{
+			name:     "no speaker markers",
+			texts:    []string{"hello there", "Note: this is fine"},
+			turns:    []int{0, 0},
+			speakers: []string{"", ""},
+		},
+		{
+			name:     "arrow markers alternate unnamed speakers",
+			texts:    []string{"welcome to the show", ">> thanks for having me", "it's great", ">> so tell me"},
+			turns:    []int{1, 2, 2, 3},
+			speakers: []string{"Speaker 1", "Speaker 2", "Speaker 2", "Speaker 1"},
+		},
+		{
+			name:     "dash markers",
+			texts:    []string{"- Are you coming?", "- Yes.", "I'll be there"},
+			turns:    []int{1, 2, 2},
+			speakers: []string{"Speaker 1", "Speaker 2", "Speaker 2"},
+		},
+		{
+			name:     "capitalized names",
+			texts:    []string{"JOHN: Good morning.", "How are you?", ">> MARY SMITH: Fine.", "JOHN: Great.", "JOHN: Let's start."},
+			turns:    []int{1, 1, 2, 3, 3},
+			speakers: []string{"JOHN", "JOHN", "MARY SMITH", "JOHN", "JOHN"},
+		},
+		{
+			name:     "mixed case names must recur",
+			texts:    []string{"Host: Welcome.", "Guest: Hi.", "Host: Let's begin.", "Guest: Sure.", "Note: unrelated"},
+			turns:    []int{1, 2, 3, 4, 4},
+			speakers: []string{"Host", "Guest", "Host", "Guest", "Guest"},
+		},
+		{
+			name:     "text before the first marker",
+			texts:    []string{"[Music]", "so where were we", ">> ANNA: right"},
+			turns:    []int{1, 1, 2},
+			speakers: []string{"Speaker 1", "Speaker 1", "ANNA"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segments := make([]models.TranscriptSegment, len(tt.texts))
+			for i, text := range tt.texts {
+				segments[i] = models.TranscriptSegment{Text: text, Start: float64(i)}
+			}
+			assignSpeakerTurns(segments)
+
+			turns := make([]int, len(segments))
+			speakers := make([]string, len(segments))
+			for i, segment := range segments {
+				turns[i], speakers[i] = segment.Turn, segment.Speaker
+			}
+			if !reflect.DeepEqual(turns, tt.turns) {
+				t.Errorf("turns = %v, want %v", turns, tt.turns)
+			}
+			if !reflect.DeepEqual(speakers, tt.speakers) {
+				t.Errorf("speakers = %q, want %q", speakers, tt.speakers)
+			}
+		})
+	}
+}
+
+func TestSplitSpeakerMarker(t *testing.T) {
+	tests := []struct {
+		text   string
+		before string
+		rest   string
+		marked bool
+	}{
+		{"plain text", "", "plain text", false},
+		{">> hi", "", "hi", true},
+		{"that's all. >> Thanks", "that's all.", "Thanks", true},
+		{"- Hi. - Hello.", "", "Hi. - Hello.", true},
+		{"Right? - Sure.", "Right?", "Sure.", true},
+		{"a well-known - thing", "", "a well-known - thing", false},
+	}
+
+	for _, tt := range tests {
+		before, rest, marked := splitSpeakerMarker(tt.text)
+		if before != tt.before || rest != tt.rest || marked != tt.marked {
+			t.Errorf("splitSpeakerMarker(%q) = %q, %q, %v, want %q, %q, %v",
+				tt.text, before, rest, marked, tt.before, tt.rest, tt.marked)
+		}
+	}
+}
+
+func TestFormatAsSpeakerTurns(t *testing.T) {
+	s := &Service{}
+
+	segments := []models.TranscriptSegment{
+		{Text: "JOHN: Good morning.", Start: 0},
+		{Text: "How are you?", Start: 2},
+		{Text: "Fine thanks. >> MARY: Hello", Start: 4},
+		{Text: "everyone.", Start: 6},
+	}
+	assignSpeakerTurns(segments)
+
+	want := "[0.0s] JOHN: Good morning. How are you? Fine thanks.\n\n[4.0s] MARY: Hello everyone."
+	if got := s.formatAsSpeakerTurns(segments, true); got != want {
+		t.Errorf("formatAsSpeakerTurns() = %q, want %q", got, want)
+	}
+
+	// Segments without assigned turns, as cached before turns existed, are grouped on the fly
+	unassigned := []models.TranscriptSegment{{Text: "hi"}, {Text: ">> hello"}}
+	if got, want := s.formatAsSpeakerTurns(unassigned, false), "Speaker 1: hi\n\nSpeaker 2: hello"; got != want {
+		t.Errorf("formatAsSpeakerTurns() = %q, want %q", got, want)
+	}
+
+	plain := []models.TranscriptSegment{{Text: "just one"}, {Text: "voice"}}
+	if got, want := s.formatAsSpeakerTurns(plain, false), "Speaker 1: just one voice"; got != want {
+		t.Errorf("formatAsSpeakerTurns() = %q, want %q", got, want)
+	}
+}
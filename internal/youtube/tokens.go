@@ -0,0 +1,233 @@
+package youtube
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// tokenPattern approximates the pre-tokenization split used by GPT-style BPE vocabularies:
+// contractions, words with their leading space, short digit groups, punctuation runs and whitespace
+var tokenPattern = regexp.MustCompile(`'(?:s|t|re|ve|m|ll|d)| ?\p{L}+| ?\p{N}{1,3}| ?[^\s\p{L}\p{N}]+|\s+`)
+
+// fillerWords are dropped by the compress token strategy
+var fillerWords = map[string]bool{
+	"um":  true,
+	"umm": true,
+	"uh":  true,
+	"uhm": true,
+	"erm": true,
+	"er":  true,
+	"ah":  true,
+	"hmm": true,
+	"mhm": true,
+	"えー":  true,
+	"あの":  true,
+	"えっと": true,
+}
+
+// fillerPhrases are multi-word fillers dropped by the compress token strategy
+var fillerPhrases = regexp.MustCompile(`(?i)\b(?:you know|i mean|sort of|kind of)\b,?\s*`)
+
+// maxBudgetPasses bounds how often a formatted output is re-fitted to absorb markup overhead
+const maxBudgetPasses = 5
+
+// estimateTokens returns an approximate BPE token count for text.
+// It is a built-in estimate tuned to be close to cl100k-style vocabularies without shipping the vocabulary itself.
+func estimateTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+
+	count := 0
+	for _, piece := range tokenPattern.FindAllString(text, -1) {
+		count += estimatePieceTokens(piece)
+	}
+	return count
+}
+
+// estimatePieceTokens estimates how many BPE merges a single pre-tokenized piece ends up as
+func estimatePieceTokens(piece string) int {
+	trimmed := strings.TrimLeft(piece, " ")
+	if strings.TrimSpace(trimmed) == "" {
+		return 1
+	}
+
+	runes := []rune(trimmed)
+	first := runes[0]
+
+	switch {
+	case isCJK(first):
+		// Ideographs and kana rarely merge, roughly one token per character
+		return len(runes)
+	case first < unicode.MaxASCII && unicode.IsLetter(first):
+		// Common English words are a single token, longer words split every ~4 characters
+		if len(runes) <= 7 {
+			return 1
+		}
+		return (len(runes) + 3) / 4
+	case unicode.IsLetter(first):
+		// Other scripts merge less aggressively than ASCII
+		return (len(runes) + 2) / 3
+	case unicode.IsDigit(first):
+		return 1
+	default:
+		return (len(runes) + 1) / 2
+	}
+}
+
+// isCJK reports whether r belongs to a Chinese, Japanese or Korean script
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// segmentTokenCosts returns the estimated token cost of each segment and their sum
+func segmentTokenCosts(segments []models.TranscriptSegment) ([]int, int) {
+	costs := make([]int, len(segments))
+	total := 0
+	for i, segment := range segments {
+		// Segments are joined with a space, which BPE folds into the first word
+		costs[i] = estimateTokens(" " + segment.Text)
+		total += costs[i]
+	}
+	return costs, total
+}
+
+// fitSegmentsToTokenBudget reduces segments with the given strategy until their text fits maxTokens.
+// It reports whether any content was removed.
+func fitSegmentsToTokenBudget(segments []models.TranscriptSegment, maxTokens int, strategy string) ([]models.TranscriptSegment, bool) {
+	if maxTokens <= 0 || len(segments) == 0 {
+		return segments, false
+	}
+
+	costs, total := segmentTokenCosts(segments)
+	if total <= maxTokens {
+		return segments, false
+	}
+
+	switch strategy {
+	case models.TokenStrategySample:
+		return sampleSegments(segments, costs, maxTokens), true
+	case models.TokenStrategyCompress:
+		compressed := compressSegments(segments)
+		compressedCosts, compressedTotal := segmentTokenCosts(compressed)
+		if compressedTotal <= maxTokens {
+			return compressed, true
+		}
+		return sampleSegments(compressed, compressedCosts, maxTokens), true
+	default:
+		return truncateSegments(segments, costs, maxTokens), true
+	}
+}
+
+// truncateSegments keeps the leading segments that fit maxTokens
+func truncateSegments(segments []models.TranscriptSegment, costs []int, maxTokens int) []models.TranscriptSegment {
+	used := 0
+	for i, cost := range costs {
+		if used+cost > maxTokens {
+			return segments[:i]
+		}
+		used += cost
+	}
+	return segments
+}
+
+// sampleSegments keeps the largest evenly spaced subset of segments that fits maxTokens,
+// so the result still covers the whole timeline
+func sampleSegments(segments []models.TranscriptSegment, costs []int, maxTokens int) []models.TranscriptSegment {
+	n := len(segments)
+	pick := func(m int) ([]int, bool) {
+		indices := make([]int, 0, m)
+		used := 0
+		for j := 0; j < m; j++ {
+			idx := j * n / m
+			used += costs[idx]
+			if used > maxTokens {
+				return nil, false
+			}
+			indices = append(indices, idx)
+		}
+		return indices, true
+	}
+
+	// Binary search for the largest sample size that fits
+	best := []int{}
+	low, high := 1, n
+	for low <= high {
+		mid := (low + high) / 2
+		if indices, ok := pick(mid); ok {
+			best = indices
+			low = mid + 1
+		} else {
+			high = mid - 1
+		}
+	}
+
+	sampled := make([]models.TranscriptSegment, 0, len(best))
+	for _, idx := range best {
+		sampled = append(sampled, segments[idx])
+	}
+	return sampled
+}
+
+// compressSegments removes filler words and stuttered repetitions while keeping segment timing
+func compressSegments(segments []models.TranscriptSegment) []models.TranscriptSegment {
+	compressed := make([]models.TranscriptSegment, 0, len(segments))
+	for _, segment := range segments {
+		text := compressText(segment.Text)
+		if text == "" {
+			continue
+		}
+		segment.Text = text
+		compressed = append(compressed, segment)
+	}
+	return compressed
+}
+
+// compressText drops fillers and immediately repeated words from a single line of text
+func compressText(text string) string {
+	text = fillerPhrases.ReplaceAllString(text, "")
+
+	words := strings.Fields(text)
+	kept := make([]string, 0, len(words))
+	previous := ""
+	for _, word := range words {
+		normalized := strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+			return unicode.IsPunct(r)
+		}))
+		if fillerWords[normalized] {
+			continue
+		}
+		if normalized != "" && normalized == previous {
+			continue
+		}
+		kept = append(kept, word)
+		previous = normalized
+	}
+
+	return strings.Join(kept, " ")
+}
+
+// distributeTokenBudget splits maxTokens across transcripts so short transcripts keep all their
+// content and the remainder is shared evenly between the longer ones
+func distributeTokenBudget(needs []int, maxTokens int) []int {
+	budgets := make([]int, len(needs))
+	order := make([]int, len(needs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return needs[order[a]] < needs[order[b]]
+	})
+
+	remaining := maxTokens
+	for pos, idx := range order {
+		share := remaining / (len(order) - pos)
+		budgets[idx] = min(needs[idx], share)
+		remaining -= budgets[idx]
+	}
+	return budgets
+}
@@ -0,0 +1,154 @@
+package youtube
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected int
+	}{
+		{"empty", "", 0},
+		{"short words", "Hello world", 2},
+		{"punctuation", "Hello, world!", 4},
+		{"contraction", "don't", 2},
+		{"long word", "internationalization", 5},
+		{"digits grouped by three", "1234567", 3},
+		{"japanese", "こんにちは", 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := estimateTokens(tt.text)
+			if result != tt.expected {
+				t.Errorf("estimateTokens(%q) = %d, want %d", tt.text, result, tt.expected)
+			}
+		})
+	}
+}
+
+func makeSegments(count int) []models.TranscriptSegment {
+	segments := make([]models.TranscriptSegment, count)
+	for i := range segments {
+		segments[i] = models.TranscriptSegment{
+			Text:     fmt.Sprintf("segment number %d here", i),
+			Start:    float64(i * 2),
+			Duration: 2,
+			End:      float64(i*2 + 2),
+		}
+	}
+	return segments
+}
+
+func TestFitSegmentsToTokenBudget(t *testing.T) {
+	segments := makeSegments(100)
+	_, total := segmentTokenCosts(segments)
+
+	t.Run("within budget is unchanged", func(t *testing.T) {
+		result, truncated := fitSegmentsToTokenBudget(segments, total, models.TokenStrategyTruncate)
+		if truncated {
+			t.Error("Expected no truncation")
+		}
+		if len(result) != len(segments) {
+			t.Errorf("Expected %d segments, got %d", len(segments), len(result))
+		}
+	})
+
+	t.Run("zero budget disables fitting", func(t *testing.T) {
+		result, truncated := fitSegmentsToTokenBudget(segments, 0, models.TokenStrategyTruncate)
+		if truncated || len(result) != len(segments) {
+			t.Error("Expected segments to be returned untouched")
+		}
+	})
+
+	t.Run("truncate keeps the beginning", func(t *testing.T) {
+		result, truncated := fitSegmentsToTokenBudget(segments, total/4, models.TokenStrategyTruncate)
+		if !truncated {
+			t.Error("Expected truncation")
+		}
+		if _, used := segmentTokenCosts(result); used > total/4 {
+			t.Errorf("Result uses %d tokens, budget was %d", used, total/4)
+		}
+		if result[0].Start != 0 || result[len(result)-1].Start >= 100 {
+			t.Errorf("Expected a prefix of the transcript, got %v..%v", result[0].Start, result[len(result)-1].Start)
+		}
+	})
+
+	t.Run("sample spans the timeline", func(t *testing.T) {
+		result, truncated := fitSegmentsToTokenBudget(segments, total/4, models.TokenStrategySample)
+		if !truncated {
+			t.Error("Expected truncation")
+		}
+		if _, used := segmentTokenCosts(result); used > total/4 {
+			t.Errorf("Result uses %d tokens, budget was %d", used, total/4)
+		}
+		if result[len(result)-1].Start < 150 {
+			t.Errorf("Expected samples near the end of the video, last start was %v", result[len(result)-1].Start)
+		}
+		for i := 1; i < len(result); i++ {
+			if result[i].Start <= result[i-1].Start {
+				t.Fatal("Expected samples in timeline order")
+			}
+		}
+	})
+
+	t.Run("compress drops fillers before sampling", func(t *testing.T) {
+		filler := []models.TranscriptSegment{
+			{Text: "um so uh the the idea is", Start: 0},
+			{Text: "you know pretty simple", Start: 2},
+		}
+		_, fillerTotal := segmentTokenCosts(filler)
+		result, truncated := fitSegmentsToTokenBudget(filler, fillerTotal-1, models.TokenStrategyCompress)
+		if !truncated {
+			t.Error("Expected truncation")
+		}
+		if len(result) != 2 {
+			t.Fatalf("Expected compression to keep both segments, got %d", len(result))
+		}
+		if result[0].Text != "so the idea is" || result[1].Text != "pretty simple" {
+			t.Errorf("Unexpected compressed text: %q / %q", result[0].Text, result[1].Text)
+		}
+	})
+}
+
+func TestDistributeTokenBudget(t *testing.T) {
+	budgets := distributeTokenBudget([]int{50, 1000, 400}, 900)
+
+	if budgets[0] != 50 {
+		t.Errorf("Expected short transcript to keep its full 50 tokens, got %d", budgets[0])
+	}
+	if budgets[1] != 450 || budgets[2] != 400 {
+		t.Errorf("Expected remaining budget shared as 450/400, got %d/%d", budgets[1], budgets[2])
+	}
+}
+
+func TestApplyTranscriptOptions(t *testing.T) {
+	s := &Service{logger: slog.Default()}
+
+	original := &models.TranscriptResponse{
+		VideoID:    "dQw4w9WgXcQ",
+		Transcript: makeSegments(20),
+	}
+	original.FormattedText = s.formatTranscriptText(original.Transcript)
+
+	result := s.applyTranscriptOptions(original, models.TranscriptOptions{TokenStrategy: models.TokenStrategyTruncate}, 20)
+
+	if !result.Truncated {
+		t.Error("Expected truncated flag")
+	}
+	if result.TokenCount == 0 || result.TokenCount > 20 {
+		t.Errorf("Expected token count within budget, got %d", result.TokenCount)
+	}
+	if len(original.Transcript) != 20 {
+		t.Error("Expected the original response to be left untouched")
+	}
+	if result.WordCount != s.countWords(result.FormattedText) {
+		t.Error("Expected word count to match the truncated text")
+	}
+}
@@ -0,0 +1,132 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// GetTranscriptRange returns the transcript segments between two offsets, clipped at the edges and
+// rendered in any format_transcript output type
+func (s *Service) GetTranscriptRange(ctx context.Context, videoIdentifier string, opts models.TranscriptRangeOptions) (*models.TranscriptRangeResponse, error) {
+	start := 0.0
+	if opts.Start != nil {
+		start = *opts.Start
+	} else if offset, ok := extractStartOffset(videoIdentifier); ok {
+		start = offset
+	}
+
+	transcript, err := s.GetTranscript(ctx, videoIdentifier, opts.Languages, true)
+	if err != nil {
+		return nil, err
+	}
+
+	end := s.calculateDuration(transcript.Transcript)
+	if opts.End != nil {
+		end = *opts.End
+	}
+
+	if end <= start {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeValidationError,
+			Message: fmt.Sprintf("Range end (%.3fs) must be after start (%.3fs)", end, start),
+			VideoID: transcript.VideoID,
+		}
+	}
+
+	segments := clipSegments(transcript.Transcript, start, end)
+	if opts.Rebase {
+		segments = shiftSegments(segments, -start)
+	}
+
+	formatType := opts.Format.FormatType
+	if formatType == "" {
+		formatType = models.FormatTypePlainText
+	}
+
+	formatted, err := s.renderSegments(segments, renderOptions{
+		formatType:        formatType,
+		includeTimestamps: opts.Format.IncludeTimestamps,
+		transcriptType:    transcript.TranscriptType,
+		paragraphs:        opts.Format.Paragraphs,
+		chapters:          s.chaptersForFormat(ctx, formatType, transcript.VideoID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TranscriptRangeResponse{
+		VideoID:       transcript.VideoID,
+		Title:         transcript.Title,
+		Language:      transcript.Language,
+		FormatType:    formatType,
+		FormattedText: formatted,
+		URL:           buildTimestampURL(transcript.VideoID, start),
+		Transcript:    withoutWordTimings(segments),
+		Start:         start,
+		End:           end,
+		WordCount:     s.countWords(joinSegmentText(segments)),
+		Rebased:       opts.Rebase,
+	}, nil
+}
+
+// clipSegments returns copies of the segments overlapping [start, end) with their timing clipped to the range
+func clipSegments(segments []models.TranscriptSegment, start, end float64) []models.TranscriptSegment {
+	clipped := make([]models.TranscriptSegment, 0)
+	for _, segment := range segments {
+		segmentStop := segmentEnd(segment)
+		if segmentStop <= start || segment.Start >= end {
+			continue
+		}
+
+		segment.Start = max(segment.Start, start)
+		segment.End = min(segmentStop, end)
+		segment.Duration = segment.End - segment.Start
+		clipped = append(clipped, segment)
+	}
+	return clipped
+}
+
+// shiftSegments moves every segment by delta seconds
+func shiftSegments(segments []models.TranscriptSegment, delta float64) []models.TranscriptSegment {
+	shifted := make([]models.TranscriptSegment, len(segments))
+	for i, segment := range segments {
+		segment.Start += delta
+		segment.End += delta
+		shifted[i] = segment
+	}
+	return shifted
+}
+
+// extractStartOffset reads the playback offset from a pasted YouTube URL's t= or start= parameter,
+// in the query string or the fragment
+func extractStartOffset(identifier string) (float64, bool) {
+	if !strings.Contains(identifier, "://") {
+		return 0, false
+	}
+
+	parsed, err := url.Parse(identifier)
+	if err != nil {
+		return 0, false
+	}
+
+	candidates := []url.Values{parsed.Query()}
+	if fragment, err := url.ParseQuery(parsed.Fragment); err == nil {
+		candidates = append(candidates, fragment)
+	}
+
+	for _, values := range candidates {
+		for _, key := range []string{"t", "start"} {
+			if value := values.Get(key); value != "" {
+				if seconds, err := models.ParseTimeOffset(value); err == nil {
+					return seconds, true
+				}
+			}
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,73 @@
+package youtube
+
+import (
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestClipSegments(t *testing.T) {
+	segments := []models.TranscriptSegment{
+		{Text: "a", Start: 0, Duration: 4, End: 4},
+		{Text: "b", Start: 4, Duration: 4, End: 8},
+		{Text: "c", Start: 8, Duration: 4, End: 12},
+		{Text: "d", Start: 12, Duration: 4, End: 16},
+	}
+
+	clipped := clipSegments(segments, 6, 10)
+	if len(clipped) != 2 {
+		t.Fatalf("Expected 2 segments, got %d", len(clipped))
+	}
+	if clipped[0].Text != "b" || clipped[0].Start != 6 || clipped[0].End != 8 || clipped[0].Duration != 2 {
+		t.Errorf("Unexpected first segment: %+v", clipped[0])
+	}
+	if clipped[1].Text != "c" || clipped[1].Start != 8 || clipped[1].End != 10 || clipped[1].Duration != 2 {
+		t.Errorf("Unexpected last segment: %+v", clipped[1])
+	}
+	if segments[1].Start != 4 {
+		t.Error("Expected the original segments to be left untouched")
+	}
+
+	if len(clipSegments(segments, 16, 20)) != 0 {
+		t.Error("Expected no segments after the end of the transcript")
+	}
+}
+
+func TestShiftSegments(t *testing.T) {
+	segments := []models.TranscriptSegment{
+		{Text: "a", Start: 750, Duration: 2, End: 752},
+	}
+
+	shifted := shiftSegments(segments, -750)
+	if shifted[0].Start != 0 || shifted[0].End != 2 || shifted[0].Duration != 2 {
+		t.Errorf("Unexpected shifted segment: %+v", shifted[0])
+	}
+
+	s := &Service{}
+	srt := s.formatAsSRT(shifted)
+	if srt != "1\n00:00:00,000 --> 00:00:02,000\na" {
+		t.Errorf("Expected rebased SRT cue, got %q", srt)
+	}
+}
+
+func TestExtractStartOffset(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+		found    bool
+	}{
+		{"https://youtu.be/dQw4w9WgXcQ?t=83", 83, true},
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=1m23s", 83, true},
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ#t=1h0m5s", 3605, true},
+		{"https://www.youtube.com/embed/dQw4w9WgXcQ?start=42", 42, true},
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ", 0, false},
+		{"dQw4w9WgXcQ", 0, false},
+	}
+
+	for _, tt := range tests {
+		result, found := extractStartOffset(tt.input)
+		if found != tt.found || result != tt.expected {
+			t.Errorf("extractStartOffset(%q) = %v, %v; want %v, %v", tt.input, result, found, tt.expected, tt.found)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// SearchTranscript finds query matches in a video's transcript and returns them with timing and deep links
+func (s *Service) SearchTranscript(ctx context.Context, videoIdentifier string, opts models.TranscriptSearchOptions) (*models.SearchTranscriptResponse, error) {
+	if opts.Mode == "" {
+		opts.Mode = models.SearchModeKeyword
+	}
+
+	pattern, err := buildSearchPattern(opts)
+	if err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeValidationError,
+			Message: fmt.Sprintf("Invalid search query: %s", err.Error()),
+			VideoID: videoIdentifier,
+		}
+	}
+
+	transcript, err := s.GetTranscript(ctx, videoIdentifier, opts.Languages, true)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := searchSegments(transcript.Transcript, pattern, opts.FoldDiacritics, opts.ContextSegments)
+	for i := range matches {
+		matches[i].URL = buildTimestampURL(transcript.VideoID, matches[i].Start)
+	}
+
+	response := &models.SearchTranscriptResponse{
+		VideoID:      transcript.VideoID,
+		Title:        transcript.Title,
+		Language:     transcript.Language,
+		Query:        opts.Query,
+		Mode:         opts.Mode,
+		Matches:      matches,
+		TotalMatches: len(matches),
+	}
+
+	if opts.MaxResults > 0 && len(response.Matches) > opts.MaxResults {
+		response.Matches = response.Matches[:opts.MaxResults]
+	}
+
+	return response, nil
+}
+
+// buildSearchPattern compiles the query into a regular expression according to the search mode
+func buildSearchPattern(opts models.TranscriptSearchOptions) (*regexp.Regexp, error) {
+	query := strings.TrimSpace(opts.Query)
+	if query == "" {
+		return nil, fmt.Errorf("query is empty")
+	}
+	if opts.FoldDiacritics {
+		query = foldDiacritics(query)
+	}
+
+	var expr string
+	switch opts.Mode {
+	case models.SearchModeRegex:
+		expr = query
+	case models.SearchModePhrase:
+		words := strings.Fields(query)
+		for i, word := range words {
+			words[i] = regexp.QuoteMeta(word)
+		}
+		expr = boundaryBefore(words[0]) + strings.Join(words, `\s+`) + boundaryAfter(words[len(words)-1])
+	case models.SearchModeKeyword:
+		words := strings.Fields(query)
+		alternatives := make([]string, 0, len(words))
+		for _, word := range words {
+			quoted := regexp.QuoteMeta(word)
+			alternatives = append(alternatives, boundaryBefore(word)+quoted+boundaryAfter(word))
+		}
+		expr = strings.Join(alternatives, "|")
+	default:
+		return nil, fmt.Errorf("unknown search mode: %s", opts.Mode)
+	}
+
+	if !opts.CaseSensitive {
+		expr = "(?i)" + expr
+	}
+
+	return regexp.Compile(expr)
+}
+
+// boundaryBefore and boundaryAfter return \b only next to ASCII word characters, since Go's \b is ASCII-only.
+// Scripts without spaces, such as Japanese, never get a boundary so substrings still match.
+func boundaryBefore(word string) string {
+	r, _ := utf8.DecodeRuneInString(word)
+	return asciiWordBoundary(r)
+}
+
+func boundaryAfter(word string) string {
+	r, _ := utf8.DecodeLastRuneInString(word)
+	return asciiWordBoundary(r)
+}
+
+func asciiWordBoundary(r rune) string {
+	if r < utf8.RuneSelf && (r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+		return `\b`
+	}
+	return ""
+}
+
+// searchSegments matches pattern against the joined segment text so hits can span segment boundaries
+func searchSegments(segments []models.TranscriptSegment, pattern *regexp.Regexp, fold bool, contextSegments int) []models.TranscriptSearchMatch {
+	if len(segments) == 0 {
+		return []models.TranscriptSearchMatch{}
+	}
+
+	// Build one searchable document and remember where each segment starts
+	var document strings.Builder
+	offsets := make([]int, len(segments))
+	for i, segment := range segments {
+		if i > 0 {
+			document.WriteString(" ")
+		}
+		offsets[i] = document.Len()
+		text := segment.Text
+		if fold {
+			text = foldDiacritics(text)
+		}
+		document.WriteString(text)
+	}
+
+	segmentAt := func(offset int) int {
+		return sort.Search(len(offsets), func(i int) bool { return offsets[i] > offset }) - 1
+	}
+
+	matches := []models.TranscriptSearchMatch{}
+	lastSegment := -1
+	for _, loc := range pattern.FindAllStringIndex(document.String(), -1) {
+		if loc[1] == loc[0] {
+			continue
+		}
+
+		first := segmentAt(loc[0])
+		last := segmentAt(loc[1] - 1)
+
+		// Report each segment once even if it contains several hits
+		if first <= lastSegment {
+			continue
+		}
+		lastSegment = last
+
+		texts := make([]string, 0, last-first+1)
+		for i := first; i <= last; i++ {
+			texts = append(texts, segments[i].Text)
+		}
+
+		match := models.TranscriptSearchMatch{
+			Text:         strings.Join(texts, " "),
+			Start:        segments[first].Start,
+			End:          segmentEnd(segments[last]),
+			SegmentIndex: first,
+			SegmentCount: last - first + 1,
+		}
+		match.ContextBefore = joinSegmentText(segments[max(0, first-contextSegments):first])
+		match.ContextAfter = joinSegmentText(segments[last+1 : min(len(segments), last+1+contextSegments)])
+
+		matches = append(matches, match)
+	}
+
+	return matches
+}
+
+// segmentEnd returns the end time of a segment, deriving it from the duration when End is unset
+func segmentEnd(segment models.TranscriptSegment) float64 {
+	if segment.End > 0 {
+		return segment.End
+	}
+	return segment.Start + segment.Duration
+}
+
+// joinSegmentText joins the text of segments with spaces
+func joinSegmentText(segments []models.TranscriptSegment) string {
+	texts := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		texts = append(texts, segment.Text)
+	}
+	return strings.Join(texts, " ")
+}
+
+// foldDiacritics removes combining accents from Latin, Greek and Cyrillic letters so "café" matches "cafe".
+// Only the Combining Diacritical Marks block is stripped, which keeps Japanese voicing marks intact.
+func foldDiacritics(text string) string {
+	combining := runes.Predicate(func(r rune) bool {
+		return r >= 0x0300 && r <= 0x036F
+	})
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(combining), norm.NFC), text)
+	if err != nil {
+		return text
+	}
+	return folded
+}
+
+// buildTimestampURL returns a short link that starts playback at the given offset
+func buildTimestampURL(videoID string, seconds float64) string {
+	return fmt.Sprintf("https://youtu.be/%s?t=%d", videoID, int(seconds))
+}
@@ -0,0 +1,132 @@
+package youtube
+
+import (
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestSearchSegments(t *testing.T) {
+	segments := []models.TranscriptSegment{
+		{Text: "Welcome to the café", Start: 0, Duration: 2, End: 2},
+		{Text: "today we talk about", Start: 2, Duration: 2, End: 4},
+		{Text: "machine learning and", Start: 4, Duration: 2, End: 6},
+		{Text: "why Machine Learning matters", Start: 6, Duration: 3, End: 9},
+		{Text: "機械学習の話です", Start: 9, Duration: 2, End: 11},
+	}
+
+	tests := []struct {
+		name          string
+		opts          models.TranscriptSearchOptions
+		expectedStart []float64
+		expectedCount []int
+	}{
+		{
+			name:          "keyword is case insensitive",
+			opts:          models.TranscriptSearchOptions{Query: "machine", Mode: models.SearchModeKeyword},
+			expectedStart: []float64{4, 6},
+			expectedCount: []int{1, 1},
+		},
+		{
+			name:          "case sensitive keyword",
+			opts:          models.TranscriptSearchOptions{Query: "Machine", Mode: models.SearchModeKeyword, CaseSensitive: true},
+			expectedStart: []float64{6},
+			expectedCount: []int{1},
+		},
+		{
+			name:          "phrase spanning segments",
+			opts:          models.TranscriptSearchOptions{Query: "about machine learning", Mode: models.SearchModePhrase},
+			expectedStart: []float64{2},
+			expectedCount: []int{2},
+		},
+		{
+			name:          "diacritics folded",
+			opts:          models.TranscriptSearchOptions{Query: "cafe", Mode: models.SearchModeKeyword, FoldDiacritics: true},
+			expectedStart: []float64{0},
+			expectedCount: []int{1},
+		},
+		{
+			name:          "diacritics kept",
+			opts:          models.TranscriptSearchOptions{Query: "cafe", Mode: models.SearchModeKeyword},
+			expectedStart: []float64{},
+			expectedCount: []int{},
+		},
+		{
+			name:          "regex",
+			opts:          models.TranscriptSearchOptions{Query: `learn\w+ (and|matters)`, Mode: models.SearchModeRegex},
+			expectedStart: []float64{4, 6},
+			expectedCount: []int{1, 1},
+		},
+		{
+			name:          "japanese substring",
+			opts:          models.TranscriptSearchOptions{Query: "学習", Mode: models.SearchModeKeyword},
+			expectedStart: []float64{9},
+			expectedCount: []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := buildSearchPattern(tt.opts)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			matches := searchSegments(segments, pattern, tt.opts.FoldDiacritics, 1)
+			if len(matches) != len(tt.expectedStart) {
+				t.Fatalf("Expected %d matches, got %d: %+v", len(tt.expectedStart), len(matches), matches)
+			}
+			for i, match := range matches {
+				if match.Start != tt.expectedStart[i] {
+					t.Errorf("Match %d: expected start %v, got %v", i, tt.expectedStart[i], match.Start)
+				}
+				if match.SegmentCount != tt.expectedCount[i] {
+					t.Errorf("Match %d: expected %d segments, got %d", i, tt.expectedCount[i], match.SegmentCount)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchSegmentsContext(t *testing.T) {
+	segments := []models.TranscriptSegment{
+		{Text: "one", Start: 0, End: 1},
+		{Text: "two", Start: 1, End: 2},
+		{Text: "three", Start: 2, End: 3},
+		{Text: "four", Start: 3, End: 4},
+	}
+
+	pattern, err := buildSearchPattern(models.TranscriptSearchOptions{Query: "two", Mode: models.SearchModeKeyword})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	matches := searchSegments(segments, pattern, false, 2)
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 match, got %d", len(matches))
+	}
+	if matches[0].ContextBefore != "one" {
+		t.Errorf("Expected context before 'one', got %q", matches[0].ContextBefore)
+	}
+	if matches[0].ContextAfter != "three four" {
+		t.Errorf("Expected context after 'three four', got %q", matches[0].ContextAfter)
+	}
+	if matches[0].End != 2 {
+		t.Errorf("Expected end 2, got %v", matches[0].End)
+	}
+}
+
+func TestBuildSearchPatternInvalidRegex(t *testing.T) {
+	_, err := buildSearchPattern(models.TranscriptSearchOptions{Query: "(unclosed", Mode: models.SearchModeRegex})
+	if err == nil {
+		t.Error("Expected error for invalid regex")
+	}
+}
+
+func TestBuildTimestampURL(t *testing.T) {
+	result := buildTimestampURL("dQw4w9WgXcQ", 83.7)
+	expected := "https://youtu.be/dQw4w9WgXcQ?t=83"
+	if result != expected {
+		t.Errorf("Expected %s, got %s", expected, result)
+	}
+}
@@ -0,0 +1,232 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// defaultTranslationBatchSize is the number of segments sent per translation request when unconfigured
+const defaultTranslationBatchSize = 50
+
+// translateWithProvider translates a caption track with the external translator. The source is the
+// requested language's track, or the track GetTranscript would pick by default.
+func (s *Service) translateWithProvider(ctx context.Context, videoData *VideoData, tracks []CaptionTrack, targetLanguage, sourceLanguage string) (*models.TranscriptResponse, error) {
+	var track *CaptionTrack
+	if sourceLanguage != "" {
+		track = findTrackByLanguage(tracks, sourceLanguage)
+		if track == nil {
+			return nil, &models.TranscriptError{
+				Type:        models.ErrorTypeLanguageNotAvailable,
+				Message:     fmt.Sprintf("No captions available in source language %q", sourceLanguage),
+				VideoID:     videoData.VideoID,
+				Suggestions: s.getAvailableLanguageCodes(tracks),
+			}
+		}
+	} else {
+		track = s.selectBestTrack(tracks, s.config.DefaultLanguages)
+	}
+
+	segments, err := s.fetchTranscriptFromTrack(ctx, track, false)
+	if err != nil {
+		s.recordRateLimitFailure(err)
+		return nil, err
+	}
+
+	translated, err := translateSegments(ctx, s.translator, segments, track.LanguageCode, targetLanguage, s.config.TranslationBatchSize)
+	if err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeTranslationFailed,
+			Message: fmt.Sprintf("Translation with %s failed: %s", s.translator.Name(), err.Error()),
+			VideoID: videoData.VideoID,
+		}
+	}
+
+	response := s.buildTranscriptResponse(videoData, track, translated, false)
+	response.Language = targetLanguage
+	response.TranscriptType = models.TranscriptTypeTranslated
+	response.Metadata.IsTranslated = true
+	response.Metadata.SourceLanguage = track.LanguageCode
+	response.Metadata.TargetLanguage = targetLanguage
+	response.Metadata.TranslationProvider = s.translator.Name()
+	return response, nil
+}
+
+// translateSegments translates segment text in batches of batchSize, keeping each segment's timing.
+// Word timings are dropped, as they time the source words. Blank segments are passed through rather
+// than sent to the provider.
+func translateSegments(ctx context.Context, translator Translator, segments []models.TranscriptSegment, source, target string, batchSize int) ([]models.TranscriptSegment, error) {
+	if batchSize <= 0 {
+		batchSize = defaultTranslationBatchSize
+	}
+
+	translated := make([]models.TranscriptSegment, len(segments))
+	copy(translated, segments)
+	for i := range translated {
+		translated[i].Words = nil
+	}
+
+	pending := make([]int, 0, len(segments))
+	for i, segment := range segments {
+		if strings.TrimSpace(segment.Text) != "" {
+			pending = append(pending, i)
+		}
+	}
+
+	for start := 0; start < len(pending); start += batchSize {
+		batch := pending[start:min(start+batchSize, len(pending))]
+		texts := make([]string, len(batch))
+		for i, index := range batch {
+			texts[i] = segments[index].Text
+		}
+
+		results, err := translator.Translate(ctx, texts, source, target)
+		if err != nil {
+			return nil, err
+		}
+		if len(results) != len(texts) {
+			return nil, fmt.Errorf("expected %d translations, got %d", len(texts), len(results))
+		}
+		for i, index := range batch {
+			translated[index].Text = results[i]
+		}
+	}
+
+	return translated, nil
+}
+
+// matchesLanguage reports whether a language code matches a requested one, either exactly or as a
+// regional variant such as "en-US" for "en". The comparison ignores case.
+func matchesLanguage(code, requested string) bool {
+	code, requested = strings.ToLower(code), strings.ToLower(requested)
+	return code == requested || strings.HasPrefix(code, requested+"-")
+}
+
+// findTrackByLanguage returns the track in a language, preferring exact codes and manual captions
+// over automatic ones
+func findTrackByLanguage(tracks []CaptionTrack, language string) *CaptionTrack {
+	var best *CaptionTrack
+	bestRank := 0
+	for i := range tracks {
+		track := &tracks[i]
+		if !matchesLanguage(track.LanguageCode, language) {
+			continue
+		}
+		rank := 1
+		if strings.EqualFold(track.LanguageCode, language) {
+			rank += 2
+		}
+		if track.Kind != "asr" {
+			rank++
+		}
+		if rank > bestRank {
+			best, bestRank = track, rank
+		}
+	}
+	return best
+}
+
+// selectTranslationSource picks the track to translate from. A requested source language must
+// have a translatable track; otherwise the default track is preferred, then manual captions.
+func selectTranslationSource(tracks []CaptionTrack, sourceLanguage, videoID string) (*CaptionTrack, error) {
+	if sourceLanguage != "" {
+		track := findTrackByLanguage(tracks, sourceLanguage)
+		if track == nil {
+			return nil, &models.TranscriptError{
+				Type:        models.ErrorTypeLanguageNotAvailable,
+				Message:     fmt.Sprintf("No captions available in source language %q", sourceLanguage),
+				VideoID:     videoID,
+				Suggestions: translatableTrackCodes(tracks),
+			}
+		}
+		if !track.IsTranslatable {
+			return nil, &models.TranscriptError{
+				Type:        models.ErrorTypeTranslationNotAvailable,
+				Message:     fmt.Sprintf("Captions in %q cannot be translated", track.LanguageCode),
+				VideoID:     videoID,
+				Suggestions: translatableTrackCodes(tracks),
+			}
+		}
+		return track, nil
+	}
+
+	var manual, automatic *CaptionTrack
+	for i := range tracks {
+		track := &tracks[i]
+		if !track.IsTranslatable {
+			continue
+		}
+		if track.IsDefault {
+			return track, nil
+		}
+		if track.Kind == "asr" {
+			if automatic == nil {
+				automatic = track
+			}
+		} else if manual == nil {
+			manual = track
+		}
+	}
+	if manual != nil {
+		return manual, nil
+	}
+	if automatic != nil {
+		return automatic, nil
+	}
+
+	return nil, &models.TranscriptError{
+		Type:    models.ErrorTypeTranslationNotAvailable,
+		Message: "None of this video's caption tracks can be translated",
+		VideoID: videoID,
+	}
+}
+
+// findTranslationLanguage returns the code YouTube uses for a requested target language
+func findTranslationLanguage(languages []TranslationLanguage, target string) (string, bool) {
+	for _, language := range languages {
+		if strings.EqualFold(language.LanguageCode, target) {
+			return language.LanguageCode, true
+		}
+	}
+	for _, language := range languages {
+		if matchesLanguage(language.LanguageCode, target) {
+			return language.LanguageCode, true
+		}
+	}
+	return "", false
+}
+
+// translationLanguageCodes lists the codes of the translation targets
+func translationLanguageCodes(languages []TranslationLanguage) []string {
+	codes := make([]string, 0, len(languages))
+	for _, language := range languages {
+		codes = append(codes, language.LanguageCode)
+	}
+	return codes
+}
+
+// translatableTrackCodes lists the languages of the tracks that can be translated
+func translatableTrackCodes(tracks []CaptionTrack) []string {
+	codes := make([]string, 0, len(tracks))
+	for _, track := range tracks {
+		if track.IsTranslatable {
+			codes = append(codes, track.LanguageCode)
+		}
+	}
+	return codes
+}
+
+// addURLParam returns rawURL with a query parameter set, replacing any existing value
+func addURLParam(rawURL, key, value string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	query := parsed.Query()
+	query.Set(key, value)
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), nil
+}
@@ -0,0 +1,127 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestSelectTranslationSource(t *testing.T) {
+	tracks := []CaptionTrack{
+		{LanguageCode: "en", Kind: "asr", IsTranslatable: true},
+		{LanguageCode: "en", IsTranslatable: true},
+		{LanguageCode: "fr-CA", IsTranslatable: false},
+	}
+
+	tests := []struct {
+		name      string
+		source    string
+		expected  *CaptionTrack
+		errorType string
+	}{
+		{"manual preferred over automatic", "", &tracks[1], ""},
+		{"requested source", "en", &tracks[1], ""},
+		{"regional source", "fr", nil, models.ErrorTypeTranslationNotAvailable},
+		{"missing source", "de", nil, models.ErrorTypeLanguageNotAvailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			track, err := selectTranslationSource(tracks, tt.source, "abc")
+			if tt.errorType != "" {
+				transcriptErr, ok := err.(*models.TranscriptError)
+				if !ok || transcriptErr.Type != tt.errorType {
+					t.Fatalf("Expected %s error, got %v", tt.errorType, err)
+				}
+				if len(transcriptErr.Suggestions) != 2 {
+					t.Errorf("Expected translatable tracks as suggestions, got %v", transcriptErr.Suggestions)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if track != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, track)
+			}
+		})
+	}
+}
+
+func TestFindTranslationLanguage(t *testing.T) {
+	languages := []TranslationLanguage{{LanguageCode: "de"}, {LanguageCode: "zh-Hans"}, {LanguageCode: "zh-Hant"}}
+
+	if code, ok := findTranslationLanguage(languages, "zh-hant"); !ok || code != "zh-Hant" {
+		t.Errorf("Expected case-insensitive match zh-Hant, got %q", code)
+	}
+	if code, ok := findTranslationLanguage(languages, "zh"); !ok || code != "zh-Hans" {
+		t.Errorf("Expected prefix match zh-Hans, got %q", code)
+	}
+	if _, ok := findTranslationLanguage(languages, "ja"); ok {
+		t.Error("Expected ja to be unsupported")
+	}
+}
+
+func TestTranslateTranscriptUsesTlang(t *testing.T) {
+	var requestedTlang []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/watch", func(w http.ResponseWriter, r *http.Request) {
+		captionURL := "http://" + r.Host + "/api/timedtext"
+		fmt.Fprintf(w, `<script>var ytInitialPlayerResponse = {"videoDetails":{"videoId":"dQw4w9WgXcQ","title":"Demo"},"captions":{"playerCaptionsTracklistRenderer":{`+
+			`"captionTracks":[{"baseUrl":"%[1]s?v=dQw4w9WgXcQ&lang=en","languageCode":"en","isTranslatable":true,"isDefault":true},`+
+			`{"baseUrl":"%[1]s?v=dQw4w9WgXcQ&lang=ja","languageCode":"ja","isTranslatable":false}],`+
+			`"translationLanguages":[{"languageCode":"de","languageName":{"simpleText":"German"}},{"languageCode":"es","languageName":{"simpleText":"Spanish"}}]}}};</script>`, captionURL)
+	})
+	mux.HandleFunc("/api/timedtext", func(w http.ResponseWriter, r *http.Request) {
+		tlang := r.URL.Query().Get("tlang")
+		requestedTlang = append(requestedTlang, tlang)
+		text := map[string]string{"": "Hello world", "de": "Hallo Welt"}[tlang]
+		if r.URL.Query().Get("lang") == "ja" {
+			text = "こんにちは"
+		}
+		fmt.Fprintf(w, `<transcript><text start="0" dur="2">%s</text></transcript>`, text)
+	})
+
+	service := newTestService(t, mux)
+	ctx := context.Background()
+
+	translated, err := service.TranslateTranscript(ctx, "dQw4w9WgXcQ", "de", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if translated.Language != "de" || translated.TranscriptType != models.TranscriptTypeTranslated {
+		t.Errorf("Expected a translated German transcript, got %s (%s)", translated.Language, translated.TranscriptType)
+	}
+	if !translated.Metadata.IsTranslated || translated.Metadata.SourceLanguage != "en" || translated.Metadata.TargetLanguage != "de" {
+		t.Errorf("Unexpected translation metadata: %+v", translated.Metadata)
+	}
+	if len(translated.Transcript) != 1 || translated.Transcript[0].Text != "Hallo Welt" {
+		t.Errorf("Unexpected transcript: %+v", translated.Transcript)
+	}
+
+	// A native track in the target language is returned without translation
+	native, err := service.TranslateTranscript(ctx, "dQw4w9WgXcQ", "ja", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if native.Metadata.IsTranslated || native.Transcript[0].Text != "こんにちは" {
+		t.Errorf("Expected the native Japanese track, got %+v", native)
+	}
+	if strings.Join(requestedTlang, ",") != "de," {
+		t.Errorf("Unexpected tlang requests: %q", requestedTlang)
+	}
+
+	// Unsupported targets list what YouTube can translate to
+	_, err = service.TranslateTranscript(ctx, "dQw4w9WgXcQ", "fr", "")
+	transcriptErr, ok := err.(*models.TranscriptError)
+	if !ok || transcriptErr.Type != models.ErrorTypeTranslationNotAvailable {
+		t.Fatalf("Expected translation not available error, got %v", err)
+	}
+	if strings.Join(transcriptErr.Suggestions, ",") != "de,es" {
+		t.Errorf("Expected translation languages as suggestions, got %v", transcriptErr.Suggestions)
+	}
+}
@@ -0,0 +1,238 @@
+package youtube
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/youtube-transcript-mcp/internal/config"
+)
+
+// Translation provider names as configured in YouTubeConfig.TranslationProvider
+const (
+	TranslationProviderLibreTranslate = "libretranslate"
+	TranslationProviderDeepL          = "deepl"
+)
+
+// DeepL API endpoints; keys of free accounts end in ":fx" and must use the free endpoint
+const (
+	deeplAPIURL     = "https://api.deepl.com"
+	deeplFreeAPIURL = "https://api-free.deepl.com"
+)
+
+// Translator translates text with an external machine translation service. It is used when
+// YouTube cannot translate a caption track itself.
+type Translator interface {
+	// Name identifies the provider in transcript metadata
+	Name() string
+	// Translate translates texts into target and returns one translation per text, in order.
+	// An empty source asks the provider to detect the language.
+	Translate(ctx context.Context, texts []string, source, target string) ([]string, error)
+}
+
+// NewTranslator creates the translator selected in cfg, or nil when none is configured
+func NewTranslator(cfg config.YouTubeConfig) (Translator, error) {
+	client := &http.Client{Timeout: cfg.RequestTimeout}
+
+	switch cfg.TranslationProvider {
+	case "":
+		return nil, nil
+	case TranslationProviderLibreTranslate:
+		if cfg.TranslationURL == "" {
+			return nil, fmt.Errorf("libretranslate requires a translation URL")
+		}
+		return &LibreTranslateTranslator{
+			baseURL: strings.TrimRight(cfg.TranslationURL, "/"),
+			apiKey:  cfg.TranslationAPIKey,
+			client:  client,
+		}, nil
+	case TranslationProviderDeepL:
+		if cfg.TranslationAPIKey == "" {
+			return nil, fmt.Errorf("deepl requires a translation API key")
+		}
+		baseURL := cfg.TranslationURL
+		if baseURL == "" {
+			baseURL = deeplAPIURL
+			if strings.HasSuffix(cfg.TranslationAPIKey, ":fx") {
+				baseURL = deeplFreeAPIURL
+			}
+		}
+		return &DeepLTranslator{
+			baseURL: strings.TrimRight(baseURL, "/"),
+			apiKey:  cfg.TranslationAPIKey,
+			client:  client,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown translation provider: %s", cfg.TranslationProvider)
+	}
+}
+
+// LibreTranslateTranslator calls a LibreTranslate-compatible /translate endpoint
+type LibreTranslateTranslator struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+}
+
+// Name implements Translator
+func (t *LibreTranslateTranslator) Name() string {
+	return TranslationProviderLibreTranslate
+}
+
+// Translate implements Translator
+func (t *LibreTranslateTranslator) Translate(ctx context.Context, texts []string, source, target string) ([]string, error) {
+	request := map[string]any{
+		"q":      texts,
+		"source": "auto",
+		"target": baseLanguage(target),
+		"format": "text",
+	}
+	if source != "" {
+		request["source"] = baseLanguage(source)
+	}
+	if t.apiKey != "" {
+		request["api_key"] = t.apiKey
+	}
+
+	var response struct {
+		TranslatedText []string `json:"translatedText"`
+		Error          string   `json:"error"`
+	}
+	if err := postJSON(ctx, t.client, t.baseURL+"/translate", request, nil, &response); err != nil {
+		return nil, err
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("libretranslate: %s", response.Error)
+	}
+	return response.TranslatedText, nil
+}
+
+// DeepLTranslator calls the DeepL v2 translate API
+type DeepLTranslator struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+}
+
+// Name implements Translator
+func (t *DeepLTranslator) Name() string {
+	return TranslationProviderDeepL
+}
+
+// Translate implements Translator
+func (t *DeepLTranslator) Translate(ctx context.Context, texts []string, source, target string) ([]string, error) {
+	request := map[string]any{
+		"text":        texts,
+		"target_lang": deepLTargetLanguage(target),
+	}
+	if source != "" {
+		request["source_lang"] = strings.ToUpper(baseLanguage(source))
+	}
+
+	var response struct {
+		Translations []struct {
+			Text string `json:"text"`
+		} `json:"translations"`
+		Message string `json:"message"`
+	}
+	headers := map[string]string{"Authorization": "DeepL-Auth-Key " + t.apiKey}
+	if err := postJSON(ctx, t.client, t.baseURL+"/v2/translate", request, headers, &response); err != nil {
+		return nil, err
+	}
+
+	translations := make([]string, len(response.Translations))
+	for i, translation := range response.Translations {
+		translations[i] = translation.Text
+	}
+	return translations, nil
+}
+
+// deepLRegionalTargets are the regional and script variants DeepL accepts as target languages
+var deepLRegionalTargets = map[string]bool{
+	"EN-GB": true, "EN-US": true,
+	"PT-BR": true, "PT-PT": true,
+	"ZH-HANS": true, "ZH-HANT": true,
+}
+
+// deepLTargetAliases are DeepL's codes for languages it does not take bare, or knows under another code
+var deepLTargetAliases = map[string]string{
+	"EN": "EN-US",
+	"PT": "PT-BR",
+	"IW": "HE",
+	"IN": "ID",
+	"NO": "NB",
+}
+
+// traditionalChineseRegions are the regions that write Chinese in traditional characters
+var traditionalChineseRegions = map[string]bool{"TW": true, "HK": true, "MO": true}
+
+// deepLTargetLanguage returns DeepL's code for a target language. DeepL only takes a region or
+// script for English, Portuguese and Chinese, so any other is dropped; English and Portuguese
+// without one default to American English and Brazilian Portuguese.
+func deepLTargetLanguage(target string) string {
+	code := strings.ToUpper(target)
+	if deepLRegionalTargets[code] {
+		return code
+	}
+	base := strings.ToUpper(baseLanguage(target))
+	if base == "ZH" && code != base {
+		for _, subtag := range strings.Split(code, "-")[1:] {
+			if subtag == "HANT" || traditionalChineseRegions[subtag] {
+				return "ZH-HANT"
+			}
+		}
+		return "ZH-HANS"
+	}
+	if alias, ok := deepLTargetAliases[base]; ok {
+		return alias
+	}
+	return base
+}
+
+// postJSON posts a JSON request to an external API and decodes the JSON response
+func postJSON(ctx context.Context, client *http.Client, endpoint string, request any, headers map[string]string, response any) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			slog.Warn("Failed to close response body", "error", closeErr)
+		}
+	}()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP error %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return json.Unmarshal(data, response)
+}
+
+// baseLanguage strips the region or script from a language code, such as "pt" from "pt-BR"
+func baseLanguage(code string) string {
+	base, _, _ := strings.Cut(code, "-")
+	return strings.ToLower(base)
+}
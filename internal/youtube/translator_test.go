@@ -0,0 +1,230 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/youtube-transcript-mcp/internal/config"
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// libreTranslateStandIn is a local LibreTranslate stand-in that upper-cases text and records batch sizes
+func libreTranslateStandIn(t *testing.T, batches *[]int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/translate" {
+			http.NotFound(w, r)
+			return
+		}
+		var request struct {
+			Q      []string `json:"q"`
+			Source string   `json:"source"`
+			Target string   `json:"target"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		if request.Target != "de" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": request.Target + " is not supported"})
+			return
+		}
+		*batches = append(*batches, len(request.Q))
+		translated := make([]string, len(request.Q))
+		for i, text := range request.Q {
+			translated[i] = strings.ToUpper(text)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"translatedText": translated})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNewTranslator(t *testing.T) {
+	translator, err := NewTranslator(config.YouTubeConfig{})
+	if err != nil || translator != nil {
+		t.Errorf("Expected no translator without a provider, got %v, %v", translator, err)
+	}
+
+	translator, err = NewTranslator(config.YouTubeConfig{TranslationProvider: "deepl", TranslationAPIKey: "key:fx"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if deepl, ok := translator.(*DeepLTranslator); !ok || deepl.baseURL != deeplFreeAPIURL {
+		t.Errorf("Expected a DeepL translator on the free API, got %+v", translator)
+	}
+
+	if _, err := NewTranslator(config.YouTubeConfig{TranslationProvider: "libretranslate"}); err == nil {
+		t.Error("Expected error for libretranslate without a URL")
+	}
+}
+
+func TestLibreTranslateTranslator(t *testing.T) {
+	var batches []int
+	server := libreTranslateStandIn(t, &batches)
+
+	translator, err := NewTranslator(config.YouTubeConfig{TranslationProvider: "libretranslate", TranslationURL: server.URL + "/", RequestTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	results, err := translator.Translate(context.Background(), []string{"hello", "world"}, "en-US", "de")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Join(results, " ") != "HELLO WORLD" {
+		t.Errorf("Unexpected translations %v", results)
+	}
+
+	if _, err := translator.Translate(context.Background(), []string{"hello"}, "", "xx"); err == nil || !strings.Contains(err.Error(), "xx is not supported") {
+		t.Errorf("Expected the provider's error message, got %v", err)
+	}
+}
+
+func TestDeepLTranslator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/translate" || r.Header.Get("Authorization") != "DeepL-Auth-Key secret" {
+			t.Errorf("Unexpected request %s with authorization %q", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		var request struct {
+			Text       []string `json:"text"`
+			TargetLang string   `json:"target_lang"`
+			SourceLang string   `json:"source_lang"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		if request.TargetLang != "PT-BR" || request.SourceLang != "EN" {
+			t.Errorf("Unexpected languages %s -> %s", request.SourceLang, request.TargetLang)
+		}
+		translations := make([]map[string]string, len(request.Text))
+		for i, text := range request.Text {
+			translations[i] = map[string]string{"text": "pt:" + text}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"translations": translations})
+	}))
+	t.Cleanup(server.Close)
+
+	translator, err := NewTranslator(config.YouTubeConfig{TranslationProvider: "deepl", TranslationURL: server.URL, TranslationAPIKey: "secret"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	results, err := translator.Translate(context.Background(), []string{"one", "two"}, "en-GB", "pt-br")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Join(results, ",") != "pt:one,pt:two" {
+		t.Errorf("Unexpected translations %v", results)
+	}
+}
+
+func TestDeepLTargetLanguage(t *testing.T) {
+	tests := []struct {
+		target   string
+		expected string
+	}{
+		{"de", "DE"},
+		{"en", "EN-US"},
+		{"en-gb", "EN-GB"},
+		{"en-AU", "EN-US"},
+		{"pt", "PT-BR"},
+		{"pt-PT", "PT-PT"},
+		{"ja-JP", "JA"},
+		{"es-419", "ES"},
+		{"fr-CA", "FR"},
+		{"zh", "ZH"},
+		{"zh-Hans", "ZH-HANS"},
+		{"zh-CN", "ZH-HANS"},
+		{"zh-TW", "ZH-HANT"},
+		{"zh-Hant-HK", "ZH-HANT"},
+		{"iw", "HE"},
+		{"in", "ID"},
+		{"no", "NB"},
+	}
+
+	for _, tt := range tests {
+		if got := deepLTargetLanguage(tt.target); got != tt.expected {
+			t.Errorf("deepLTargetLanguage(%q) = %q, want %q", tt.target, got, tt.expected)
+		}
+	}
+}
+
+func TestTranslateTranscriptFallsBackToProvider(t *testing.T) {
+	var batches []int
+	translatorServer := libreTranslateStandIn(t, &batches)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/watch", func(w http.ResponseWriter, r *http.Request) {
+		// The only track cannot be translated by YouTube
+		fmt.Fprintf(w, `<script>var ytInitialPlayerResponse = {"videoDetails":{"videoId":"dQw4w9WgXcQ","title":"Demo"},"captions":{"playerCaptionsTracklistRenderer":{`+
+			`"captionTracks":[{"baseUrl":"http://%s/api/timedtext?lang=en","languageCode":"en","isTranslatable":false}]}}};</script>`, r.Host)
+	})
+	mux.HandleFunc("/api/timedtext", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `<transcript><text start="0" dur="2">good morning</text>`+
+			`<text start="3.5" dur="2">welcome</text><text start="5.5" dur="2">to the show</text></transcript>`)
+	})
+
+	service := newTestService(t, mux)
+	service.config.TranslationBatchSize = 2
+	service.translator = &LibreTranslateTranslator{baseURL: translatorServer.URL, client: http.DefaultClient}
+
+	response, err := service.TranslateTranscript(context.Background(), "dQw4w9WgXcQ", "de", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if response.Language != "de" || response.Metadata.TranslationProvider != TranslationProviderLibreTranslate || response.Metadata.SourceLanguage != "en" {
+		t.Errorf("Unexpected response labels: %s %+v", response.Language, response.Metadata)
+	}
+	if len(batches) != 2 || batches[0] != 2 || batches[1] != 1 {
+		t.Errorf("Expected batches of 2 and 1 segments, got %v", batches)
+	}
+	if len(response.Transcript) != 3 || response.Transcript[0].Text != "GOOD MORNING" || response.Transcript[2].Text != "TO THE SHOW" {
+		t.Fatalf("Unexpected transcript %+v", response.Transcript)
+	}
+	if response.Transcript[1].Start != 3.5 || response.Transcript[1].Duration != 2 {
+		t.Errorf("Expected timing to be kept, got %+v", response.Transcript[1])
+	}
+
+	// Provider errors surface as typed translation failures
+	_, err = service.TranslateTranscript(context.Background(), "dQw4w9WgXcQ", "xx", "")
+	if transcriptErr, ok := err.(*models.TranscriptError); !ok || transcriptErr.Type != models.ErrorTypeTranslationFailed {
+		t.Errorf("Expected translation failed error, got %v", err)
+	}
+}
+
+// upperTranslator upper-cases text in place of a translation provider
+type upperTranslator struct{}
+
+func (upperTranslator) Name() string { return "upper" }
+
+func (upperTranslator) Translate(_ context.Context, texts []string, _, _ string) ([]string, error) {
+	translated := make([]string, len(texts))
+	for i, text := range texts {
+		translated[i] = strings.ToUpper(text)
+	}
+	return translated, nil
+}
+
+func TestTranslateSegmentsDropsWordTimings(t *testing.T) {
+	segments := []models.TranscriptSegment{
+		{Text: "good morning", Start: 0, Duration: 2, Words: []models.TranscriptWord{
+			{Text: "good", Start: 0, Duration: 1}, {Text: "morning", Start: 1, Duration: 1}}},
+	}
+
+	translated, err := translateSegments(context.Background(), upperTranslator{}, segments, "en", "de", 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if translated[0].Text != "GOOD MORNING" || translated[0].Words != nil {
+		t.Errorf("Expected translated text without source word timings, got %+v", translated[0])
+	}
+	if segments[0].Words == nil {
+		t.Error("translateSegments() removed the word timings of its input")
+	}
+}
@@ -0,0 +1,106 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// GetVideoMetadata returns the details of a video from its watch page without fetching any caption track
+func (s *Service) GetVideoMetadata(ctx context.Context, videoIdentifier string) (*models.VideoInfo, error) {
+	videoID, err := s.extractVideoID(videoIdentifier)
+	if err != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeInvalidVideoID,
+			Message: fmt.Sprintf("Invalid video identifier: %s", err.Error()),
+			VideoID: videoIdentifier,
+		}
+	}
+
+	// Check cache
+	cacheKey := fmt.Sprintf("%s%s", models.CacheKeyPrefixVideoInfo, videoID)
+	if cached, found := s.cache.Get(ctx, cacheKey); found {
+		if info, ok := cached.(*models.VideoInfo); ok {
+			return info, nil
+		}
+	}
+
+	if waitErr := s.waitForRateLimit(ctx); waitErr != nil {
+		return nil, &models.TranscriptError{
+			Type:    models.ErrorTypeRateLimitExceeded,
+			Message: fmt.Sprintf("Rate limit exceeded: %s", waitErr.Error()),
+			VideoID: videoID,
+		}
+	}
+
+	videoData, err := s.fetchVideoData(ctx, videoID)
+	if err != nil {
+		s.recordRateLimitFailure(err)
+		return nil, err
+	}
+	s.recordRateLimitSuccess()
+
+	info := videoInfoFromVideoData(videoData)
+
+	if err := s.cache.Set(ctx, cacheKey, info, s.metadataTTL()); err != nil {
+		s.logger.Warn("Failed to cache video metadata", "error", err)
+	}
+
+	return info, nil
+}
+
+// videoInfoFromVideoData converts parsed page data to the VideoInfo model
+func videoInfoFromVideoData(videoData *VideoData) *models.VideoInfo {
+	info := &models.VideoInfo{
+		ID:              videoData.VideoID,
+		Title:           videoData.Title,
+		Description:     videoData.Description,
+		ChannelID:       videoData.ChannelID,
+		ChannelName:     videoData.ChannelName,
+		Category:        videoData.Category,
+		Keywords:        videoData.Keywords,
+		Thumbnails:      videoData.Thumbnails,
+		DurationSeconds: videoData.LengthSeconds,
+		ViewCount:       videoData.ViewCount,
+		LikeCount:       videoData.LikeCount,
+		CommentCount:    videoData.CommentCount,
+		IsLiveContent:   videoData.IsLive,
+		IsLive:          videoData.IsLiveNow,
+		IsUpcoming:      videoData.IsUpcoming,
+		IsPrivate:       videoData.IsPrivate,
+		IsUnlisted:      videoData.IsUnlisted,
+		IsFamilySafe:    videoData.IsFamilySafe,
+	}
+
+	if videoData.LengthSeconds > 0 {
+		info.Duration = formatClock(videoData.LengthSeconds)
+	}
+	if date, ok := parsePageDate(videoData.PublishedAt); ok {
+		info.PublishDate = date
+	}
+	if date, ok := parsePageDate(videoData.UploadDate); ok {
+		info.UploadDate = date
+	}
+
+	// The largest thumbnail is listed last
+	if n := len(info.Thumbnails); n > 0 {
+		info.ThumbnailURL = info.Thumbnails[n-1].URL
+	}
+
+	return info
+}
+
+// parsePageDate parses the dates found in page data, either a plain date or a full RFC 3339 timestamp
+func parsePageDate(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
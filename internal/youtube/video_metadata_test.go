@@ -0,0 +1,72 @@
+package youtube
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVideoInfoFromVideoData(t *testing.T) {
+	html := `<script>var ytInitialPlayerResponse = {"videoDetails":{"videoId":"abc","title":"Demo};","shortDescription":"desc","channelId":"UC1","author":"Chan",` +
+		`"lengthSeconds":"3723","viewCount":"1500","keywords":["go","mcp"],"isPrivate":false,"isLiveContent":true,"isLive":false,` +
+		`"thumbnail":{"thumbnails":[{"url":"https://i.ytimg.com/small.jpg","width":120,"height":90},{"url":"https://i.ytimg.com/large.jpg","width":1280,"height":720}]}},` +
+		`"microformat":{"playerMicroformatRenderer":{"publishDate":"2024-03-01T08:00:00-08:00","uploadDate":"2024-02-28","category":"Education","isUnlisted":true,"isFamilySafe":true,` +
+		`"liveBroadcastDetails":{"isLiveNow":false,"startTimestamp":"2024-03-01T08:00:00-08:00"}}}};</script>` +
+		`<script>var ytInitialData = {"frameworkUpdates":{"entityBatchUpdate":{"mutations":[{"payload":{}},{"payload":{"likeCountEntity":{"likeCountIfIndifferentNumber":"18234"}}}]}},` +
+		`"engagementPanels":[{"engagementPanelSectionListRenderer":{"panelIdentifier":"engagement-panel-comments-section","header":{"engagementPanelTitleHeaderRenderer":{"contextualInfo":{"runs":[{"text":"1.2K"}]}}}}}]};</script>`
+
+	service := &Service{}
+	videoData, err := service.parseVideoData(html, "abc")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	info := videoInfoFromVideoData(videoData)
+	if info.Title != "Demo};" {
+		t.Errorf("Expected title to survive braces, got %q", info.Title)
+	}
+	if info.Duration != "1:02:03" || info.DurationSeconds != 3723 {
+		t.Errorf("Unexpected duration %q (%v)", info.Duration, info.DurationSeconds)
+	}
+	if info.Category != "Education" || len(info.Keywords) != 2 {
+		t.Errorf("Unexpected category/keywords: %q %v", info.Category, info.Keywords)
+	}
+	if info.LikeCount != 18234 || info.CommentCount != 1200 || info.ViewCount != 1500 {
+		t.Errorf("Unexpected counts: likes=%d comments=%d views=%d", info.LikeCount, info.CommentCount, info.ViewCount)
+	}
+	if !info.IsUnlisted || !info.IsFamilySafe || !info.IsLiveContent || info.IsLive || info.IsPrivate {
+		t.Errorf("Unexpected flags: %+v", info)
+	}
+	if info.ThumbnailURL != "https://i.ytimg.com/large.jpg" || len(info.Thumbnails) != 2 {
+		t.Errorf("Unexpected thumbnails: %q %v", info.ThumbnailURL, info.Thumbnails)
+	}
+	if !info.UploadDate.Equal(time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected upload date %v", info.UploadDate)
+	}
+	if !info.PublishDate.Equal(time.Date(2024, 3, 1, 16, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected publish date %v", info.PublishDate)
+	}
+}
+
+func TestParseCompactCount(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+		ok       bool
+	}{
+		{"18,234", 18234, true},
+		{"1.2K", 1200, true},
+		{"3M", 3000000, true},
+		{" 42 ", 42, true},
+		{"", 0, false},
+		{"many", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, ok := parseCompactCount(tt.input)
+			if ok != tt.ok || result != tt.expected {
+				t.Errorf("parseCompactCount(%q) = %d, %v; expected %d, %v", tt.input, result, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package youtube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+// captionFormat is the caption format requested from YouTube when word timings are wanted. json3
+// times every word of automatic captions; tracks that cannot provide it are still served, as XML.
+const captionFormat = "json3"
+
+// json3Transcript is YouTube's json3 caption format. Events without segs only position caption
+// windows.
+type json3Transcript struct {
+	Events []struct {
+		StartMs    float64 `json:"tStartMs"`
+		DurationMs float64 `json:"dDurationMs"`
+		Segs       []struct {
+			Text     string  `json:"utf8"`
+			OffsetMs float64 `json:"tOffsetMs"`
+		} `json:"segs"`
+	} `json:"events"`
+}
+
+// timedWord is a word of caption text with the time it is shown
+type timedWord struct {
+	text  string
+	start float64
+}
+
+// captionFormatURL returns the URL of a caption track in the richer json3 format, unless the URL
+// already asks for a format
+func captionFormatURL(baseURL string) string {
+	parsed, err := url.Parse(baseURL)
+	if err != nil || parsed.Query().Has("fmt") {
+		return baseURL
+	}
+	formatted, err := addURLParam(baseURL, "fmt", captionFormat)
+	if err != nil {
+		return baseURL
+	}
+	return formatted
+}
+
+// parseTranscriptData parses caption data of a track of transcriptType in the json3 format or any
+// of the XML formats
+func (s *Service) parseTranscriptData(data []byte, transcriptType string) ([]models.TranscriptSegment, error) {
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "{") {
+		return s.parseTranscriptJSON3(data, transcriptType)
+	}
+	return s.parseTranscriptXML(data, transcriptType)
+}
+
+// parseTranscriptJSON3 parses YouTube's json3 caption format, keeping the offset of every word
+func (s *Service) parseTranscriptJSON3(data []byte, transcriptType string) ([]models.TranscriptSegment, error) {
+	var transcript json3Transcript
+	if err := json.Unmarshal(data, &transcript); err != nil {
+		return nil, fmt.Errorf("failed to parse json3 transcript: %w", err)
+	}
+
+	var segments []models.TranscriptSegment
+	for _, event := range transcript.Events {
+		var builder strings.Builder
+		words := make([]timedWord, 0, len(event.Segs))
+		// Automatic captions give every word a seg of its own; segs of manual captions are
+		// styled runs of several words without timing
+		timedByWord := true
+		for _, seg := range event.Segs {
+			builder.WriteString(seg.Text)
+			words = append(words, timedWord{text: seg.Text, start: (event.StartMs + seg.OffsetMs) / 1000})
+			timedByWord = timedByWord && len(strings.Fields(seg.Text)) <= 1
+		}
+
+		text := s.cleanTranscriptText(builder.String())
+		if text == "" {
+			continue
+		}
+		duration := event.DurationMs / 1000
+		if duration <= 0 {
+			duration = 2.0 // Default duration
+		}
+		start := event.StartMs / 1000
+		segment := models.TranscriptSegment{
+			Text:     text,
+			Start:    start,
+			Duration: duration,
+			End:      start + duration,
+		}
+		if timedByWord {
+			segment.Words = wordTimings(words, segment.End)
+		}
+		annotateSegment(&segment)
+		segments = append(segments, segment)
+	}
+
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("no transcript segments found in json3 data")
+	}
+	segments = mergeRollingSegments(transcriptType, segments)
+	assignSpeakerTurns(segments)
+
+	s.logger.Debug("Successfully parsed json3 transcript", "segments", len(segments))
+	return segments, nil
+}
+
+// wordTimings turns the timed words of a caption line into transcript words, each lasting until
+// the next one starts and the last one until the line ends
+func wordTimings(words []timedWord, end float64) []models.TranscriptWord {
+	result := make([]models.TranscriptWord, 0, len(words))
+	for _, word := range words {
+		text := strings.TrimSpace(word.text)
+		if text == "" {
+			continue
+		}
+		if n := len(result); n > 0 {
+			result[n-1].Duration = max(0, word.start-result[n-1].Start)
+		}
+		result = append(result, models.TranscriptWord{Text: text, Start: word.start})
+	}
+	if n := len(result); n > 0 {
+		result[n-1].Duration = max(0, end-result[n-1].Start)
+	}
+	return result
+}
+
+// withoutWordTimings returns segments without their word timings, copying them only when needed
+func withoutWordTimings(segments []models.TranscriptSegment) []models.TranscriptSegment {
+	for i := range segments {
+		if segments[i].Words == nil {
+			continue
+		}
+		result := append([]models.TranscriptSegment(nil), segments...)
+		for j := range result {
+			result[j].Words = nil
+		}
+		return result
+	}
+	return segments
+}
@@ -0,0 +1,159 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/youtube-transcript-mcp/internal/models"
+)
+
+func TestParseTranscriptJSON3(t *testing.T) {
+	s := &Service{logger: setupTestLogger()}
+
+	segments, err := s.parseTranscriptData(readFixture(t, "captions.json3"), models.TranscriptTypeAuto)
+	if err != nil {
+		t.Fatalf("parseTranscriptData() error = %v", err)
+	}
+
+	expected := []models.TranscriptSegment{
+		{Text: "[Music]", Start: 0, Duration: 2, End: 2, SoundEvents: []string{"music"}, Words: []models.TranscriptWord{
+			{Text: "[Music]", Start: 0, Duration: 2}}},
+		{Text: "welcome back everyone", Start: 2, Duration: 3, End: 5, Words: []models.TranscriptWord{
+			{Text: "welcome", Start: 2, Duration: 0.48}, {Text: "back", Start: 2.48, Duration: 0.48},
+			{Text: "everyone", Start: 2.96, Duration: 2.04}}},
+		{Text: "today we", Start: 6.2, Duration: 1.8, End: 8, Words: []models.TranscriptWord{
+			{Text: "today", Start: 6.2, Duration: 0.5}, {Text: "we", Start: 6.7, Duration: 1.3}}},
+		{Text: "A manual line in italics", Start: 8, Duration: 2.5, End: 10.5},
+	}
+
+	if len(segments) != len(expected) {
+		t.Fatalf("parseTranscriptData() returned %d segments, want %d: %+v", len(segments), len(expected), segments)
+	}
+	for i, segment := range segments {
+		if !reflect.DeepEqual(roundSegment(segment), expected[i]) {
+			t.Errorf("segment %d = %+v, want %+v", i, segment, expected[i])
+		}
+	}
+}
+
+func TestParseTranscriptJSON3Errors(t *testing.T) {
+	s := &Service{logger: setupTestLogger()}
+
+	for _, data := range []string{`{"events": [`, `{"events": [{"tStartMs": 0, "dDurationMs": 1000}]}`} {
+		if _, err := s.parseTranscriptData([]byte(data), models.TranscriptTypeAuto); err == nil {
+			t.Errorf("parseTranscriptData(%q) expected an error", data)
+		}
+	}
+}
+
+func TestCaptionFormatURL(t *testing.T) {
+	tests := []struct {
+		baseURL  string
+		expected string
+	}{
+		{"https://www.youtube.com/api/timedtext?v=abc&lang=en", "https://www.youtube.com/api/timedtext?fmt=json3&lang=en&v=abc"},
+		{"https://www.youtube.com/api/timedtext?v=abc&fmt=srv3", "https://www.youtube.com/api/timedtext?v=abc&fmt=srv3"},
+	}
+
+	for _, tt := range tests {
+		if got := captionFormatURL(tt.baseURL); got != tt.expected {
+			t.Errorf("captionFormatURL(%q) = %q, want %q", tt.baseURL, got, tt.expected)
+		}
+	}
+}
+
+func TestApplyTranscriptOptionsWordTimings(t *testing.T) {
+	s := &Service{}
+	transcript := &models.TranscriptResponse{
+		VideoID: "dQw4w9WgXcQ",
+		Transcript: []models.TranscriptSegment{
+			{Text: "[Music] hello there", Start: 0, Duration: 2, Words: []models.TranscriptWord{
+				{Text: "[Music]", Start: 0, Duration: 1}, {Text: "hello", Start: 1, Duration: 0.5}, {Text: "there", Start: 1.5, Duration: 0.5}}},
+		},
+	}
+
+	result := s.applyTranscriptOptions(transcript, models.TranscriptOptions{}, 0)
+	if result.Transcript[0].Words != nil {
+		t.Errorf("word timings returned without include_word_timings: %+v", result.Transcript[0].Words)
+	}
+	if transcript.Transcript[0].Words == nil {
+		t.Error("applyTranscriptOptions() removed the word timings of the cached transcript")
+	}
+
+	result = s.applyTranscriptOptions(transcript, models.TranscriptOptions{
+		IncludeWordTimings: true,
+		Annotations:        models.AnnotationModeStrip,
+	}, 0)
+	expected := []models.TranscriptWord{{Text: "hello", Start: 1, Duration: 0.5}, {Text: "there", Start: 1.5, Duration: 0.5}}
+	if !reflect.DeepEqual(result.Transcript[0].Words, expected) {
+		t.Errorf("words = %+v, want %+v", result.Transcript[0].Words, expected)
+	}
+}
+
+// wordTimingsMux serves an auto-generated track and records the caption format of every request
+func wordTimingsMux(formats *[]string) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/watch", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<script>var ytInitialPlayerResponse = {"videoDetails":{"videoId":"dQw4w9WgXcQ","title":"Demo"},"captions":{"playerCaptionsTracklistRenderer":{`+
+			`"captionTracks":[{"baseUrl":"http://%s/api/timedtext?lang=en&kind=asr","languageCode":"en","kind":"asr","isTranslatable":true}]}}};</script>`, r.Host)
+	})
+	mux.HandleFunc("/api/timedtext", func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("fmt")
+		*formats = append(*formats, format)
+		if format == captionFormat {
+			_, _ = fmt.Fprint(w, `{"events":[{"tStartMs":0,"dDurationMs":2000,"segs":[{"utf8":"hello"},{"utf8":" there","tOffsetMs":500}]}]}`)
+			return
+		}
+		// srv3 times words as well, but they are only returned when asked for
+		_, _ = fmt.Fprint(w, `<timedtext format="3"><body><p t="0" d="2000"><s>hello</s><s t="500"> there</s></p></body></timedtext>`)
+	})
+	return mux
+}
+
+func TestGetTranscriptFetchesWordTimingsOnlyWhenAsked(t *testing.T) {
+	var formats []string
+	service := newTestService(t, wordTimingsMux(&formats))
+	ctx := context.Background()
+
+	plain, err := service.GetTranscriptWithOptions(ctx, "dQw4w9WgXcQ", nil, true, models.TranscriptOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if plain.Transcript[0].Words != nil {
+		t.Errorf("word timings returned without include_word_timings: %+v", plain.Transcript[0].Words)
+	}
+
+	timed, err := service.GetTranscriptWithOptions(ctx, "dQw4w9WgXcQ", nil, true, models.TranscriptOptions{IncludeWordTimings: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []models.TranscriptWord{{Text: "hello", Start: 0, Duration: 0.5}, {Text: "there", Start: 0.5, Duration: 1.5}}
+	if !reflect.DeepEqual(timed.Transcript[0].Words, expected) {
+		t.Errorf("words = %+v, want %+v", timed.Transcript[0].Words, expected)
+	}
+
+	if got := strings.Join(formats, ","); got != ","+captionFormat {
+		t.Errorf("requested caption formats %q, want json3 only for word timings", got)
+	}
+}
+
+func TestEnhancedServiceFetchesWordTimings(t *testing.T) {
+	var formats []string
+	service := NewEnhancedService(newTestService(t, wordTimingsMux(&formats)))
+
+	timed, err := service.GetTranscriptWithOptions(context.Background(), "dQw4w9WgXcQ", nil, true, models.TranscriptOptions{IncludeWordTimings: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []models.TranscriptWord{{Text: "hello", Start: 0, Duration: 0.5}, {Text: "there", Start: 0.5, Duration: 1.5}}
+	if !reflect.DeepEqual(timed.Transcript[0].Words, expected) {
+		t.Errorf("words = %+v, want %+v", timed.Transcript[0].Words, expected)
+	}
+	if got := strings.Join(formats, ","); got != captionFormat {
+		t.Errorf("requested caption formats %q, want %q", got, captionFormat)
+	}
+}